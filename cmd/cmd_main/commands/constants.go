@@ -390,9 +390,9 @@ DerivedData/
     "protocol": "tcp",
     "async": true,
     "reflection": true,
-    "configs": {
-      "maxReceiveMessageSize": 104857600,
-      "maxSendMessageSize": 1048576000
+    "limits": {
+      "max_receive_message_size": 104857600,
+      "max_send_message_size": 1048576000
     }
   }
 }`
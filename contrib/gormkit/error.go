@@ -1,6 +1,12 @@
 package gormkit
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"gorm.io/gorm"
+)
 
 // NotImplementedErr Error
 type NotImplementedErr struct {
@@ -11,6 +17,11 @@ func (err *NotImplementedErr) Error() string {
 	return fmt.Sprintf("Not Implemented Yet")
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotImplementedErr) ErrorCode() errs.Code {
+	return errs.Unimplemented
+}
+
 // NewNotImplementedErr - return a new instance of NotImplementedErr
 func NewNotImplementedErr() error {
 	return &NotImplementedErr{}
@@ -26,6 +37,16 @@ func (err *CreateSqlWrapperErr) Error() string {
 	return fmt.Sprintf("Create a new sql wrapper encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateSqlWrapperErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CreateSqlWrapperErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewCreateSqlWrapperErr - return a new instance of CreateSqlWrapperErr
 func NewCreateSqlWrapperErr(err error) error {
 	return &CreateSqlWrapperErr{Err: err}
@@ -41,6 +62,11 @@ func (err *NotSupportedDbTypeErr) Error() string {
 	return fmt.Sprintf("Not Supported Database Dialect: %v", err.dbType)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotSupportedDbTypeErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
 // NewNotSupportedDbTypeErr - return a new instance of NotSupportedDbTypeErr
 func NewNotSupportedDbTypeErr(dbType string) error {
 	return &NotSupportedDbTypeErr{dbType: dbType}
@@ -56,6 +82,11 @@ func (err *NotExistServiceNameErr) Error() string {
 	return fmt.Sprintf("Instance with service name not exist: %v", err.serviceName)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotExistServiceNameErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewNotExistServiceNameErr - return a new instance of NotExistServiceNameErr
 func NewNotExistServiceNameErr(serviceName string) error {
 	return &NotExistServiceNameErr{serviceName: serviceName}
@@ -71,6 +102,16 @@ func (err *MigrateErr) Error() string {
 	return fmt.Sprintf("Migrating tables got error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MigrateErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MigrateErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Internal)
+}
+
 // NewMigrateErr - return a new instance of MigrateErr
 func NewMigrateErr(err error) error {
 	return &MigrateErr{Err: err}
@@ -87,6 +128,16 @@ func (err *SelectQueryErr) Error() string {
 	return fmt.Sprintf("Select query (`%v`) encouters error: %v", err.query, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *SelectQueryErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *SelectQueryErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewSelectQueryErr - return a new instance of SelectQueryErr
 func NewSelectQueryErr(q string, err error) error {
 	return &SelectQueryErr{query: q, Err: err}
@@ -104,6 +155,16 @@ func (err *DeleteModelErr) Error() string {
 	return fmt.Sprintf("Deleting a record from (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *DeleteModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *DeleteModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewDeleteModelErr - return a new instance of DeleteModelErr
 func NewDeleteModelErr(table string, data any, err error) error {
 	return &DeleteModelErr{table: table, data: data, Err: err}
@@ -121,6 +182,16 @@ func (err *InsertModelErr) Error() string {
 	return fmt.Sprintf("Inserting a record to (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *InsertModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *InsertModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewInsertModelErr - return a new instance of InsertModelErr
 func NewInsertModelErr(table string, data any, err error) error {
 	return &InsertModelErr{table: table, data: data, Err: err}
@@ -138,7 +209,28 @@ func (err *UpdateModelErr) Error() string {
 	return fmt.Sprintf("Updating record(s) in (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *UpdateModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *UpdateModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewUpdateModelErr - return a new instance of UpdateModelErr
 func NewUpdateModelErr(table string, data any, err error) error {
 	return &UpdateModelErr{table: table, data: data, Err: err}
 }
+
+// queryCode - return the code of the error of a query, the missing records are `NotFound` and the duplicated keys are `AlreadyExists`
+func queryCode(err error) errs.Code {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errs.NotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return errs.AlreadyExists
+	}
+	return errs.CodeOr(err, errs.Internal)
+}
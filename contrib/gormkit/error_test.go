@@ -0,0 +1,35 @@
+package gormkit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"gorm.io/gorm"
+)
+
+func TestQueryErr_CodeAndUnwrap(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		root     error
+		expected errs.Code
+	}{
+		{"missing record", NewSelectQueryErr("select * from users", gorm.ErrRecordNotFound), gorm.ErrRecordNotFound, errs.NotFound},
+		{"duplicated key", NewInsertModelErr("users", nil, gorm.ErrDuplicatedKey), gorm.ErrDuplicatedKey, errs.AlreadyExists},
+		{"other", NewUpdateModelErr("users", nil, gorm.ErrInvalidData), gorm.ErrInvalidData, errs.Internal},
+		{"migration", NewMigrateErr(gorm.ErrInvalidData), gorm.ErrInvalidData, errs.Internal},
+	}
+	for _, item := range cases {
+		if !errors.Is(item.err, item.root) {
+			t.Errorf("Unwrap of %v --> Expected: %v, but got %v", item.name, item.root, item.err)
+		}
+		if code := errs.CodeOf(item.err); code != item.expected {
+			t.Errorf("Code of %v --> Expected: %v, but got %v", item.name, item.expected, code)
+		}
+	}
+
+	if code := errs.CodeOf(NewNotExistServiceNameErr("main")); code != errs.NotFound {
+		t.Errorf("Code of the unknown service --> Expected: %v, but got %v", errs.NotFound, code)
+	}
+}
@@ -1,6 +1,12 @@
 package mongokit
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"go.mongodb.org/mongo-driver/mongo"
+)
 
 // NotExistServiceNameErr Error
 type NotExistServiceNameErr struct {
@@ -12,6 +18,11 @@ func (err *NotExistServiceNameErr) Error() string {
 	return fmt.Sprintf("Instance with service name not exist: %v", err.serviceName)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotExistServiceNameErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewNotExistServiceNameErr - return a new instance of NotExistServiceNameErr
 func NewNotExistServiceNameErr(serviceName string) error {
 	return &NotExistServiceNameErr{serviceName: serviceName}
@@ -27,6 +38,16 @@ func (err *CreateMongoWrapperErr) Error() string {
 	return fmt.Sprintf("Create a new mongo wrapper encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateMongoWrapperErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CreateMongoWrapperErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewCreateMongoWrapperErr - return a new instance of CreateMongoWrapperErr
 func NewCreateMongoWrapperErr(err error) error {
 	return &CreateMongoWrapperErr{Err: err}
@@ -44,6 +65,16 @@ func (err *MongoFindQueryErr) Error() string {
 	return fmt.Sprintf("Find query on (`%s`) with (%v) filter encouters error: %v", err.collection, err.filter, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MongoFindQueryErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MongoFindQueryErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewMongoFindQueryErr - return a new instance of MongoFindQueryErr
 func NewMongoFindQueryErr(collection string, filter any, err error) error {
 	return &MongoFindQueryErr{collection: collection, filter: filter, Err: err}
@@ -61,7 +92,28 @@ func (err *MongoDeleteErr) Error() string {
 	return fmt.Sprintf("Delete query on (`%s`) with (%v) filter encouters error: %v", err.collection, err.filter, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MongoDeleteErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MongoDeleteErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewMongoDeleteErr - return a new instance of MongoDeleteErr
 func NewMongoDeleteErr(collection string, filter any, err error) error {
 	return &MongoDeleteErr{collection: collection, filter: filter, Err: err}
 }
+
+// queryCode - return the code of the error of a query, the missing documents are `NotFound` and the duplicated keys are `AlreadyExists`
+func queryCode(err error) errs.Code {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return errs.NotFound
+	case mongo.IsDuplicateKeyError(err):
+		return errs.AlreadyExists
+	}
+	return errs.CodeOr(err, errs.Internal)
+}
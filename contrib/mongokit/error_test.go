@@ -0,0 +1,30 @@
+package mongokit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestQueryErr_CodeAndUnwrap(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		root     error
+		expected errs.Code
+	}{
+		{"missing document", NewMongoFindQueryErr("users", nil, mongo.ErrNoDocuments), mongo.ErrNoDocuments, errs.NotFound},
+		{"other", NewMongoDeleteErr("users", nil, mongo.ErrClientDisconnected), mongo.ErrClientDisconnected, errs.Internal},
+		{"connection", NewCreateMongoWrapperErr(mongo.ErrClientDisconnected), mongo.ErrClientDisconnected, errs.Unavailable},
+	}
+	for _, item := range cases {
+		if !errors.Is(item.err, item.root) {
+			t.Errorf("Unwrap of %v --> Expected: %v, but got %v", item.name, item.root, item.err)
+		}
+		if code := errs.CodeOf(item.err); code != item.expected {
+			t.Errorf("Code of %v --> Expected: %v, but got %v", item.name, item.expected, code)
+		}
+	}
+}
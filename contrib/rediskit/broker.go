@@ -0,0 +1,27 @@
+package rediskit
+
+import (
+	"context"
+	"io"
+)
+
+// Broker shares pub/sub messages between service instances through a configured Redis client,
+// it can be passed to `http.Hub.UseBroker` to fan out websocket and server-sent events broadcasts
+type Broker struct {
+	clientName string
+}
+
+// NewBroker creates a broker on top of the Redis client instance with the name
+func NewBroker(clientName string) *Broker {
+	return &Broker{clientName: clientName}
+}
+
+// Publish sends the payload to the channel
+func (b *Broker) Publish(ctx context.Context, channel string, payload []byte) error {
+	return Publish(ctx, b.clientName, channel, payload)
+}
+
+// Subscribe calls the handler for every payload published to the channel until the returned closer is closed
+func (b *Broker) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error) {
+	return Subscribe(ctx, b.clientName, channel, handler)
+}
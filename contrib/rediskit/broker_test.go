@@ -0,0 +1,134 @@
+package rediskit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/realtime"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// pubSubServer - a Redis server which only answers SUBSCRIBE and PUBLISH, the other commands are answered by an error
+type pubSubServer struct {
+	listener    net.Listener
+	lock        sync.Mutex
+	subscribers map[string][]net.Conn
+	published   []string
+}
+
+func newPubSubServer(t *testing.T) *pubSubServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening --> Expected: %v, but got %v", nil, err)
+	}
+	s := &pubSubServer{listener: listener, subscribers: make(map[string][]net.Conn)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { _ = listener.Close() })
+	return s
+}
+
+func (s *pubSubServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		s.lock.Lock()
+		switch strings.ToUpper(args[0]) {
+		case "SUBSCRIBE":
+			s.subscribers[args[1]] = append(s.subscribers[args[1]], conn)
+			_, _ = fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+		case "PUBLISH":
+			s.published = append(s.published, args[1])
+			for _, subscriber := range s.subscribers[args[1]] {
+				_, _ = fmt.Fprintf(subscriber, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(args[2]), args[2])
+			}
+			_, _ = fmt.Fprintf(conn, ":%d\r\n", len(s.subscribers[args[1]]))
+		default:
+			_, _ = fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.lock.Unlock()
+	}
+}
+
+// readCommand - read a command of the RESP protocol, it is an array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		value, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(value, "\r\n")
+	}
+	return args, nil
+}
+
+type hubClient struct {
+	messages chan realtime.Message
+	done     chan struct{}
+}
+
+func (c *hubClient) ID() string                      { return "member" }
+func (c *hubClient) Done() <-chan struct{}           { return c.done }
+func (c *hubClient) Send(msg realtime.Message) error { c.messages <- msg; return nil }
+
+func TestBroker_BroadcastWithTenant(t *testing.T) {
+	server := newPubSubServer(t)
+	newClient := func() *Client {
+		return &Client{prefix: "app", initialized: true, client: redis.NewClient(&redis.Options{Addr: server.listener.Addr().String(), DisableIndentity: true})}
+	}
+	publisher, subscriber := newClient(), newClient()
+	defer publisher.client.Close()
+	defer subscriber.client.Close()
+
+	// the hubs of two instances, the second one has the member of the room
+	hub1, hub2 := realtime.NewHub("chat"), realtime.NewHub("chat")
+	assert.NoError(t, hub1.UseBroker(context.Background(), publisher))
+	assert.NoError(t, hub2.UseBroker(context.Background(), subscriber))
+	member := &hubClient{messages: make(chan realtime.Message, 1), done: make(chan struct{})}
+	hub2.Join("room", member)
+
+	// the hub subscribes without a tenant, so the broadcast of a tenant request is published to the same channel
+	ctx := tenancy.WithTenant(context.Background(), "acme")
+	assert.NoError(t, hub1.Broadcast(ctx, "room", realtime.Message{Data: []byte("hello")}))
+
+	select {
+	case msg := <-member.messages:
+		assert.Equal(t, "hello", string(msg.Data))
+	case <-time.After(5 * time.Second):
+		t.Errorf("Broadcast with tenant --> Expected: %v, but got %v", "hello", "no message")
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	assert.Equal(t, []string{"app$gonyx:hub:chat"}, server.published)
+}
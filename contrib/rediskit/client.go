@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"github.com/Blocktunium/gonyx/internal/config"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
 	"github.com/redis/go-redis/v9"
 	"sync"
 	"time"
@@ -180,7 +182,7 @@ func (c *Client) Close() error {
 func (c *Client) Get(ctx context.Context, key string, val any) error {
 	c.wg.Wait()
 
-	err := c.client.Get(ctx, c.generateKey(key)).Scan(val)
+	err := c.client.Get(ctx, c.generateKey(ctx, key)).Scan(val)
 	if err != nil {
 		return NewReadError(key, err)
 	}
@@ -191,7 +193,7 @@ func (c *Client) Get(ctx context.Context, key string, val any) error {
 func (c *Client) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
 	c.wg.Wait()
 
-	err := c.client.Set(ctx, c.generateKey(key), val, expiration).Err()
+	err := c.client.Set(ctx, c.generateKey(ctx, key), val, expiration).Err()
 	if err != nil {
 		return NewWriteError(key, val, err)
 	}
@@ -234,12 +236,12 @@ func (c *Client) GetStruct(ctx context.Context, key string, val any) error {
 func (c *Client) HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error {
 	c.wg.Wait()
 
-	err := c.client.HSet(ctx, c.generateKey(key), val...).Err()
+	err := c.client.HSet(ctx, c.generateKey(ctx, key), val...).Err()
 	if err != nil {
 		return NewWriteError(key, val, err)
 	}
 
-	err = c.client.Expire(ctx, c.generateKey(key), expiration).Err()
+	err = c.client.Expire(ctx, c.generateKey(ctx, key), expiration).Err()
 	if err != nil {
 		return NewWriteError(key, val, err)
 	}
@@ -250,7 +252,7 @@ func (c *Client) HSet(ctx context.Context, key string, expiration time.Duration,
 func (c *Client) HGet(ctx context.Context, key string, field string, val any) error {
 	c.wg.Wait()
 
-	cmd := c.client.HGet(ctx, c.generateKey(key), field)
+	cmd := c.client.HGet(ctx, c.generateKey(ctx, key), field)
 	err := cmd.Scan(val)
 	if err != nil {
 		return NewReadError(fmt.Sprintf("%s:%s", key, field), err)
@@ -258,11 +260,54 @@ func (c *Client) HGet(ctx context.Context, key string, field string, val any) er
 	return nil
 }
 
-// generateKey creates a prefixed key for Redis
-func (c *Client) generateKey(key string) string {
+// Publish sends the payload to the Redis pub/sub channel, the channel is not scoped to the tenant of the context
+func (c *Client) Publish(ctx context.Context, channel string, payload []byte) error {
+	c.wg.Wait()
+
+	err := c.client.Publish(ctx, c.generateChannel(channel), payload).Err()
+	if err != nil {
+		return NewPublishError(channel, err)
+	}
+	return nil
+}
+
+// Subscribe listens on the Redis pub/sub channel and calls the handler for every message until the returned closer is closed
+// The channel is not scoped to the tenant of the context like Publish
+func (c *Client) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error) {
+	c.wg.Wait()
+
+	pubSub := c.client.Subscribe(ctx, c.generateChannel(channel))
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+		return nil, NewSubscribeError(channel, err)
+	}
+
+	go func() {
+		for msg := range pubSub.Channel() {
+			handler([]byte(msg.Payload))
+		}
+	}()
+
+	return pubSub, nil
+}
+
+// generateKey creates a prefixed key for Redis, the key is scoped to the tenant of the context, e.g. "prefix$acme$key"
+func (c *Client) generateKey(ctx context.Context, key string) string {
 	newKey := key
+	if tenantPrefix := tenancy.KeyPrefix(ctx); tenantPrefix != "" {
+		newKey = tenantPrefix + "$" + newKey
+	}
 	if c.prefix != "" {
 		newKey = c.prefix + "$" + newKey
 	}
 	return newKey
 }
+
+// generateChannel creates a prefixed channel for Redis pub/sub, e.g. "prefix$channel"
+// The channels are shared by all tenants, since a subscriber, e.g. the broker of a hub, receives the messages of every tenant
+func (c *Client) generateChannel(channel string) string {
+	if c.prefix != "" {
+		return c.prefix + "$" + channel
+	}
+	return channel
+}
@@ -2,6 +2,7 @@ package rediskit
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
@@ -64,6 +65,19 @@ func (m *MockRedisClient) HGet(ctx context.Context, key, field string, val any)
 	return args.Error(0)
 }
 
+func (m *MockRedisClient) Publish(ctx context.Context, channel string, payload []byte) error {
+	args := m.Called(ctx, channel, payload)
+	return args.Error(0)
+}
+
+func (m *MockRedisClient) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error) {
+	args := m.Called(ctx, channel, handler)
+	if closer, ok := args.Get(0).(io.Closer); ok {
+		return closer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // Basic tests for the Client struct methods
 func TestNewClient(t *testing.T) {
 	client := &Client{}
@@ -73,10 +87,10 @@ func TestNewClient(t *testing.T) {
 
 func TestClient_generateKey(t *testing.T) {
 	client := &Client{prefix: "test"}
-	key := client.generateKey("example")
+	key := client.generateKey(context.Background(), "example")
 	assert.Equal(t, "test$example", key)
 
 	client = &Client{prefix: ""}
-	key = client.generateKey("example")
+	key = client.generateKey(context.Background(), "example")
 	assert.Equal(t, "example", key)
 }
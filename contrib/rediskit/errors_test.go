@@ -0,0 +1,32 @@
+package rediskit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestError_CodeAndUnwrap(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		root     error
+		expected errs.Code
+	}{
+		{"missing key", NewReadError("users", redis.Nil), redis.Nil, errs.NotFound},
+		{"read", NewReadError("users", redis.ErrClosed), redis.ErrClosed, errs.Unavailable},
+		{"write", NewWriteError("users", "value", redis.ErrClosed), redis.ErrClosed, errs.Unavailable},
+		{"publish", NewPublishError("chat", redis.ErrClosed), redis.ErrClosed, errs.Unavailable},
+		{"subscribe", NewSubscribeError("chat", redis.ErrClosed), redis.ErrClosed, errs.Unavailable},
+	}
+	for _, item := range cases {
+		if !errors.Is(item.err, item.root) {
+			t.Errorf("Unwrap of %v --> Expected: %v, but got %v", item.name, item.root, item.err)
+		}
+		if code := errs.CodeOf(item.err); code != item.expected {
+			t.Errorf("Code of %v --> Expected: %v, but got %v", item.name, item.expected, code)
+		}
+	}
+}
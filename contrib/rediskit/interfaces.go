@@ -2,6 +2,7 @@ package rediskit
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -17,4 +18,6 @@ type IRedisClient interface {
 	GetStruct(ctx context.Context, key string, val any) error
 	HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error
 	HGet(ctx context.Context, key string, field string, val any) error
+	Publish(ctx context.Context, channel string, payload []byte) error
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error)
 }
@@ -2,6 +2,7 @@ package rediskit
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -74,3 +75,23 @@ func Ping(ctx context.Context, clientName string) error {
 
 	return client.Ping(ctx)
 }
+
+// Publish sends the payload to the pub/sub channel with the specified client instance
+func Publish(ctx context.Context, clientName string, channel string, payload []byte) error {
+	client, err := GetManager().GetClient(clientName)
+	if err != nil {
+		return err
+	}
+
+	return client.Publish(ctx, channel, payload)
+}
+
+// Subscribe listens on the pub/sub channel with the specified client instance
+func Subscribe(ctx context.Context, clientName string, channel string, handler func(payload []byte)) (io.Closer, error) {
+	client, err := GetManager().GetClient(clientName)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Subscribe(ctx, channel, handler)
+}
@@ -1,6 +1,12 @@
 package cache
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/redis/go-redis/v9"
+)
 
 // Error object
 type Error struct {
@@ -12,6 +18,16 @@ func (err *Error) Error() string {
 	return fmt.Sprintf("Cache Error | %v", err.Err.Error())
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *Error) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewError - return a new instance of Error
 func NewError(err error) error {
 	return &Error{Err: err}
@@ -28,6 +44,16 @@ func (err *ReadError) Error() string {
 	return fmt.Sprintf("Cache Read Error: key = %s | %v", err.Key, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *ReadError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ReadError) ErrorCode() errs.Code {
+	return readCode(err.Err)
+}
+
 // NewReadError - return a new instance of ReadError
 func NewReadError(key string, err error) error {
 	return &ReadError{
@@ -48,6 +74,16 @@ func (err *WriteError) Error() string {
 	return fmt.Sprintf("Cache Write Error: key = %s / value = %v | %v", err.Key, err.Value, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *WriteError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *WriteError) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewWriteError - return a new instance of WriteError
 func NewWriteError(key string, value any, err error) error {
 	return &WriteError{
@@ -67,7 +103,25 @@ func (err *PingError) Error() string {
 	return fmt.Sprintf("Cache Ping Error | %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *PingError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *PingError) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewPingError - return a new instance of PingError
 func NewPingError(err error) error {
 	return &PingError{Err: err}
 }
+
+// readCode - return the code of the error of a read, the missing keys are `NotFound`
+func readCode(err error) errs.Code {
+	if errors.Is(err, redis.Nil) {
+		return errs.NotFound
+	}
+	return errs.CodeOr(err, errs.Unavailable)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Blocktunium/gonyx/internal/config"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
 	"github.com/redis/go-redis/v9"
 	"sync"
 	"time"
@@ -187,7 +188,7 @@ func (ins *RedisClientCache) Close() error {
 func (ins *RedisClientCache) Get(ctx context.Context, key string, val any) error {
 	ins.wg.Wait()
 
-	err := ins.client.Get(ctx, ins.generateKey(key)).Scan(val)
+	err := ins.client.Get(ctx, ins.generateKey(ctx, key)).Scan(val)
 	if err != nil {
 		return NewReadError(key, err)
 	}
@@ -198,7 +199,7 @@ func (ins *RedisClientCache) Get(ctx context.Context, key string, val any) error
 func (ins *RedisClientCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
 	ins.wg.Wait()
 
-	err := ins.client.Set(ctx, ins.generateKey(key), val, expiration).Err()
+	err := ins.client.Set(ctx, ins.generateKey(ctx, key), val, expiration).Err()
 	if err != nil {
 		return NewWriteError(key, val, err)
 	}
@@ -220,12 +221,12 @@ func (ins *RedisClientCache) SetStruct(ctx context.Context, key string, val any,
 
 	//
 	//
-	//err := ins.client.HSet(ctx, ins.generateKey(key), val).Err()
+	//err := ins.client.HSet(ctx, ins.generateKey(ctx, key), val).Err()
 	//if err != nil {
 	//	return NewWriteError(key, val, err)
 	//}
 	//
-	//err = ins.client.Expire(ctx, ins.generateKey(key), expiration).Err()
+	//err = ins.client.Expire(ctx, ins.generateKey(ctx, key), expiration).Err()
 	//if err != nil {
 	//	return NewWriteError(key, val, err)
 	//}
@@ -252,8 +253,13 @@ func (ins *RedisClientCache) GetStruct(ctx context.Context, key string, val any)
 }
 
 // MARK: Private Receivers
-func (ins *RedisClientCache) generateKey(key string) string {
+
+// generateKey - return the prefixed key, the key is scoped to the tenant of the context, e.g. "prefix$acme$key"
+func (ins *RedisClientCache) generateKey(ctx context.Context, key string) string {
 	newKey := key
+	if tenantPrefix := tenancy.KeyPrefix(ctx); tenantPrefix != "" {
+		newKey = tenantPrefix + "$" + newKey
+	}
 	if ins.prefix != "" {
 		newKey = ins.prefix + "$" + newKey
 	}
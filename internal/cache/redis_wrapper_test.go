@@ -1 +1,26 @@
 package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+)
+
+func TestRedisClientCache_GenerateKey(t *testing.T) {
+	_ = config.CreateManager("../..", "test", "Gonyx")
+	tenancy.GetManager().RegisterTenant(tenancy.Tenant{ID: "acme", KeyPrefix: "t-acme"})
+
+	ins := &RedisClientCache{prefix: "app"}
+	testCases := map[string]context.Context{
+		"app$users":        context.Background(),
+		"app$t-acme$users": tenancy.WithTenant(context.Background(), "acme"),
+		"app$globex$users": tenancy.WithTenant(context.Background(), "globex"),
+	}
+	for expected, ctx := range testCases {
+		if key := ins.generateKey(ctx, "users"); key != expected {
+			t.Errorf("Generated key --> Expected: %v, but got %v", expected, key)
+		}
+	}
+}
@@ -25,6 +25,9 @@ func NewCompileCommandCmd() *cobra.Command {
 		Run:   runCompileCmdExecute,
 		RunE:  runCompileCmdExecuteE,
 	}
+	createCmd.Flags().Bool("gateway", false, "Generate the gRPC-Gateway stubs of the `google.api.http` annotations (protoc-gen-grpc-gateway is needed)")
+	createCmd.Flags().Bool("validate", false, "Generate the `Validate` and `ValidateAll` methods of the `validate.rules` annotations (protoc-gen-validate is needed)")
+	createCmd.Flags().StringSliceP("proto_path", "I", nil, "The directories of the imported protobuf files, e.g. the one of `google/api/annotations.proto`")
 	return createCmd
 }
 
@@ -80,12 +83,12 @@ func runCompileCmdExecute(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	gateway, _ := cmd.Flags().GetBool("gateway")
+	validate, _ := cmd.Flags().GetBool("validate")
+	protoPaths, _ := cmd.Flags().GetStringSlice("proto_path")
+
 	protoCmd := exec.Command("protoc")
-	protoCmd.Args = append(protoCmd.Args, fmt.Sprintf("--go_out=./%s", args[0]))
-	protoCmd.Args = append(protoCmd.Args, fmt.Sprintf("--go_opt=paths=source_relative"))
-	protoCmd.Args = append(protoCmd.Args, fmt.Sprintf("--go-grpc_out=./%s", args[0]))
-	protoCmd.Args = append(protoCmd.Args, fmt.Sprintf("--go-grpc_opt=paths=source_relative"))
-	protoCmd.Args = append(protoCmd.Args, fmt.Sprintf("%s.proto", args[0]))
+	protoCmd.Args = append(protoCmd.Args, protocArgs(args[0], gateway, validate, protoPaths)...)
 
 	protocErr := protoCmd.Start()
 	if protocErr != nil {
@@ -101,3 +104,31 @@ func runCompileCmdExecute(cmd *cobra.Command, args []string) {
 		return
 	}
 }
+
+// protocArgs - return the arguments of protoc to compile the protobuf file into the folder of its name
+// The validation methods are checked by the validation interceptor of the grpc servers and by the binding of the http requests
+func protocArgs(name string, gateway bool, validate bool, protoPaths []string) []string {
+	var args []string
+
+	// the folder of the file must be listed too if any import path is given
+	if len(protoPaths) > 0 {
+		args = append(args, "--proto_path=.")
+		for _, item := range protoPaths {
+			args = append(args, fmt.Sprintf("--proto_path=%s", item))
+		}
+	}
+
+	args = append(args, fmt.Sprintf("--go_out=./%s", name))
+	args = append(args, "--go_opt=paths=source_relative")
+	args = append(args, fmt.Sprintf("--go-grpc_out=./%s", name))
+	args = append(args, "--go-grpc_opt=paths=source_relative")
+	if gateway {
+		args = append(args, fmt.Sprintf("--grpc-gateway_out=./%s", name))
+		args = append(args, "--grpc-gateway_opt=paths=source_relative")
+	}
+	if validate {
+		args = append(args, fmt.Sprintf("--validate_out=lang=go,paths=source_relative:./%s", name))
+	}
+	args = append(args, fmt.Sprintf("%s.proto", name))
+	return args
+}
@@ -380,3 +380,20 @@ func TestCompileCommand_DirectoryCreation(t *testing.T) {
 	err = os.Mkdir(newDir, os.ModePerm)
 	assert.Error(t, err, "Creating existing directory should return error")
 }
+
+func TestProtocArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--go_out=./greeter", "--go_opt=paths=source_relative",
+		"--go-grpc_out=./greeter", "--go-grpc_opt=paths=source_relative",
+		"greeter.proto",
+	}, protocArgs("greeter", false, false, nil))
+
+	assert.Equal(t, []string{
+		"--proto_path=.", "--proto_path=third_party",
+		"--go_out=./greeter", "--go_opt=paths=source_relative",
+		"--go-grpc_out=./greeter", "--go-grpc_opt=paths=source_relative",
+		"--grpc-gateway_out=./greeter", "--grpc-gateway_opt=paths=source_relative",
+		"--validate_out=lang=go,paths=source_relative:./greeter",
+		"greeter.proto",
+	}, protocArgs("greeter", true, true, []string{"third_party"}))
+}
@@ -10,12 +10,18 @@ import (
 	"github.com/Blocktunium/gonyx/internal/cache"
 	"github.com/Blocktunium/gonyx/internal/grpc"
 	"github.com/Blocktunium/gonyx/internal/http"
+	"github.com/Blocktunium/gonyx/internal/listener"
 	"github.com/spf13/cobra"
 )
 
 const (
 	RunServerInitMsg     = `Gonyx > Running Server ...`
 	RunServerShutdownMsg = `Gonyx > Shutting Down Server ...`
+
+	RunServerStartFailedMsg = "Gonyx > Starting Servers Failed: %v\n"
+
+	RunServerHandoffMsg       = "Gonyx > Listeners Handed To Process %d ...\n"
+	RunServerHandoffFailedMsg = "Gonyx > Handing Listeners Failed: %v\n"
 )
 
 func NewRunServerCmd() *cobra.Command {
@@ -53,26 +59,39 @@ func runServerCmdExecute(cmd *cobra.Command, args []string) {
 	}
 
 	if serverType == "" || serverType == "grpc" {
-		grpc.GetManager().StartServers()
+		if err := grpc.GetManager().StartServers(); err != nil {
+			// the servers which are started are stopped, the process exits instead of serving partially
+			fmt.Fprintf(cmd.OutOrStdout(), RunServerStartFailedMsg, err)
+			stopServers(serverType)
+			_ = m.Release()
+			return
+		}
 	}
 
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
 	// kill -9 is syscall.SIGKILL but can't be caught, so don't need to add it
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	// the handoff signal starts a new process with the same listeners and then this one shuts down
+	signals := []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	if listener.HandoffSignal != nil {
+		signals = append(signals, listener.HandoffSignal)
+	}
+	signal.Notify(quit, signals...)
+	sig := <-quit
+
+	if listener.HandoffSignal != nil && sig == listener.HandoffSignal {
+		process, err := listener.Handoff()
+		if err != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), RunServerHandoffFailedMsg, err)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), RunServerHandoffMsg, process.Pid)
+		}
+	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), RunServerShutdownMsg)
 
-	// Stop only the servers that were started
-	if serverType == "" || serverType == "http" {
-		http.GetManager().StopServers()
-	}
-
-	if serverType == "" || serverType == "grpc" {
-		grpc.GetManager().StopServers()
-	}
+	stopServers(serverType)
 
 	err := m.Release()
 	if err != nil {
@@ -83,7 +102,7 @@ func runServerCmdExecute(cmd *cobra.Command, args []string) {
 	//wg.Add(1)
 	//
 	//go func() {
-	//	quit := make(chan os.Signal)
+	//	quit := make(chan os.Signal, 1)
 	//	// kill (no param) default send syscall.SIGTERM
 	//	// kill -2 is syscall.SIGINT
 	//	// kill -9 is syscall.SIGKILL but can't be caught, so don't need to add it
@@ -95,3 +114,14 @@ func runServerCmdExecute(cmd *cobra.Command, args []string) {
 	//
 	//wg.Wait()
 }
+
+// stopServers - stop only the servers that were started
+func stopServers(serverType string) {
+	if serverType == "" || serverType == "http" {
+		http.GetManager().StopServers()
+	}
+
+	if serverType == "" || serverType == "grpc" {
+		grpc.GetManager().StopServers()
+	}
+}
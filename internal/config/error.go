@@ -3,6 +3,8 @@ package config
 // Imports needed list
 import (
 	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
 )
 
 // KeyNotExistErr Error
@@ -17,6 +19,16 @@ func (err *KeyNotExistErr) Error() string {
 	return fmt.Sprintf("The key: '%v' does not exist in %v | %v", err.Key, err.Category, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *KeyNotExistErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *KeyNotExistErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewKeyNotExistErr - return a new instance of KeyNotExistErr
 func NewKeyNotExistErr(key, category string, err error) error {
 	return &KeyNotExistErr{
@@ -37,6 +49,16 @@ func (err *CategoryNotExistErr) Error() string {
 	return fmt.Sprintf("The config file '%v' does not exist | %v", err.Key, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CategoryNotExistErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CategoryNotExistErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewCategoryNotExistErr - return a new instance of CategoryNotExistErr
 func NewCategoryNotExistErr(key string, err error) error {
 	return &CategoryNotExistErr{
@@ -56,6 +78,16 @@ func (err *RemoteLoadErr) Error() string {
 	return fmt.Sprintf("Cannot load remote config: %v | %v", err.Name, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *RemoteLoadErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *RemoteLoadErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewRemoteLoadErr - return a new instance of RemoteLoadErr
 func NewRemoteLoadErr(name interface{}, err error) error {
 	return &RemoteLoadErr{
@@ -74,6 +106,16 @@ func (err *RemoteResponseErr) Error() string {
 	return fmt.Sprintf("Cannot get response from remote | %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *RemoteResponseErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *RemoteResponseErr) ErrorCode() errs.Code {
+	return errs.Unavailable
+}
+
 // NewRemoteResponseErr - return a new instance of RemoteResponseErr
 func NewRemoteResponseErr(err error) error {
 	return &RemoteResponseErr{Err: err}
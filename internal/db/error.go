@@ -1,6 +1,13 @@
 package db
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
 
 // NotImplementedErr Error
 type NotImplementedErr struct {
@@ -11,6 +18,11 @@ func (err *NotImplementedErr) Error() string {
 	return fmt.Sprintf("Not Implemented Yet")
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotImplementedErr) ErrorCode() errs.Code {
+	return errs.Unimplemented
+}
+
 // NewNotImplementedErr - return a new instance of NotImplementedErr
 func NewNotImplementedErr() error {
 	return &NotImplementedErr{}
@@ -26,6 +38,16 @@ func (err *CreateSqlWrapperErr) Error() string {
 	return fmt.Sprintf("Create a new sql wrapper encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateSqlWrapperErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CreateSqlWrapperErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewCreateSqlWrapperErr - return a new instance of CreateSqlWrapperErr
 func NewCreateSqlWrapperErr(err error) error {
 	return &CreateSqlWrapperErr{Err: err}
@@ -41,6 +63,11 @@ func (err *NotSupportedDbTypeErr) Error() string {
 	return fmt.Sprintf("Not Supported Database Dialect: %v", err.dbType)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotSupportedDbTypeErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
 // NewNotSupportedDbTypeErr - return a new instance of NotSupportedDbTypeErr
 func NewNotSupportedDbTypeErr(dbType string) error {
 	return &NotSupportedDbTypeErr{dbType: dbType}
@@ -56,6 +83,11 @@ func (err *NotExistServiceNameErr) Error() string {
 	return fmt.Sprintf("Instance with service name not exist: %v", err.serviceName)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotExistServiceNameErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewNotExistServiceNameErr - return a new instance of NotExistServiceNameErr
 func NewNotExistServiceNameErr(serviceName string) error {
 	return &NotExistServiceNameErr{serviceName: serviceName}
@@ -71,6 +103,16 @@ func (err *MigrateErr) Error() string {
 	return fmt.Sprintf("Migrating tables got error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MigrateErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MigrateErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Internal)
+}
+
 // NewMigrateErr - return a new instance of MigrateErr
 func NewMigrateErr(err error) error {
 	return &MigrateErr{Err: err}
@@ -86,6 +128,16 @@ func (err *CreateMongoWrapperErr) Error() string {
 	return fmt.Sprintf("Create a new mongo wrapper encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateMongoWrapperErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CreateMongoWrapperErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewCreateMongoWrapperErr - return a new instance of CreateMongoWrapperErr
 func NewCreateMongoWrapperErr(err error) error {
 	return &CreateMongoWrapperErr{Err: err}
@@ -102,6 +154,16 @@ func (err *SelectQueryErr) Error() string {
 	return fmt.Sprintf("Select query (`%v`) encouters error: %v", err.query, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *SelectQueryErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *SelectQueryErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewSelectQueryErr - return a new instance of SelectQueryErr
 func NewSelectQueryErr(q string, err error) error {
 	return &SelectQueryErr{query: q, Err: err}
@@ -119,6 +181,16 @@ func (err *DeleteModelErr) Error() string {
 	return fmt.Sprintf("Deleting a record from (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *DeleteModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *DeleteModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewDeleteModelErr - return a new instance of DeleteModelErr
 func NewDeleteModelErr(table string, data any, err error) error {
 	return &DeleteModelErr{table: table, data: data, Err: err}
@@ -136,6 +208,16 @@ func (err *InsertModelErr) Error() string {
 	return fmt.Sprintf("Inserting a record to (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *InsertModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *InsertModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewInsertModelErr - return a new instance of InsertModelErr
 func NewInsertModelErr(table string, data any, err error) error {
 	return &InsertModelErr{table: table, data: data, Err: err}
@@ -153,6 +235,16 @@ func (err *UpdateModelErr) Error() string {
 	return fmt.Sprintf("Updating record(s) in (%v) with data: %v -> encouters error: %v", err.table, err.data, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *UpdateModelErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *UpdateModelErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewUpdateModelErr - return a new instance of UpdateModelErr
 func NewUpdateModelErr(table string, data any, err error) error {
 	return &UpdateModelErr{table: table, data: data, Err: err}
@@ -170,6 +262,16 @@ func (err *MongoFindQueryErr) Error() string {
 	return fmt.Sprintf("Find query on (`%s`) with (%v) filter encouters error: %v", err.collection, err.filter, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MongoFindQueryErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MongoFindQueryErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewMongoFindQueryErr - return a new instance of MongoFindQueryErr
 func NewMongoFindQueryErr(collection string, filter any, err error) error {
 	return &MongoFindQueryErr{collection: collection, filter: filter, Err: err}
@@ -187,7 +289,28 @@ func (err *MongoDeleteErr) Error() string {
 	return fmt.Sprintf("Delete query on (`%s`) with (%v) filter encouters error: %v", err.collection, err.filter, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MongoDeleteErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MongoDeleteErr) ErrorCode() errs.Code {
+	return queryCode(err.Err)
+}
+
 // NewMongoDeleteErr - return a new instance of MongoDeleteErr
 func NewMongoDeleteErr(collection string, filter any, err error) error {
 	return &MongoDeleteErr{collection: collection, filter: filter, Err: err}
 }
+
+// queryCode - return the code of the error of a query, the missing records are `NotFound` and the duplicated keys are `AlreadyExists`
+func queryCode(err error) errs.Code {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		return errs.NotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey), mongo.IsDuplicateKeyError(err):
+		return errs.AlreadyExists
+	}
+	return errs.CodeOr(err, errs.Internal)
+}
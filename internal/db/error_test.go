@@ -0,0 +1,55 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+func TestQueryErr_CodeAndUnwrap(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		root     error
+		expected errs.Code
+	}{
+		{"missing record", NewSelectQueryErr("select * from users", gorm.ErrRecordNotFound), gorm.ErrRecordNotFound, errs.NotFound},
+		{"missing document", NewMongoFindQueryErr("users", nil, mongo.ErrNoDocuments), mongo.ErrNoDocuments, errs.NotFound},
+		{"duplicated key", NewInsertModelErr("users", nil, gorm.ErrDuplicatedKey), gorm.ErrDuplicatedKey, errs.AlreadyExists},
+		{"other", NewUpdateModelErr("users", nil, gorm.ErrInvalidData), gorm.ErrInvalidData, errs.Internal},
+	}
+	for _, item := range cases {
+		if !errors.Is(item.err, item.root) {
+			t.Errorf("Unwrap of %v --> Expected: %v, but got %v", item.name, item.root, item.err)
+		}
+		if code := errs.CodeOf(item.err); code != item.expected {
+			t.Errorf("Code of %v --> Expected: %v, but got %v", item.name, item.expected, code)
+		}
+	}
+
+	if code := errs.CodeOf(NewNotExistServiceNameErr("main")); code != errs.NotFound {
+		t.Errorf("Code of the unknown service --> Expected: %v, but got %v", errs.NotFound, code)
+	}
+}
+
+func TestQueryErr_NotSentToClient(t *testing.T) {
+	query := "select * from users where password = 'secret'"
+	cases := map[string]error{
+		"query":         NewSelectQueryErr(query, errors.New("connection refused")),
+		"wrapped query": fmt.Errorf("loading user: %w", NewSelectQueryErr(query, gorm.ErrRecordNotFound)),
+		"application":   errs.Wrap(errs.Internal, NewSelectQueryErr(query, gorm.ErrInvalidData), ""),
+	}
+	for name, err := range cases {
+		if st := errs.ToStatus(err); strings.Contains(st.Message(), "secret") {
+			t.Errorf("Status message of %v --> Expected: %v, but got %v", name, errs.CodeOf(err).Text(), st.Message())
+		}
+		if problem := errs.NewProblem(err, "/users"); strings.Contains(problem.Detail, "secret") || strings.Contains(problem.Title, "secret") {
+			t.Errorf("Problem detail of %v --> Expected: %v, but got %v", name, "", problem.Detail)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MARK: Variables
+
+const (
+	tenantCallbackName = "gonyx:tenant_column"
+	tenantColumnKey    = "gonyx:tenant_column"
+	tenantIDKey        = "gonyx:tenant_id"
+)
+
+// tenantCallbackLock - prevent the concurrent registration of the create callback
+var tenantCallbackLock sync.Mutex
+
+// MARK: Public functions
+
+// GetDbWithContext - Get *gorm.DB instance which is scoped to the tenant of the context
+// The connection, the schema and the row filter of the tenant config are applied, the context is set on the instance
+func (m *manager) GetDbWithContext(ctx context.Context, instanceName string) (*gorm.DB, error) {
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		db, err := m.GetDb(instanceName)
+		if err != nil {
+			return nil, err
+		}
+		return db.WithContext(ctx), nil
+	}
+
+	tenant, err := tenancy.GetManager().GetTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.DB.Connection != "" {
+		instanceName = tenant.DB.Connection
+	}
+	db, err := m.GetDb(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	return TenantScope(db.WithContext(ctx), tenant), nil
+}
+
+// TenantScope - return the instance with the schema and the row filter of the tenant
+// The returned instance is a new session, so it can be reused for several queries like the instance of GetDb
+func TenantScope(db *gorm.DB, tenant tenancy.Tenant) *gorm.DB {
+	if tenant.DB.Schema != "" {
+		db = db.Scopes(schemaScope(tenant.DB.Schema))
+	}
+	if tenant.DB.Column != "" {
+		registerTenantCallback(db)
+		db = db.Scopes(columnScope(tenant.DB.Column, tenant.ID))
+	}
+	return db.Session(&gorm.Session{})
+}
+
+// MARK: Private functions
+
+// schemaScope - use the table of the model in the schema, an explicit table is kept as it is
+func schemaScope(schema string) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if tx.Statement.Table != "" || tx.Statement.TableExpr != nil {
+			return tx
+		}
+		model := tx.Statement.Model
+		if model == nil {
+			model = tx.Statement.Dest
+		}
+		if model == nil || tx.Statement.Parse(model) != nil {
+			return tx
+		}
+		table := schema + "." + tx.Statement.Schema.Table
+		// some dialects write the insert target from the table name instead of the table expression
+		tx.Statement.AddClause(clause.Insert{Table: clause.Table{Name: table}})
+		return tx.Table(table)
+	}
+}
+
+// columnScope - filter the rows by the tenant column, the column is set on create by the tenant callback
+func columnScope(column string, tenantID string) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Set(tenantColumnKey, column).Set(tenantIDKey, tenantID).
+			Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: tenantID})
+	}
+}
+
+// registerTenantCallback - register the create callback which sets the tenant column, once per instance
+func registerTenantCallback(db *gorm.DB) {
+	tenantCallbackLock.Lock()
+	defer tenantCallbackLock.Unlock()
+
+	if db.Callback().Create().Get(tenantCallbackName) != nil {
+		return
+	}
+	_ = db.Callback().Create().Before("gorm:create").Register(tenantCallbackName, setTenantColumn)
+}
+
+// setTenantColumn - set the tenant column of the created rows
+func setTenantColumn(tx *gorm.DB) {
+	column, ok := tx.Get(tenantColumnKey)
+	if !ok || tx.Statement.Schema == nil {
+		return
+	}
+	tenantID, _ := tx.Get(tenantIDKey)
+	if field := tx.Statement.Schema.LookUpField(column.(string)); field != nil {
+		tx.Statement.SetColumn(field.DBName, tenantID, true)
+	}
+}
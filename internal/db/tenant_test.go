@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type tenantNote struct {
+	ID       uint
+	TenantID string
+	Text     string
+}
+
+func openTenantDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Opening database --> Expected: %v, but got %v", nil, err)
+	}
+	sqlDb, _ := db.DB()
+	// the attached schema only exists on the connection which attaches it
+	sqlDb.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDb.Close() })
+	return db
+}
+
+func TestTenantScope_Column(t *testing.T) {
+	db := openTenantDb(t)
+	if err := db.AutoMigrate(&tenantNote{}); err != nil {
+		t.Fatalf("Migrating --> Expected: %v, but got %v", nil, err)
+	}
+
+	acme := TenantScope(db.WithContext(context.Background()), tenancy.Tenant{ID: "acme", DB: tenancy.DBConfig{Column: "tenant_id"}})
+	globex := TenantScope(db.WithContext(context.Background()), tenancy.Tenant{ID: "globex", DB: tenancy.DBConfig{Column: "tenant_id"}})
+
+	if err := acme.Create(&[]tenantNote{{Text: "a1"}, {Text: "a2"}}).Error; err != nil {
+		t.Fatalf("Creating rows --> Expected: %v, but got %v", nil, err)
+	}
+	if err := globex.Create(&tenantNote{Text: "g1"}).Error; err != nil {
+		t.Fatalf("Creating row --> Expected: %v, but got %v", nil, err)
+	}
+
+	var notes []tenantNote
+	if err := acme.Find(&notes).Error; err != nil || len(notes) != 2 || notes[0].TenantID != "acme" {
+		t.Errorf("Finding rows of tenant --> Expected: %v, but got %v %v", 2, notes, err)
+	}
+	var count int64
+	globex.Model(&tenantNote{}).Where("text LIKE ?", "%1").Count(&count)
+	if count != 1 {
+		t.Errorf("Counting rows of tenant --> Expected: %v, but got %v", 1, count)
+	}
+
+	// the updates and the deletes do not reach the rows of the other tenants
+	globex.Model(&tenantNote{}).Where("1 = 1").Update("text", "changed")
+	acme.Where("1 = 1").Delete(&tenantNote{})
+	var all []tenantNote
+	db.Order("id").Find(&all)
+	if len(all) != 1 || all[0].TenantID != "globex" || all[0].Text != "changed" {
+		t.Errorf("Rows of all tenants --> Expected: %v, but got %v", "[globex changed]", all)
+	}
+}
+
+func TestTenantScope_Schema(t *testing.T) {
+	db := openTenantDb(t)
+	if err := db.Exec("ATTACH DATABASE 'file::memory:' AS globex").Error; err != nil {
+		t.Fatalf("Attaching schema --> Expected: %v, but got %v", nil, err)
+	}
+	if err := db.AutoMigrate(&tenantNote{}); err != nil {
+		t.Fatalf("Migrating --> Expected: %v, but got %v", nil, err)
+	}
+	if err := db.Exec("CREATE TABLE globex.tenant_notes (id integer primary key, tenant_id text, text text)").Error; err != nil {
+		t.Fatalf("Creating schema table --> Expected: %v, but got %v", nil, err)
+	}
+
+	globex := TenantScope(db, tenancy.Tenant{ID: "globex", DB: tenancy.DBConfig{Schema: "globex"}})
+	if err := globex.Create(&tenantNote{Text: "g1"}).Error; err != nil {
+		t.Fatalf("Creating row --> Expected: %v, but got %v", nil, err)
+	}
+
+	var count int64
+	db.Model(&tenantNote{}).Count(&count)
+	var notes []tenantNote
+	globex.Find(&notes)
+	if count != 0 || len(notes) != 1 || notes[0].Text != "g1" {
+		t.Errorf("Rows of schema --> Expected: %v %v, but got %v %v", 0, "[g1]", count, notes)
+	}
+}
@@ -1,6 +1,9 @@
 package engine
 
-import "github.com/Blocktunium/gonyx/pkg/http"
+import (
+	"github.com/Blocktunium/gonyx/internal/grpc/interceptors"
+	"github.com/Blocktunium/gonyx/pkg/http"
+)
 
 type RestfulApp interface {
 	Routes() []http.HttpRoute
@@ -11,3 +14,10 @@ type GrpcApp interface {
 	GetName() string
 	GetServerNames() []string
 }
+
+// GrpcAuthApp - a GrpcApp which declares the authentication of its methods for the `auth` interceptor
+// The keys are the full methods, e.g. "/pkg.Service/Method", or "/pkg.Service/*" for all methods of a service
+type GrpcAuthApp interface {
+	GrpcApp
+	AuthRequirements() map[string]interceptors.AuthRequirement
+}
@@ -0,0 +1,162 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Variables
+
+// Code - the canonical code of an error, the values are the same as the ones of the gRPC codes
+type Code uint32
+
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+// codeNames - the names of the codes, they are the same as the ones of the Connect protocol
+var codeNames = map[Code]string{
+	OK: "ok", Canceled: "canceled", Unknown: "unknown", InvalidArgument: "invalid_argument",
+	DeadlineExceeded: "deadline_exceeded", NotFound: "not_found", AlreadyExists: "already_exists",
+	PermissionDenied: "permission_denied", ResourceExhausted: "resource_exhausted",
+	FailedPrecondition: "failed_precondition", Aborted: "aborted", OutOfRange: "out_of_range",
+	Unimplemented: "unimplemented", Internal: "internal", Unavailable: "unavailable",
+	DataLoss: "data_loss", Unauthenticated: "unauthenticated",
+}
+
+// httpStatuses - the http status of the codes
+var httpStatuses = map[Code]int{
+	OK: http.StatusOK, Canceled: 499, Unknown: http.StatusInternalServerError, InvalidArgument: http.StatusBadRequest,
+	DeadlineExceeded: http.StatusGatewayTimeout, NotFound: http.StatusNotFound, AlreadyExists: http.StatusConflict,
+	PermissionDenied: http.StatusForbidden, ResourceExhausted: http.StatusTooManyRequests,
+	FailedPrecondition: http.StatusBadRequest, Aborted: http.StatusConflict, OutOfRange: http.StatusBadRequest,
+	Unimplemented: http.StatusNotImplemented, Internal: http.StatusInternalServerError,
+	Unavailable: http.StatusServiceUnavailable, DataLoss: http.StatusInternalServerError,
+	Unauthenticated: http.StatusUnauthorized,
+}
+
+// Coder - the errors which carry a code, e.g. the errors of the framework and Error
+type Coder interface {
+	ErrorCode() Code
+}
+
+// MARK: Public functions
+
+// String - return the name of the code, e.g. "not_found"
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return codeNames[Unknown]
+}
+
+// Text - return the generic text of the code for the clients, e.g. "Not Found", it is the name of the code if it has no http text
+func (c Code) Text() string {
+	if text := http.StatusText(c.HTTPStatus()); text != "" {
+		return text
+	}
+	return c.String()
+}
+
+// GRPCCode - return the gRPC code of the code
+func (c Code) GRPCCode() codes.Code {
+	return codes.Code(c)
+}
+
+// HTTPStatus - return the http status of the code, the unknown codes are answered by 500
+func (c Code) HTTPStatus() int {
+	if httpStatus, ok := httpStatuses[c]; ok {
+		return httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeOf - return the code of the first error in the chain of err which carries a code
+// The gRPC status errors have their own code, the context errors are `DeadlineExceeded` and `Canceled`, others are `Unknown`
+func CodeOf(err error) Code {
+	if err == nil {
+		return OK
+	}
+
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	var grpcStatus interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcStatus) {
+		return Code(grpcStatus.GRPCStatus().Code())
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	}
+	return Unknown
+}
+
+// CodeOr - return the code of err like CodeOf, the fallback is returned if err has no code
+// It is used by the errors of the framework to take the code of the errors which they wrap
+func CodeOr(err error, fallback Code) Code {
+	if code := CodeOf(err); code != Unknown {
+		return code
+	}
+	return fallback
+}
+
+// HasCode - report whether the code of err is the code
+func HasCode(err error, code Code) bool {
+	return CodeOf(err) == code
+}
+
+// CodeFromHTTPStatus - return the code of an http status, e.g. of the responses of the upstream services
+func CodeFromHTTPStatus(httpStatus int) Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return InvalidArgument
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return AlreadyExists
+	case http.StatusTooManyRequests:
+		return ResourceExhausted
+	case http.StatusNotImplemented:
+		return Unimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return Unavailable
+	case http.StatusGatewayTimeout:
+		return DeadlineExceeded
+	}
+	switch {
+	case httpStatus >= 200 && httpStatus < 300:
+		return OK
+	case httpStatus >= 400 && httpStatus < 500:
+		return FailedPrecondition
+	}
+	return Unknown
+}
@@ -0,0 +1,89 @@
+package errs
+
+import (
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MARK: Variables
+
+// Violator - the errors which have the violations of the fields of a request, e.g. the binding errors of the http requests
+type Violator interface {
+	FieldViolations() []utils.FieldViolation
+}
+
+// Error - an error of the application with a code, it is answered by the status of the code in the grpc and http servers
+// The Message is sent to the client, the wrapped Err is not, an empty Message is sent as the generic text of the code
+type Error struct {
+	Code       Code
+	Message    string
+	Reason     string            // the machine readable reason, e.g. "USER_NOT_FOUND", it is sent in the ErrorInfo detail
+	Metadata   map[string]string // the metadata of the reason
+	Violations []utils.FieldViolation
+	Details    []proto.Message // the extra details of the status, e.g. errdetails.RetryInfo
+	Err        error
+}
+
+// MARK: Public functions
+
+// New - return a new instance of Error with the code and the message
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap - return a new instance of Error which wraps err, err is matched by errors.Is and errors.As
+func Wrap(code Code, err error, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// Error method - satisfying error interface
+func (err *Error) Error() string {
+	message := err.Message
+	if message == "" {
+		message = err.Code.String()
+	}
+	if err.Err != nil {
+		return message + ": " + err.Err.Error()
+	}
+	return message
+}
+
+// Unwrap - return the wrapped error
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode - return the code of the error
+func (err *Error) ErrorCode() Code {
+	return err.Code
+}
+
+// FieldViolations - return the violations of the fields
+func (err *Error) FieldViolations() []utils.FieldViolation {
+	return err.Violations
+}
+
+// GRPCStatus - return the status of the error, so it is answered with its details even without the interceptor
+func (err *Error) GRPCStatus() *status.Status {
+	return ToStatus(err)
+}
+
+// WithReason - set the reason and its metadata and return the error
+func (err *Error) WithReason(reason string, metadata map[string]string) *Error {
+	err.Reason = reason
+	err.Metadata = metadata
+	return err
+}
+
+// WithViolations - add the violations of the fields and return the error
+func (err *Error) WithViolations(violations ...utils.FieldViolation) *Error {
+	err.Violations = append(err.Violations, violations...)
+	return err
+}
+
+// WithDetails - add the details of the status and return the error
+func (err *Error) WithDetails(details ...proto.Message) *Error {
+	err.Details = append(err.Details, details...)
+	return err
+}
@@ -0,0 +1,136 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// missingErr - an error of the framework which carries a code
+type missingErr struct {
+	Err error
+}
+
+func (err *missingErr) Error() string   { return "missing: " + err.Err.Error() }
+func (err *missingErr) Unwrap() error   { return err.Err }
+func (err *missingErr) ErrorCode() Code { return CodeOr(err.Err, NotFound) }
+
+func TestCodeOf(t *testing.T) {
+	root := errors.New("no rows")
+	cases := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{"nil", nil, OK},
+		{"plain", root, Unknown},
+		{"framework", fmt.Errorf("loading user: %w", &missingErr{Err: root}), NotFound},
+		{"framework of a status", &missingErr{Err: status.Error(codes.PermissionDenied, "denied")}, PermissionDenied},
+		{"application", fmt.Errorf("wrapped: %w", New(AlreadyExists, "the user exists")), AlreadyExists},
+		{"status", status.Error(codes.Unavailable, "down"), Unavailable},
+		{"deadline", fmt.Errorf("calling: %w", context.DeadlineExceeded), DeadlineExceeded},
+		{"canceled", context.Canceled, Canceled},
+	}
+	for _, item := range cases {
+		if code := CodeOf(item.err); code != item.expected {
+			t.Errorf("Code of %v --> Expected: %v, but got %v", item.name, item.expected, code)
+		}
+	}
+
+	if !HasCode(&missingErr{Err: root}, NotFound) || CodeOr(root, Internal) != Internal {
+		t.Errorf("HasCode and CodeOr --> Expected: %v %v, but got %v %v", true, Internal, HasCode(&missingErr{Err: root}, NotFound), CodeOr(root, Internal))
+	}
+}
+
+func TestError_Wrap(t *testing.T) {
+	root := &missingErr{Err: errors.New("no rows")}
+	err := fmt.Errorf("handler: %w", Wrap(Internal, root, "cannot load the user"))
+
+	var target *missingErr
+	if !errors.As(err, &target) || !errors.Is(err, root) {
+		t.Errorf("Wrapped error --> Expected: %v, but got %v", root, err)
+	}
+	if CodeOf(err) != Internal {
+		t.Errorf("Code of the outer error --> Expected: %v, but got %v", Internal, CodeOf(err))
+	}
+	if err.Error() != "handler: cannot load the user: missing: no rows" {
+		t.Errorf("Message --> Expected: %v, but got %v", "handler: cannot load the user: missing: no rows", err.Error())
+	}
+}
+
+func TestCode_Mapping(t *testing.T) {
+	if NotFound.String() != "not_found" || NotFound.GRPCCode() != codes.NotFound || NotFound.HTTPStatus() != http.StatusNotFound {
+		t.Errorf("NotFound --> Expected: %v %v %v, but got %v %v %v", "not_found", codes.NotFound, http.StatusNotFound, NotFound.String(), NotFound.GRPCCode(), NotFound.HTTPStatus())
+	}
+	if Code(100).String() != "unknown" || Code(100).HTTPStatus() != http.StatusInternalServerError {
+		t.Errorf("Unknown code --> Expected: %v %v, but got %v %v", "unknown", http.StatusInternalServerError, Code(100).String(), Code(100).HTTPStatus())
+	}
+	if CodeFromHTTPStatus(http.StatusForbidden) != PermissionDenied || CodeFromHTTPStatus(http.StatusTeapot) != FailedPrecondition {
+		t.Errorf("Code of the http status --> Expected: %v %v, but got %v %v", PermissionDenied, FailedPrecondition, CodeFromHTTPStatus(http.StatusForbidden), CodeFromHTTPStatus(http.StatusTeapot))
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	err := New(InvalidArgument, "the user is not valid").
+		WithReason("USER_INVALID", map[string]string{"user": "42"}).
+		WithViolations(utils.FieldViolation{Field: "email", Reason: "value must be a valid email address"}).
+		WithDetails(&errdetails.LocalizedMessage{Locale: "en", Message: "The user is not valid"})
+
+	st := ToStatus(fmt.Errorf("handler: %w", err))
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 3 {
+		t.Fatalf("Status of the error --> Expected: %v with 3 details, but got %v %v", codes.InvalidArgument, st.Code(), st.Details())
+	}
+	if badRequest, ok := st.Details()[0].(*errdetails.BadRequest); !ok || badRequest.FieldViolations[0].Field != "email" {
+		t.Errorf("BadRequest detail --> Expected: %v, but got %v", "email", st.Details()[0])
+	}
+	if info, ok := st.Details()[1].(*errdetails.ErrorInfo); !ok || info.Reason != "USER_INVALID" || info.Domain != Domain || info.Metadata["user"] != "42" {
+		t.Errorf("ErrorInfo detail --> Expected: %v, but got %v", "USER_INVALID", st.Details()[1])
+	}
+
+	// the error itself has the status for the handlers which are not intercepted
+	if direct, _ := status.FromError(err); direct.Message() != "the user is not valid" || direct.Code() != codes.InvalidArgument {
+		t.Errorf("Status of Error --> Expected: %v, but got %v", "the user is not valid", direct)
+	}
+
+	upstream := status.Error(codes.Unavailable, "down")
+	if st := ToStatus(upstream); st.Code() != codes.Unavailable || st.Message() != "down" {
+		t.Errorf("Status error --> Expected: %v, but got %v", upstream, st.Err())
+	}
+	if st := ToStatus(&missingErr{Err: errors.New("no rows")}); st.Code() != codes.NotFound || st.Message() != "Not Found" {
+		t.Errorf("Framework error --> Expected: %v, but got %v", codes.NotFound, st.Err())
+	}
+	if ToStatus(nil).Err() != nil {
+		t.Errorf("Nil error --> Expected: %v, but got %v", nil, ToStatus(nil).Err())
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	err := Wrap(NotFound, errors.New("no rows"), "the user is not found").
+		WithReason("USER_NOT_FOUND", nil).
+		WithViolations(utils.FieldViolation{Field: "id", Reason: "unknown id"})
+
+	problem := NewProblem(err, "/users/42")
+	expected := Problem{
+		Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "the user is not found",
+		Instance: "/users/42", Code: "not_found", Reason: "USER_NOT_FOUND",
+	}
+	if problem.Type != expected.Type || problem.Title != expected.Title || problem.Status != expected.Status ||
+		problem.Detail != expected.Detail || problem.Instance != expected.Instance || problem.Code != expected.Code || problem.Reason != expected.Reason {
+		t.Errorf("Problem --> Expected: %+v, but got %+v", expected, *problem)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0] != (ProblemViolation{Field: "id", Reason: "unknown id"}) {
+		t.Errorf("Problem errors --> Expected: %v, but got %v", "id", problem.Errors)
+	}
+
+	// the errors without a message are answered by the title only
+	if problem := NewProblem(Wrap(Internal, errors.New("connection refused"), ""), "/users/42"); problem.Detail != "" || problem.Title != "Internal Server Error" {
+		t.Errorf("Problem of error without message --> Expected: %v, but got %v", "Internal Server Error", problem.Detail)
+	}
+}
@@ -0,0 +1,59 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// MARK: Variables
+
+// ProblemContentType - the content type of the problem details, RFC 9457
+const ProblemContentType = "application/problem+json"
+
+// Problem - the problem details of an error in the http responses, the members after `instance` are the extensions
+type Problem struct {
+	Type     string             `json:"type"`
+	Title    string             `json:"title"`
+	Status   int                `json:"status"`
+	Detail   string             `json:"detail,omitempty"`
+	Instance string             `json:"instance,omitempty"`
+	Code     string             `json:"code"`
+	Reason   string             `json:"reason,omitempty"`
+	Metadata map[string]string  `json:"metadata,omitempty"`
+	Errors   []ProblemViolation `json:"errors,omitempty"`
+}
+
+// ProblemViolation - a violation of a field in the problem details
+type ProblemViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// MARK: Public functions
+
+// NewProblem - return the problem details of err, the status is of the code of CodeOf and instance is the path of the request
+// The detail is the message of Error, the text of the other errors is not sent
+func NewProblem(err error, instance string) *Problem {
+	code := CodeOf(err)
+	problem := &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(code.HTTPStatus()),
+		Status:   code.HTTPStatus(),
+		Detail:   clientMessage(err),
+		Instance: instance,
+		Code:     code.String(),
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		problem.Reason = appErr.Reason
+		problem.Metadata = appErr.Metadata
+	}
+	var violator Violator
+	if errors.As(err, &violator) {
+		for _, item := range violator.FieldViolations() {
+			problem.Errors = append(problem.Errors, ProblemViolation{Field: item.Field, Reason: item.Reason})
+		}
+	}
+	return problem
+}
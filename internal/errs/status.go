@@ -0,0 +1,83 @@
+package errs
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// MARK: Variables
+
+// Domain - the domain of the ErrorInfo details
+const Domain = "gonyx"
+
+// MARK: Public functions
+
+// ToStatus - return the gRPC status of err with the code of CodeOf and the message of Error or the generic text of the code
+// The violations are sent in the BadRequest detail, the reason of Error in the ErrorInfo detail with its other details
+// The status errors which have no violations are returned as they are
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	isAppErr := errors.As(err, &appErr)
+	var violator Violator
+	hasViolations := errors.As(err, &violator) && len(violator.FieldViolations()) > 0
+	if !isAppErr && !hasViolations {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+	}
+
+	st := status.New(CodeOf(err).GRPCCode(), message(err))
+	var details []protoadapt.MessageV1
+	if hasViolations {
+		badRequest := &errdetails.BadRequest{}
+		for _, item := range violator.FieldViolations() {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       item.Field,
+				Description: item.Reason,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if isAppErr {
+		if appErr.Reason != "" {
+			details = append(details, &errdetails.ErrorInfo{Reason: appErr.Reason, Domain: Domain, Metadata: appErr.Metadata})
+		}
+		for _, item := range appErr.Details {
+			details = append(details, protoadapt.MessageV1Of(item))
+		}
+	}
+
+	if len(details) > 0 {
+		if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+			st = withDetails
+		}
+	}
+	return st
+}
+
+// MARK: Private functions
+
+// message - return the message of err for the client, it is the message of Error or the generic text of the code
+// The text of the other errors is not sent, e.g. the query of `db.SelectQueryErr`
+func message(err error) string {
+	if text := clientMessage(err); text != "" {
+		return text
+	}
+	return CodeOf(err).Text()
+}
+
+// clientMessage - return the message of the first Error of the chain, it is empty if the error has no message for the client
+func clientMessage(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return ""
+}
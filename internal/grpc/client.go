@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/grpc/interceptors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// MARK: Public functions
+
+// NewClientConn - create the connection of the client config, the connection is established on the first call
+func NewClientConn(config ClientConfig) (*grpc.ClientConn, error) {
+	if config.Target == "" && len(config.Addresses) == 0 {
+		return nil, NewGrpcDialError(config.Name, errors.New("the target or the addresses are needed"))
+	}
+
+	options, target, err := clientOptions(config)
+	if err != nil {
+		return nil, NewGrpcDialError(target, err)
+	}
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		return nil, NewGrpcDialError(target, err)
+	}
+	return conn, nil
+}
+
+// MARK: Private functions
+
+// clientOptions - return the dial options and the target of the client config
+func clientOptions(config ClientConfig) ([]grpc.DialOption, string, error) {
+	var options []grpc.DialOption
+	target := config.Target
+
+	// the static addresses are resolved by a resolver of the connection
+	if len(config.Addresses) > 0 {
+		scheme := "gonyx-" + strings.ToLower(strings.ReplaceAll(config.Name, "_", "-"))
+		if config.Name == "" {
+			scheme = "gonyx-static"
+		}
+		r := manual.NewBuilderWithScheme(scheme)
+		addresses := make([]resolver.Address, 0, len(config.Addresses))
+		for _, item := range config.Addresses {
+			addresses = append(addresses, resolver.Address{Addr: item})
+		}
+		r.InitialState(resolver.State{Addresses: addresses})
+		options = append(options, grpc.WithResolvers(r))
+		target = scheme + ":///" + config.Name
+	}
+
+	creds, err := clientCredentials(config.TLS)
+	if err != nil {
+		return nil, target, err
+	}
+	options = append(options, grpc.WithTransportCredentials(creds))
+
+	if config.Keepalive.Time > 0 {
+		timeout := 20 * time.Second
+		if config.Keepalive.Timeout > 0 {
+			timeout = time.Duration(config.Keepalive.Timeout) * time.Millisecond
+		}
+		options = append(options, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(config.Keepalive.Time) * time.Millisecond,
+			Timeout:             timeout,
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	serviceConfig, err := clientServiceConfig(config)
+	if err != nil {
+		return nil, target, err
+	}
+	options = append(options, grpc.WithDefaultServiceConfig(serviceConfig))
+
+	propagate := config.Propagate
+	if len(propagate) == 0 {
+		propagate = interceptors.DefaultPropagateMetadata
+	}
+	unary := []grpc.UnaryClientInterceptor{interceptors.PropagationUnaryClientInterceptor(propagate, config.Metadata)}
+	if config.Timeout > 0 {
+		unary = append(unary, deadlineUnaryClientInterceptor(time.Duration(config.Timeout)*time.Millisecond))
+	}
+	options = append(options,
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(interceptors.PropagationStreamClientInterceptor(propagate, config.Metadata)))
+
+	return options, target, nil
+}
+
+// clientCredentials - return the transport credentials of the tls config
+func clientCredentials(config ClientTLSConfig) (credentials.TransportCredentials, error) {
+	if !config.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if config.CAFile != "" {
+		data, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate is found in `%v`", config.CAFile)
+		}
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// clientServiceConfig - return the json service config of the load balancing and the retry policy
+func clientServiceConfig(config ClientConfig) (string, error) {
+	policy := config.LoadBalancing
+	if policy == "" {
+		policy = "pick_first"
+		if len(config.Addresses) > 1 || strings.HasPrefix(config.Target, "dns:") {
+			policy = "round_robin"
+		}
+	}
+	if policy != "round_robin" && policy != "pick_first" {
+		return "", fmt.Errorf("the load balancing `%v` is not supported", policy)
+	}
+
+	serviceConfig := map[string]interface{}{
+		"loadBalancingConfig": []interface{}{map[string]interface{}{policy: map[string]interface{}{}}},
+	}
+
+	retry := config.Retry
+	if retry.MaxAttempts > 1 {
+		if retry.InitialBackoff <= 0 {
+			retry.InitialBackoff = 100
+		}
+		if retry.MaxBackoff <= 0 {
+			retry.MaxBackoff = 2000
+		}
+		if retry.BackoffMultiplier <= 0 {
+			retry.BackoffMultiplier = 2
+		}
+		codes := make([]string, 0, len(retry.Codes))
+		for _, item := range retry.Codes {
+			codes = append(codes, strings.ToUpper(item))
+		}
+		if len(codes) == 0 {
+			codes = []string{"UNAVAILABLE"}
+		}
+
+		serviceConfig["methodConfig"] = []interface{}{map[string]interface{}{
+			"name": []interface{}{map[string]interface{}{}},
+			"retryPolicy": map[string]interface{}{
+				"maxAttempts":          retry.MaxAttempts,
+				"initialBackoff":       seconds(retry.InitialBackoff),
+				"maxBackoff":           seconds(retry.MaxBackoff),
+				"backoffMultiplier":    retry.BackoffMultiplier,
+				"retryableStatusCodes": codes,
+			},
+		}}
+	}
+
+	data, err := json.Marshal(serviceConfig)
+	return string(data), err
+}
+
+// seconds - return the protobuf json duration of the milliseconds, e.g. "0.100s"
+func seconds(milliseconds int) string {
+	return fmt.Sprintf("%.3fs", float64(milliseconds)/1000)
+}
+
+// deadlineUnaryClientInterceptor - set the deadline of the calls whose context has none
+func deadlineUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// recordingServer - a server which answers all the methods and records the metadata of the calls
+type recordingServer struct {
+	lock     sync.Mutex
+	addr     string
+	calls    int
+	metadata []metadata.MD
+}
+
+func startRecordingServer(t *testing.T) *recordingServer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening --> Expected: %v, but got %v", nil, err)
+	}
+
+	r := &recordingServer{addr: lis.Addr().String()}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(srv any, stream grpc.ServerStream) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		r.lock.Lock()
+		r.calls++
+		r.metadata = append(r.metadata, md)
+		r.lock.Unlock()
+
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+		return stream.SendMsg(&emptypb.Empty{})
+	}))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return r
+}
+
+func TestManager_GetClientConn(t *testing.T) {
+	makeReadyConfigManager()
+
+	first := startRecordingServer(t)
+	second := startRecordingServer(t)
+
+	m := &manager{}
+	if err := m.AddClient(ClientConfig{
+		Name:      "users",
+		Addresses: []string{first.addr, second.addr},
+		Metadata:  map[string]string{"x-client": "gateway"},
+		Timeout:   5000,
+		Retry:     ClientRetryConfig{MaxAttempts: 3},
+	}); err != nil {
+		t.Fatalf("Adding client --> Expected: %v, but got %v", nil, err)
+	}
+	if _, err := m.GetClientConn("unknown"); err == nil {
+		t.Errorf("Unknown client --> Expected an error, but got %v", err)
+	}
+
+	conn, err := m.GetClientConn("users")
+	if err != nil {
+		t.Fatalf("Getting client connection --> Expected: %v, but got %v", nil, err)
+	}
+	if again, _ := m.GetClientConn("users"); again != conn {
+		t.Errorf("Shared connection --> Expected: %v, but got %v", conn, again)
+	}
+
+	// the metadata of the incoming call is propagated
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc", "traceparent", "00-trace-span-01", "authorization", "secret"))
+	for i := 0; i < 20; i++ {
+		if err := conn.Invoke(ctx, "/test.Service/Method", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+			t.Fatalf("Calling --> Expected: %v, but got %v", nil, err)
+		}
+	}
+
+	// the calls are balanced once both the addresses are connected
+	if first.calls == 0 || second.calls == 0 || first.calls+second.calls != 20 {
+		t.Errorf("Round robin calls --> Expected: %v, but got %v %v", "calls on both servers", first.calls, second.calls)
+	}
+	md := first.metadata[0]
+	if md.Get("x-request-id")[0] != "abc" || md.Get("traceparent")[0] != "00-trace-span-01" || md.Get("x-client")[0] != "gateway" || len(md.Get("authorization")) != 0 {
+		t.Errorf("Propagated metadata --> Expected: %v, but got %v", "x-request-id, traceparent and x-client", md)
+	}
+
+	// the connections are closed with the servers
+	m.StopServers()
+	if err := conn.Invoke(context.Background(), "/test.Service/Method", &emptypb.Empty{}, &emptypb.Empty{}); status.Code(err) != codes.Canceled {
+		t.Errorf("Closed connection --> Expected: %v, but got %v", codes.Canceled, err)
+	}
+}
+
+func TestClientServiceConfig(t *testing.T) {
+	if _, err := clientServiceConfig(ClientConfig{Target: "users:7777", LoadBalancing: "random"}); err == nil {
+		t.Errorf("Unknown load balancing --> Expected an error, but got %v", err)
+	}
+
+	serviceConfig, _ := clientServiceConfig(ClientConfig{Target: "dns:///users:7777", Retry: ClientRetryConfig{MaxAttempts: 4, Codes: []string{"unavailable", "aborted"}}})
+	expected := `{"loadBalancingConfig":[{"round_robin":{}}],"methodConfig":[{"name":[{}],"retryPolicy":{"backoffMultiplier":2,"initialBackoff":"0.100s","maxAttempts":4,"maxBackoff":"2.000s","retryableStatusCodes":["UNAVAILABLE","ABORTED"]}}]}`
+	if serviceConfig != expected {
+		t.Errorf("Service config --> Expected: %v, but got %v", expected, serviceConfig)
+	}
+}
+
+func TestManager_UpdateClients(t *testing.T) {
+	makeReadyConfigManager()
+
+	first := startRecordingServer(t)
+	second := startRecordingServer(t)
+
+	m := &manager{}
+	m.updateClients(map[string]ClientConfig{"users": {Name: "users", Target: first.addr}, "orders": {Name: "orders", Target: first.addr}})
+	_ = m.AddClient(ClientConfig{Name: "discovered", Target: first.addr})
+
+	users, _ := m.GetClientConn("users")
+	orders, _ := m.GetClientConn("orders")
+	discovered, _ := m.GetClientConn("discovered")
+
+	// the changed client gets a new connection, the removed one is gone and the one of the application is kept
+	stale := m.updateClients(map[string]ClientConfig{"users": {Name: "users", Target: second.addr}})
+	if len(stale) != 2 {
+		t.Errorf("Stale connections --> Expected: %v, but got %v", 2, len(stale))
+	}
+	m.closeClientConns(stale)
+
+	if conn, err := m.GetClientConn("users"); err != nil || conn == users {
+		t.Fatalf("Connection of the changed client --> Expected: %v, but got %v %v", "a new connection", conn, err)
+	} else if err := conn.Invoke(context.Background(), "/test.Service/Method", &emptypb.Empty{}, &emptypb.Empty{}); err != nil || second.calls != 1 {
+		t.Errorf("Call of the changed client --> Expected: %v, but got %v %v", "a call on the new target", second.calls, err)
+	}
+	if _, err := m.GetClientConn("orders"); err == nil {
+		t.Errorf("Removed client --> Expected an error, but got %v", err)
+	}
+	if conn, _ := m.GetClientConn("discovered"); conn != discovered {
+		t.Errorf("Client of the application --> Expected: %v, but got %v", discovered, conn)
+	}
+	if err := orders.Invoke(context.Background(), "/test.Service/Method", &emptypb.Empty{}, &emptypb.Empty{}); status.Code(err) != codes.Canceled {
+		t.Errorf("Closed stale connection --> Expected: %v, but got %v", codes.Canceled, err)
+	}
+	m.StopServers()
+}
@@ -1,6 +1,10 @@
 package grpc
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
 
 // GrpcServerStartError struct
 type GrpcServerStartError struct {
@@ -12,6 +16,16 @@ func (err *GrpcServerStartError) Error() string {
 	return fmt.Sprintf("gRPC Start Server Error | %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *GrpcServerStartError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GrpcServerStartError) ErrorCode() errs.Code {
+	return errs.Unavailable
+}
+
 // NewGrpcServerStartError - return a new instance of GrpcServerStartError
 func NewGrpcServerStartError(err error) error {
 	return &GrpcServerStartError{Err: err}
@@ -28,6 +42,16 @@ func (err *GrpcDialError) Error() string {
 	return fmt.Sprintf("gRPC dial to %v Error | %v", err.addr, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *GrpcDialError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GrpcDialError) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.Unavailable)
+}
+
 // NewGrpcDialError - return a new instance of GrpcDialError
 func NewGrpcDialError(addr string, err error) error {
 	return &GrpcDialError{Err: err, addr: addr}
@@ -43,6 +67,16 @@ func (err *CreateServerErr) Error() string {
 	return fmt.Sprintf("Create a new server encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateServerErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CreateServerErr) ErrorCode() errs.Code {
+	return errs.CodeOr(err.Err, errs.FailedPrecondition)
+}
+
 // NewCreateServerErr - return a new instance of CreateServerErr
 func NewCreateServerErr(err error) error {
 	return &CreateServerErr{Err: err}
@@ -58,6 +92,11 @@ func (err *GrpcServerNotExistError) Error() string {
 	return fmt.Sprintf("gRPC server with specified name `%v` not exist", err.name)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GrpcServerNotExistError) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewGrpcServerNotExistError - return a new instance of GrpcServerNotExistError
 func NewGrpcServerNotExistError(name string) error {
 	return &GrpcServerNotExistError{name: name}
@@ -72,7 +111,141 @@ func (err *NilServiceRegistryError) Error() string {
 	return fmt.Sprintf("Register a Nil service to gRPC server")
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NilServiceRegistryError) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
 // NewNilServiceRegistryError - return a new instance of NilServiceRegistryError
 func NewNilServiceRegistryError() error {
 	return &NilServiceRegistryError{}
 }
+
+// UnknownInterceptorErr Error
+type UnknownInterceptorErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *UnknownInterceptorErr) Error() string {
+	return fmt.Sprintf("The interceptor '%v' is neither built-in nor registered", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *UnknownInterceptorErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewUnknownInterceptorErr - return a new instance of UnknownInterceptorErr
+func NewUnknownInterceptorErr(name string) error {
+	return &UnknownInterceptorErr{Name: name}
+}
+
+// InterceptorConfigErr Error
+type InterceptorConfigErr struct {
+	Name string
+	Err  error
+}
+
+// Error method - satisfying error interface
+func (err *InterceptorConfigErr) Error() string {
+	return fmt.Sprintf("The config of the interceptor '%v' is not valid: %v", err.Name, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *InterceptorConfigErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *InterceptorConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewInterceptorConfigErr - return a new instance of InterceptorConfigErr
+func NewInterceptorConfigErr(name string, err error) error {
+	return &InterceptorConfigErr{Name: name, Err: err}
+}
+
+// RegisterInterceptorErr Error
+type RegisterInterceptorErr struct {
+	Name   string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *RegisterInterceptorErr) Error() string {
+	return fmt.Sprintf("Cannot register the interceptor '%v': %v", err.Name, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *RegisterInterceptorErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewRegisterInterceptorErr - return a new instance of RegisterInterceptorErr
+func NewRegisterInterceptorErr(name string, reason string) error {
+	return &RegisterInterceptorErr{Name: name, Reason: reason}
+}
+
+// GrpcClientNotExistError struct
+type GrpcClientNotExistError struct {
+	name string
+}
+
+// Error method - satisfying error interface
+func (err *GrpcClientNotExistError) Error() string {
+	return fmt.Sprintf("gRPC client with specified name `%v` not exist", err.name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GrpcClientNotExistError) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewGrpcClientNotExistError - return a new instance of GrpcClientNotExistError
+func NewGrpcClientNotExistError(name string) error {
+	return &GrpcClientNotExistError{name: name}
+}
+
+// ServerStateErr Error
+type ServerStateErr struct {
+	Name   string
+	Action string
+	State  ServerState
+}
+
+// Error method - satisfying error interface
+func (err *ServerStateErr) Error() string {
+	return fmt.Sprintf("Cannot %v the gRPC server `%v` while it is %v", err.Action, err.Name, err.State)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ServerStateErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewServerStateErr - return a new instance of ServerStateErr
+func NewServerStateErr(name string, action string, state ServerState) error {
+	return &ServerStateErr{Name: name, Action: action, State: state}
+}
+
+// LocalExposureErr Error
+type LocalExposureErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *LocalExposureErr) Error() string {
+	return fmt.Sprintf("The gRPC server `%v` authenticates its clients only by their certificates, so it cannot be exposed by the in-process connection without the auth interceptor", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *LocalExposureErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewLocalExposureErr - return a new instance of LocalExposureErr
+func NewLocalExposureErr(name string) error {
+	return &LocalExposureErr{Name: name}
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// InterceptorFactory - create the interceptors from their raw config in `interceptors.<name>`, the config is nil if it is absent
+// One of the interceptors may be nil if the interceptor is only for the unary calls or the streams
+type InterceptorFactory func(rawConfig map[string]interface{}) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error)
+
+// builtinInterceptors - the interceptors configured by the server itself
+var builtinInterceptors = []string{
+	"logger",
+	"recovery",
+	"requestid",
+	"metrics",
+	"auth",
+	"validation",
+	"i18n",
+}
+
+var (
+	interceptorRegistryLock sync.RWMutex
+	interceptorRegistry     = make(map[string]InterceptorFactory)
+)
+
+// RegisterInterceptor - register an interceptor to be referenced by name in `interceptors.order`
+// It must be called before the servers are created
+func RegisterInterceptor(name string, factory InterceptorFactory) error {
+	if name == "" {
+		return NewRegisterInterceptorErr(name, "the name is empty")
+	}
+	if factory == nil {
+		return NewRegisterInterceptorErr(name, "the factory is nil")
+	}
+	for _, item := range builtinInterceptors {
+		if item == name {
+			return NewRegisterInterceptorErr(name, "the name is used by a built-in interceptor")
+		}
+	}
+
+	interceptorRegistryLock.Lock()
+	defer interceptorRegistryLock.Unlock()
+
+	if _, ok := interceptorRegistry[name]; ok {
+		return NewRegisterInterceptorErr(name, "the name is already registered")
+	}
+	interceptorRegistry[name] = factory
+	return nil
+}
+
+// getInterceptorFactory - return the registered interceptor factory
+func getInterceptorFactory(name string) (InterceptorFactory, bool) {
+	interceptorRegistryLock.RLock()
+	defer interceptorRegistryLock.RUnlock()
+
+	factory, ok := interceptorRegistry[name]
+	return factory, ok
+}
@@ -0,0 +1,178 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Variables
+
+const defaultAPIKeyMetadata = "x-api-key"
+
+// MARK: Public functions
+
+// AuthUnaryInterceptor - authenticate the calls by the api key, the bearer token or the client certificate and put the principal into the context
+// The calls which do not satisfy the requirement of their method fail with the `Unauthenticated` or `PermissionDenied` code
+func AuthUnaryInterceptor(config *AuthInterceptorConfig) (grpc.UnaryServerInterceptor, error) {
+	cfg, err := authConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, cfg, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}, nil
+}
+
+// AuthStreamInterceptor - authenticate the streams like AuthUnaryInterceptor
+func AuthStreamInterceptor(config *AuthInterceptorConfig) (grpc.StreamServerInterceptor, error) {
+	cfg, err := authConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), cfg, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}, nil
+}
+
+// MARK: Private functions
+
+// authConfig - return the config with its defaults, at least one scheme is needed
+func authConfig(config *AuthInterceptorConfig) (AuthInterceptorConfig, error) {
+	if config == nil || (len(config.Keys) == 0 && config.JWT.Secret == "" && !config.MTLS) {
+		return AuthInterceptorConfig{}, errors.New("the auth interceptor needs the keys, the jwt secret or mtls")
+	}
+	cfg := *config
+	if cfg.Metadata == "" {
+		cfg.Metadata = defaultAPIKeyMetadata
+	}
+	return cfg, nil
+}
+
+// authorize - authenticate the call and check the requirement of its method, the returned context carries the principal
+func authorize(ctx context.Context, cfg AuthInterceptorConfig, method string) (context.Context, error) {
+	for _, item := range cfg.SkipMethods {
+		if item == method {
+			return ctx, nil
+		}
+	}
+	requirement, declared := authRequirement(method)
+	if requirement.Public {
+		return ctx, nil
+	}
+
+	principal, err := authenticate(ctx, cfg, requirement.Schemes)
+	if err != nil {
+		return ctx, err
+	}
+	if principal == nil {
+		if !declared && cfg.Optional {
+			return ctx, nil
+		}
+		return ctx, status.Error(codes.Unauthenticated, "the credentials are missing")
+	}
+
+	for _, scope := range requirement.Scopes {
+		if !principal.HasScope(scope) {
+			return ctx, status.Errorf(codes.PermissionDenied, "the scope `%v` is needed", scope)
+		}
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// authenticate - return the principal of the credentials of the call, or nil if there is no credential
+// The credentials which are present but not valid fail the call
+func authenticate(ctx context.Context, cfg AuthInterceptorConfig, schemes []string) (*Principal, error) {
+	accepts := func(scheme string) bool {
+		return len(schemes) == 0 || utils.ArrayContains(&schemes, scheme)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if cfg.JWT.Secret != "" && accepts(JWTAuthScheme) {
+		if values := md.Get("authorization"); len(values) > 0 {
+			if token, ok := strings.CutPrefix(values[0], "Bearer "); ok {
+				return jwtPrincipal(strings.TrimSpace(token), cfg.JWT)
+			}
+		}
+	}
+
+	if len(cfg.Keys) > 0 && accepts(APIKeyAuthScheme) {
+		if values := md.Get(cfg.Metadata); len(values) > 0 && values[0] != "" {
+			for subject, key := range cfg.Keys {
+				if subtle.ConstantTimeCompare([]byte(values[0]), []byte(key)) == 1 {
+					return &Principal{Scheme: APIKeyAuthScheme, Subject: subject}, nil
+				}
+			}
+			return nil, status.Error(codes.Unauthenticated, "the api key is not valid")
+		}
+	}
+
+	if cfg.MTLS && accepts(MTLSAuthScheme) {
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+				return &Principal{Scheme: MTLSAuthScheme, Subject: info.State.VerifiedChains[0][0].Subject.CommonName}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// jwtPrincipal - verify the token and return its principal
+func jwtPrincipal(token string, cfg JWTAuthConfig) (*Principal, error) {
+	claims, err := utils.VerifyJWT(token, []byte(cfg.Secret))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if cfg.Issuer != "" && claims["iss"] != cfg.Issuer {
+		return nil, status.Error(codes.Unauthenticated, "the issuer of the token is not valid")
+	}
+	if cfg.Audience != "" && !hasAudience(claims["aud"], cfg.Audience) {
+		return nil, status.Error(codes.Unauthenticated, "the audience of the token is not valid")
+	}
+
+	principal := &Principal{Scheme: JWTAuthScheme, Claims: claims}
+	principal.Subject, _ = claims["sub"].(string)
+	if scopes, ok := claims["scope"].(string); ok {
+		principal.Scopes = strings.Fields(scopes)
+	}
+	if scopes, ok := claims["scp"].([]any); ok {
+		for _, item := range scopes {
+			if scope, ok := item.(string); ok {
+				principal.Scopes = append(principal.Scopes, scope)
+			}
+		}
+	}
+	return principal, nil
+}
+
+// hasAudience - return whether the `aud` claim, a string or an array, has the audience
+func hasAudience(claim any, audience string) bool {
+	switch value := claim.(type) {
+	case string:
+		return value == audience
+	case []any:
+		for _, item := range value {
+			if item == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
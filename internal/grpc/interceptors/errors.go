@@ -0,0 +1,32 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"google.golang.org/grpc"
+)
+
+// MARK: Public functions
+
+// ErrorsUnaryInterceptor - convert the errors of the handlers to their status by errs.ToStatus
+// e.g. `db.SelectQueryErr` of a missing record is answered with the `NotFound` code, errs.Error with its details
+func ErrorsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, errs.ToStatus(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// ErrorsStreamInterceptor - convert the errors of the stream handlers like ErrorsUnaryInterceptor
+func ErrorsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return errs.ToStatus(err).Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,232 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"expvar"
+	"fmt"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../../..", "test", "Gonyx")
+}
+
+var testInfo = &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+// signJWT - return a HS256 token of the payload
+func signJWT(secret string, payload string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	unsigned := encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(payload))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + encode(mac.Sum(nil))
+}
+
+// validatedRequest - a request which validates itself
+type validatedRequest struct {
+	name string
+}
+
+func (r *validatedRequest) Validate() error {
+	if r.name == "" {
+		return errors.New("the name is required")
+	}
+	return nil
+}
+
+// fieldViolationErr - the error of a field like the ones generated by protoc-gen-validate
+type fieldViolationErr struct {
+	field  string
+	reason string
+	cause  error
+}
+
+func (e fieldViolationErr) Error() string  { return e.field + ": " + e.reason }
+func (e fieldViolationErr) Field() string  { return e.field }
+func (e fieldViolationErr) Reason() string { return e.reason }
+func (e fieldViolationErr) Cause() error   { return e.cause }
+
+// multiViolationErr - the errors of all the fields like the ones generated by protoc-gen-validate
+type multiViolationErr []error
+
+func (m multiViolationErr) Error() string      { return errors.Join(m...).Error() }
+func (m multiViolationErr) AllErrors() []error { return m }
+
+// generatedRequest - a request with the generated validation methods
+type generatedRequest struct{}
+
+func (r *generatedRequest) ValidateAll() error {
+	return multiViolationErr{
+		fieldViolationErr{field: "Email", reason: "value must be a valid email address"},
+		fieldViolationErr{field: "Address", reason: "embedded message failed validation", cause: multiViolationErr{
+			fieldViolationErr{field: "City", reason: "value length must be at least 2 runes"},
+		}},
+	}
+}
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	makeReadyConfigManager()
+
+	interceptor := RecoveryUnaryInterceptor(&RecoveryInterceptorConfig{Stack: true})
+	_, err := interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Recovered panic --> Expected: %v, but got %v", codes.Internal, err)
+	}
+}
+
+func TestRequestIDUnaryInterceptor(t *testing.T) {
+	interceptor := RequestIDUnaryInterceptor(nil)
+	handler := func(ctx context.Context, req any) (any, error) {
+		return RequestIDFromContext(ctx), nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc"))
+	if resp, _ := interceptor(ctx, nil, testInfo, handler); resp != "abc" {
+		t.Errorf("Request id of metadata --> Expected: %v, but got %v", "abc", resp)
+	}
+	if resp, _ := interceptor(context.Background(), nil, testInfo, handler); len(resp.(string)) != 32 {
+		t.Errorf("Generated request id --> Expected: %v, but got %v", "32 hex characters", resp)
+	}
+}
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	interceptor := MetricsUnaryInterceptor(&MetricsInterceptorConfig{Name: "grpc_test"})
+	_, _ = interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) { return nil, nil })
+	_, _ = interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	// a second interceptor with the same name shares the metrics
+	_, _ = MetricsUnaryInterceptor(&MetricsInterceptorConfig{Name: "grpc_test"})(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) { return nil, nil })
+
+	methodMap, _ := expvar.Get("grpc_test").(*expvar.Map).Get(testInfo.FullMethod).(*expvar.Map)
+	if methodMap == nil || methodMap.Get("OK").String() != "2" || methodMap.Get("NotFound").String() != "1" {
+		t.Errorf("Metrics of method --> Expected: %v, but got %v", `{"OK": 2, "NotFound": 1}`, methodMap)
+	}
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	if _, err := AuthUnaryInterceptor(&AuthInterceptorConfig{}); err == nil {
+		t.Errorf("Auth without schemes --> Expected an error, but got %v", err)
+	}
+
+	interceptor, err := AuthUnaryInterceptor(&AuthInterceptorConfig{
+		Keys:        map[string]string{"billing": "secret"},
+		JWT:         JWTAuthConfig{Secret: "jwt-secret", Issuer: "gonyx"},
+		SkipMethods: []string{"/test.Service/Skipped"},
+	})
+	if err != nil {
+		t.Fatalf("Creating interceptor --> Expected: %v, but got %v", nil, err)
+	}
+	SetAuthRequirement("/test.Admin/*", AuthRequirement{Schemes: []string{JWTAuthScheme}, Scopes: []string{"admin"}})
+	SetAuthRequirement("/test.Admin/Status", AuthRequirement{Public: true})
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		principal, _ := PrincipalFromContext(ctx)
+		if principal == nil {
+			return "", nil
+		}
+		return principal.Scheme + ":" + principal.Subject, nil
+	}
+	adminToken := "Bearer " + signJWT("jwt-secret", `{"sub":"ali","iss":"gonyx","scope":"read admin"}`)
+	readerToken := "Bearer " + signJWT("jwt-secret", `{"sub":"sara","iss":"gonyx","scope":"read"}`)
+
+	tests := []struct {
+		name     string
+		method   string
+		md       metadata.MD
+		code     codes.Code
+		expected string
+	}{
+		{"api key", "/test.Service/Method", metadata.Pairs("x-api-key", "secret"), codes.OK, "api_key:billing"},
+		{"wrong api key", "/test.Service/Method", metadata.Pairs("x-api-key", "other"), codes.Unauthenticated, ""},
+		{"jwt", "/test.Service/Method", metadata.Pairs("authorization", readerToken), codes.OK, "jwt:sara"},
+		{"jwt wrong issuer", "/test.Service/Method", metadata.Pairs("authorization", "Bearer "+signJWT("jwt-secret", `{"sub":"ali","iss":"other"}`)), codes.Unauthenticated, ""},
+		{"missing credentials", "/test.Service/Method", nil, codes.Unauthenticated, ""},
+		{"skipped method", "/test.Service/Skipped", nil, codes.OK, ""},
+		{"scope", "/test.Admin/Delete", metadata.Pairs("authorization", adminToken), codes.OK, "jwt:ali"},
+		{"missing scope", "/test.Admin/Delete", metadata.Pairs("authorization", readerToken), codes.PermissionDenied, ""},
+		{"scheme not accepted", "/test.Admin/Delete", metadata.Pairs("x-api-key", "secret"), codes.Unauthenticated, ""},
+		{"public method", "/test.Admin/Status", nil, codes.OK, ""},
+		{"health service", "/grpc.health.v1.Health/Watch", nil, codes.OK, ""},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+		if status.Code(err) != test.code || (err == nil && resp != test.expected) {
+			t.Errorf("Auth %v --> Expected: %v %v, but got %v %v", test.name, test.code, test.expected, resp, err)
+		}
+	}
+
+	optional, _ := AuthUnaryInterceptor(&AuthInterceptorConfig{Keys: map[string]string{"billing": "secret"}, Optional: true})
+	if _, err := optional(context.Background(), nil, testInfo, handler); err != nil {
+		t.Errorf("Optional credentials --> Expected: %v, but got %v", nil, err)
+	}
+}
+
+func TestValidationUnaryInterceptor(t *testing.T) {
+	interceptor := ValidationUnaryInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), &validatedRequest{}, testInfo, handler); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Invalid request --> Expected: %v, but got %v", codes.InvalidArgument, err)
+	}
+	if resp, err := interceptor(context.Background(), &validatedRequest{name: "ali"}, testInfo, handler); err != nil || resp != "ok" {
+		t.Errorf("Valid request --> Expected: %v, but got %v %v", "ok", resp, err)
+	}
+	if _, err := interceptor(context.Background(), "not validated", testInfo, handler); err != nil {
+		t.Errorf("Request without validation --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the violations of the fields are sent in the details of the status, the embedded fields are joined by "."
+	_, err := interceptor(context.Background(), &generatedRequest{}, testInfo, handler)
+	st := status.Convert(err)
+	expected := []*errdetails.BadRequest_FieldViolation{
+		{Field: "Email", Description: "value must be a valid email address"},
+		{Field: "Address.City", Description: "value length must be at least 2 runes"},
+	}
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("Status of the violations --> Expected: %v with 1 detail, but got %v %v", codes.InvalidArgument, st.Code(), st.Details())
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != len(expected) {
+		t.Fatalf("BadRequest details --> Expected: %v, but got %v", expected, st.Details()[0])
+	}
+	for i, item := range expected {
+		if badRequest.FieldViolations[i].Field != item.Field || badRequest.FieldViolations[i].Description != item.Description {
+			t.Errorf("Field violation --> Expected: %v, but got %v", item, badRequest.FieldViolations[i])
+		}
+	}
+}
+
+func TestErrorsUnaryInterceptor(t *testing.T) {
+	interceptor := ErrorsUnaryInterceptor()
+	notFound := func(ctx context.Context, req any) (any, error) {
+		return nil, fmt.Errorf("loading: %w", errs.Wrap(errs.NotFound, errors.New("no rows"), "the user is not found"))
+	}
+	_, err := interceptor(context.Background(), nil, testInfo, notFound)
+	if st := status.Convert(err); st.Code() != codes.NotFound || st.Message() != "the user is not found" {
+		t.Errorf("Coded error --> Expected: %v, but got %v", codes.NotFound, err)
+	}
+
+	plain := func(ctx context.Context, req any) (any, error) { return nil, errors.New("boom") }
+	if _, err := interceptor(context.Background(), nil, testInfo, plain); status.Code(err) != codes.Unknown || status.Convert(err).Message() == "boom" {
+		t.Errorf("Plain error --> Expected: %v without its text, but got %v", codes.Unknown, err)
+	}
+	if resp, err := interceptor(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) { return "ok", nil }); err != nil || resp != "ok" {
+		t.Errorf("Successful call --> Expected: %v, but got %v %v", "ok", resp, err)
+	}
+}
@@ -0,0 +1,92 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Variables
+
+var (
+	GrpcCallLogType = types.NewLogType("PROTOBUF_CALL")
+)
+
+// MARK: Public functions
+
+// LoggerUnaryInterceptor - log the method, the status code and the duration of each call by the logger manager
+func LoggerUnaryInterceptor(config *LoggerInterceptorConfig) grpc.UnaryServerInterceptor {
+	skip := skipSet(config)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if skip[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, "grpc.interceptors.Unary", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// LoggerStreamInterceptor - log the method, the status code and the duration of each stream by the logger manager
+func LoggerStreamInterceptor(config *LoggerInterceptorConfig) grpc.StreamServerInterceptor {
+	skip := skipSet(config)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skip[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), "grpc.interceptors.Stream", info.FullMethod, start, err)
+		return err
+	}
+}
+
+// MARK: Private functions
+
+// skipSet - return the set of the methods which are not logged
+func skipSet(config *LoggerInterceptorConfig) map[string]bool {
+	skip := make(map[string]bool)
+	if config != nil {
+		for _, item := range config.SkipMethods {
+			skip[item] = true
+		}
+	}
+	return skip
+}
+
+// logCall - log the finished call, the level depends on whether the error is caused by the client or the server
+func logCall(ctx context.Context, module string, method string, start time.Time, err error) {
+	l, _ := logger.GetManager().GetLoggerWithContext(ctx)
+	if l == nil {
+		return
+	}
+
+	code := status.Code(err)
+	details := map[string]interface{}{
+		"method":   method,
+		"code":     code.String(),
+		"duration": time.Since(start).Milliseconds(),
+	}
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		details["request_id"] = requestID
+	}
+
+	level, message := types.INFO, "The call is finished ..."
+	switch code {
+	case codes.OK:
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.FailedPrecondition, codes.OutOfRange:
+		level, message = types.WARNING, "The call is rejected ..."
+		details["error"] = err.Error()
+	default:
+		level, message = types.ERROR, "The call failed ..."
+		details["error"] = err.Error()
+	}
+	l.Log(types.NewLogObject(level, module, GrpcCallLogType, time.Now(), message, details))
+}
@@ -0,0 +1,75 @@
+package interceptors
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Variables
+
+const defaultMetricsName = "grpc_server"
+
+// metricsLock - prevent the concurrent creation of the metric maps
+var metricsLock sync.Mutex
+
+// MARK: Public functions
+
+// MetricsUnaryInterceptor - count the calls of each method by status code and sum their durations
+// The metrics are published by expvar, e.g. `grpc_server: {"/pkg.Service/Method": {"OK": 3, "duration_ms": 12}}`
+func MetricsUnaryInterceptor(config *MetricsInterceptorConfig) grpc.UnaryServerInterceptor {
+	root := metricsRoot(config)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(root, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor - count the streams of each method by status code and sum their durations
+func MetricsStreamInterceptor(config *MetricsInterceptorConfig) grpc.StreamServerInterceptor {
+	root := metricsRoot(config)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(root, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// MARK: Private functions
+
+// metricsRoot - return the published map of the metrics, the servers with the same name share it
+func metricsRoot(config *MetricsInterceptorConfig) *expvar.Map {
+	name := defaultMetricsName
+	if config != nil && config.Name != "" {
+		name = config.Name
+	}
+
+	metricsLock.Lock()
+	defer metricsLock.Unlock()
+
+	if root, ok := expvar.Get(name).(*expvar.Map); ok {
+		return root
+	}
+	return expvar.NewMap(name)
+}
+
+// observe - add the call to the metrics of its method
+func observe(root *expvar.Map, method string, start time.Time, err error) {
+	metricsLock.Lock()
+	methodMap, ok := root.Get(method).(*expvar.Map)
+	if !ok {
+		methodMap = new(expvar.Map)
+		root.Set(method, methodMap)
+	}
+	metricsLock.Unlock()
+
+	methodMap.Add(status.Code(err).String(), 1)
+	methodMap.Add("duration_ms", time.Since(start).Milliseconds())
+}
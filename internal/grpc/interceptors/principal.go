@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// MARK: Variables
+
+const (
+	APIKeyAuthScheme = "api_key"
+	JWTAuthScheme    = "jwt"
+	MTLSAuthScheme   = "mtls"
+)
+
+// Principal - the authenticated caller of the call
+type Principal struct {
+	Scheme  string         // the scheme which authenticated the caller, e.g. "jwt"
+	Subject string         // the name of the api key, the `sub` claim of the token or the common name of the certificate
+	Scopes  []string       // the `scope` or `scp` claim of the token
+	Claims  map[string]any // the claims of the token
+}
+
+// HasScope - return whether the principal has the scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, item := range p.Scopes {
+		if item == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthRequirement - the authentication which a method needs, it is declared by the controller of the method
+type AuthRequirement struct {
+	Public  bool     // the method is called without credentials
+	Schemes []string // the accepted schemes, all the schemes of the config are accepted if empty
+	Scopes  []string // the scopes which the principal must have
+}
+
+// principalContextKey - the key of the principal in a context
+type principalContextKey struct{}
+
+var authRequirements sync.Map
+
+// the health service is public by default for the probes of the load balancers, it is declared again by SetAuthRequirement if needed
+func init() {
+	SetAuthRequirement("/grpc.health.v1.Health/*", AuthRequirement{Public: true})
+}
+
+// MARK: Public functions
+
+// WithPrincipal - return a copy of the context which carries the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext - return the principal which is authenticated by the auth interceptor
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// SetAuthRequirement - declare the requirement of a full method, e.g. "/pkg.Service/Method", or of all methods of a service by "/pkg.Service/*"
+func SetAuthRequirement(method string, requirement AuthRequirement) {
+	authRequirements.Store(method, requirement)
+}
+
+// MARK: Private functions
+
+// authRequirement - return the declared requirement of the method, the requirement of the method takes precedence over the one of its service
+func authRequirement(method string) (AuthRequirement, bool) {
+	if requirement, ok := authRequirements.Load(method); ok {
+		return requirement.(AuthRequirement), true
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if requirement, ok := authRequirements.Load(method[:i] + "/*"); ok {
+			return requirement.(AuthRequirement), true
+		}
+	}
+	return AuthRequirement{}, false
+}
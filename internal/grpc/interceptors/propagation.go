@@ -0,0 +1,64 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MARK: Variables
+
+// DefaultPropagateMetadata - the metadata of the incoming call which are sent on the outgoing calls by default
+var DefaultPropagateMetadata = []string{defaultRequestIDMetadata, "x-correlation-id", "traceparent", "tracestate", "baggage"}
+
+// MARK: Public functions
+
+// PropagationUnaryClientInterceptor - send the metadata of the incoming call and the default metadata on the outgoing call
+// The request id of the incoming call is sent too, a new one is generated if there is none
+func PropagationUnaryClientInterceptor(keys []string, defaults map[string]string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(propagateMetadata(ctx, keys, defaults), method, req, reply, cc, opts...)
+	}
+}
+
+// PropagationStreamClientInterceptor - send the metadata of the incoming call and the default metadata on the outgoing stream
+func PropagationStreamClientInterceptor(keys []string, defaults map[string]string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(propagateMetadata(ctx, keys, defaults), desc, cc, method, opts...)
+	}
+}
+
+// MARK: Private functions
+
+// propagateMetadata - return the context with the outgoing metadata, the metadata which is set by the caller is kept
+func propagateMetadata(ctx context.Context, keys []string, defaults map[string]string) context.Context {
+	out, _ := metadata.FromOutgoingContext(ctx)
+	out = out.Copy()
+	incoming, _ := metadata.FromIncomingContext(ctx)
+
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if len(out.Get(key)) > 0 {
+			continue
+		}
+		if values := incoming.Get(key); len(values) > 0 {
+			out.Set(key, values...)
+		}
+	}
+	for key, value := range defaults {
+		if len(out.Get(key)) == 0 {
+			out.Set(key, value)
+		}
+	}
+
+	if len(out.Get(defaultRequestIDMetadata)) == 0 {
+		requestID := RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		out.Set(defaultRequestIDMetadata, requestID)
+	}
+	return metadata.NewOutgoingContext(ctx, out)
+}
@@ -0,0 +1,60 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Public functions
+
+// RecoveryUnaryInterceptor - recover the panics of the handlers, the call fails with the `Internal` code
+func RecoveryUnaryInterceptor(config *RecoveryInterceptorConfig) grpc.UnaryServerInterceptor {
+	withStack := config != nil && config.Stack
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, info.FullMethod, r, withStack)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor - recover the panics of the stream handlers, the stream fails with the `Internal` code
+func RecoveryStreamInterceptor(config *RecoveryInterceptorConfig) grpc.StreamServerInterceptor {
+	withStack := config != nil && config.Stack
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), info.FullMethod, r, withStack)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// MARK: Private functions
+
+// recovered - log the panic and return the error of the call, the panic value is not sent to the client
+func recovered(ctx context.Context, method string, r any, withStack bool) error {
+	l, _ := logger.GetManager().GetLoggerWithContext(ctx)
+	if l != nil {
+		details := map[string]interface{}{
+			"method": method,
+			"panic":  fmt.Sprint(r),
+		}
+		if withStack {
+			details["stack"] = string(debug.Stack())
+		}
+		l.Log(types.NewLogObject(types.ERROR, "grpc.interceptors.Recovery", GrpcCallLogType, time.Now(), "The handler panicked ...", details))
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/Blocktunium/gonyx/internal/httpclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MARK: Variables
+
+const defaultRequestIDMetadata = "x-request-id"
+
+// requestIDContextKey - the key of the request id in a context
+type requestIDContextKey struct{}
+
+// MARK: Public functions
+
+// RequestIDFromContext - return the request id of the call, or an empty string if it is not set by the interceptor
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// RequestIDUnaryInterceptor - take the request id of the metadata or generate one, it is put into the context and sent back in the header
+// The outbound calls of the http clients which are made with the context carry the request id
+func RequestIDUnaryInterceptor(config *RequestIDInterceptorConfig) grpc.UnaryServerInterceptor {
+	key := requestIDMetadata(config)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := withRequestID(ctx, key)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(key, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// RequestIDStreamInterceptor - take the request id of the metadata or generate one, it is put into the context and sent back in the header
+func RequestIDStreamInterceptor(config *RequestIDInterceptorConfig) grpc.StreamServerInterceptor {
+	key := requestIDMetadata(config)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestID(ss.Context(), key)
+		_ = ss.SetHeader(metadata.Pairs(key, requestID))
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// MARK: Private functions
+
+// requestIDMetadata - return the metadata key of the request id
+func requestIDMetadata(config *RequestIDInterceptorConfig) string {
+	if config != nil && config.Metadata != "" {
+		return config.Metadata
+	}
+	return defaultRequestIDMetadata
+}
+
+// withRequestID - return the context with the request id of the incoming metadata, a new one is generated if there is none
+func withRequestID(ctx context.Context, key string) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := ""
+	if values := md.Get(key); len(values) > 0 {
+		requestID = values[0]
+	}
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+
+	header := http.Header{}
+	header.Set(httpclient.RequestIDHeader, requestID)
+	ctx = httpclient.WithInboundHeaders(ctx, header)
+	return context.WithValue(ctx, requestIDContextKey{}, requestID), requestID
+}
+
+// newRequestID - return a random request id of 32 hex characters
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// LoggerInterceptorConfig - the config of the logger interceptor in `interceptors.logger`
+type LoggerInterceptorConfig struct {
+	SkipMethods []string `json:"skip_methods"` // the full methods which are not logged, e.g. "/grpc.health.v1.Health/Check"
+}
+
+// RecoveryInterceptorConfig - the config of the recovery interceptor in `interceptors.recovery`
+type RecoveryInterceptorConfig struct {
+	Stack bool `json:"stack"` // log the stack of the panic
+}
+
+// RequestIDInterceptorConfig - the config of the request id interceptor in `interceptors.requestid`
+type RequestIDInterceptorConfig struct {
+	Metadata string `json:"metadata"` // the metadata key of the request id, default is `x-request-id`
+}
+
+// MetricsInterceptorConfig - the config of the metrics interceptor in `interceptors.metrics`
+type MetricsInterceptorConfig struct {
+	Name string `json:"name"` // the expvar name of the metrics, default is `grpc_server`
+}
+
+// AuthInterceptorConfig - the config of the auth interceptor in `interceptors.auth`
+type AuthInterceptorConfig struct {
+	Metadata    string            `json:"metadata"`     // the metadata key of the api key, default is `x-api-key`
+	Keys        map[string]string `json:"keys"`         // the accepted api keys by the subject of their principal
+	JWT         JWTAuthConfig     `json:"jwt"`          // the bearer tokens of the `authorization` metadata
+	MTLS        bool              `json:"mtls"`         // accept the verified client certificates, the subject is their common name
+	Optional    bool              `json:"optional"`     // the methods without a declared requirement are called without credentials
+	SkipMethods []string          `json:"skip_methods"` // the full methods which are called without credentials
+}
+
+// JWTAuthConfig - the config of the bearer tokens, they are signed by HS256, HS384 or HS512
+type JWTAuthConfig struct {
+	Secret   string `json:"secret"`
+	Issuer   string `json:"issuer"`   // the `iss` claim which the tokens must have, it is not checked if empty
+	Audience string `json:"audience"` // the `aud` claim which the tokens must have, it is not checked if empty
+}
+
+// wrappedStream - a server stream with the context which is enriched by an interceptor
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context - return the enriched context
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
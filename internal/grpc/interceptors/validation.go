@@ -0,0 +1,56 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc"
+)
+
+// MARK: Variables
+
+// validatingStream - a server stream which validates the received messages
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg - receive the message and validate it
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+// MARK: Public functions
+
+// ValidationUnaryInterceptor - reject the invalid requests with the `InvalidArgument` code and the `BadRequest` details of their fields
+// The requests are validated by their `ValidateAll` or `Validate` method and the validators of utils.RegisterMessageValidator
+func ValidationUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ValidationStreamInterceptor - validate each received message of the stream like ValidationUnaryInterceptor
+func ValidationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+// MARK: Private functions
+
+// validate - validate the message, the violations of the fields are returned in the `BadRequest` details of the status
+func validate(m any) error {
+	violations, err := utils.ValidateMessage(m)
+	if err == nil {
+		return nil
+	}
+
+	return errs.ToStatus(errs.Wrap(errs.InvalidArgument, err, err.Error()).WithViolations(violations...)).Err()
+}
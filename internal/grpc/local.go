@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/Blocktunium/gonyx/internal/grpc/web"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MARK: Variables
+
+// localBufferSize - the buffer of the in-process connections
+const localBufferSize = 1024 * 1024
+
+// inProcessConn - a connection of the in-process listener, it is plaintext and it has no identity of a peer
+type inProcessConn struct {
+	net.Conn
+}
+
+// inProcessListener - the in-process listener which marks its connections, so the credentials of the server skip them
+type inProcessListener struct {
+	*bufconn.Listener
+}
+
+// Accept - accept the next connection and mark it as in-process
+func (l inProcessListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &inProcessConn{Conn: conn}, nil
+}
+
+// inProcessAuthInfo - the auth info of the in-process connections, the auth interceptor finds no principal in it
+type inProcessAuthInfo struct {
+	credentials.CommonAuthInfo
+}
+
+// AuthType - return the type of the auth info
+func (inProcessAuthInfo) AuthType() string {
+	return "in-process"
+}
+
+// inProcessCredentials - the transport credentials of a tls server which do not handshake the in-process connections
+type inProcessCredentials struct {
+	credentials.TransportCredentials
+}
+
+// ServerHandshake - pass the in-process connections as they are, the others are handshaked by the tls credentials
+func (c inProcessCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := rawConn.(*inProcessConn); ok {
+		return rawConn, inProcessAuthInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}, nil
+	}
+	return c.TransportCredentials.ServerHandshake(rawConn)
+}
+
+// Clone - return a copy of the credentials
+func (c inProcessCredentials) Clone() credentials.TransportCredentials {
+	return inProcessCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// MARK: Public functions
+
+// LocalConn - return the in-process connection to the server, e.g. for a gRPC-Gateway which is mounted on an http server
+// The calls pass through the interceptors of the server, they are served once the server is started
+// The connection is plaintext even if the server has tls and it presents no client certificate, so the calls are
+// authenticated only by the credentials in their metadata, e.g. the `authorization` header which the gateways forward
+// The connection is kept while the server is stopped, it reconnects when the server is started again
+func (s *ServerWrapper) LocalConn() (*grpc.ClientConn, error) {
+	s.localLock.Lock()
+	defer s.localLock.Unlock()
+
+	if s.localConn != nil {
+		return s.localConn, nil
+	}
+
+	conn, err := grpc.NewClient("passthrough:///"+s.name,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			// the listener is replaced when the server is started again
+			s.localLock.Lock()
+			localListener := s.localListener
+			s.localLock.Unlock()
+			if localListener == nil {
+				return nil, errors.New("the server is not started")
+			}
+			return localListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, NewGrpcDialError(s.name, err)
+	}
+	s.localConn = conn
+	return conn, nil
+}
+
+// CheckLocalExposure - return an error if the in-process connection cannot be exposed to the other clients, e.g. by a gateway
+// The in-process connection presents no client certificate, so a server which requires the client certificates
+// is exposed only if it authenticates the calls by the auth interceptor
+func (s *ServerWrapper) CheckLocalExposure() error {
+	s.lock.Lock()
+	config := s.config
+	s.lock.Unlock()
+
+	if config.TLS.CertFile == "" && config.TLS.KeyFile == "" {
+		return nil
+	}
+	clientAuth, _ := clientAuthType(config.TLS)
+	if clientAuth != tls.RequireAnyClientCert && clientAuth != tls.RequireAndVerifyClientCert {
+		return nil
+	}
+	if order := config.interceptorOrder(); utils.ArrayContains(&order, "auth") {
+		return nil
+	}
+	return NewLocalExposureErr(s.name)
+}
+
+// WebHandler - return the handler of the gRPC-Web and the Connect requests, the calls are made on the in-process connection
+// It is refused if the server requires the client certificates and has no auth interceptor
+func (s *ServerWrapper) WebHandler(prefix string) (http.Handler, error) {
+	if err := s.CheckLocalExposure(); err != nil {
+		return nil, err
+	}
+	conn, err := s.LocalConn()
+	if err != nil {
+		return nil, err
+	}
+	return web.NewHandler(conn, prefix), nil
+}
+
+// WebConfig - return the gRPC-Web and Connect config of the server
+func (s *ServerWrapper) WebConfig() WebConfig {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.config.Web
+}
@@ -2,10 +2,13 @@ package grpc
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/Blocktunium/gonyx/internal/config"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"google.golang.org/grpc"
 	"log"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -24,6 +27,26 @@ type manager struct {
 	lock      sync.Mutex
 	servers   map[string]*ServerWrapper
 	isStarted bool
+	errCh     chan error // the errors of the servers while they are serving
+
+	clients           map[string]ClientConfig     // the clients of the config and the ones added by the application
+	configuredClients map[string]struct{}         // the names of the clients of the config, they are updated on config change
+	clientConns       map[string]*grpc.ClientConn // the connections of the clients, they are created on the first use
+}
+
+// serverUpdate - the new config of a server
+type serverUpdate struct {
+	name   string
+	server *ServerWrapper
+	config ServerConfig
+}
+
+// pendingServers - the changes of the servers which are applied after the lock of the manager is released,
+// since stopping a server waits for its calls up to its shutdown timeout
+type pendingServers struct {
+	updated []serverUpdate
+	started map[string]*ServerWrapper
+	stopped []*ServerWrapper
 }
 
 // MARK: Module variables
@@ -40,21 +63,140 @@ func (m *manager) init() {
 	m.name = "protobuf"
 
 	m.lock.Lock()
-	defer m.lock.Unlock()
+	m.clients = make(map[string]ClientConfig)
+	m.clientConns = make(map[string]*grpc.ClientConn)
+	m.updateClients(m.readClients())
 
-	servers, err := config.GetManager().Get(m.name, "servers")
+	m.loadGzipLevel()
+	pending := m.loadServers()
+	m.lock.Unlock()
+	m.applyServers(pending)
+
+	// Config config server to reload
+	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	if err == nil {
+		wrapper.RegisterChangeCallback(func() interface{} {
+			// the changed servers are built again with their controllers, the started ones are restarted
+			m.lock.Lock()
+			m.loadGzipLevel()
+			pending := m.loadServers()
+			staleConns := m.updateClients(m.readClients())
+			m.lock.Unlock()
+
+			// the servers are stopped and started without the lock, so the clients and the servers can be used meanwhile
+			m.applyServers(pending)
+			// the connections of the changed clients are closed after the new ones are used by the next callers
+			m.closeClientConns(staleConns)
+			return nil
+		})
+	}
+}
+
+// loadGzipLevel - set the `gzip_level` of the config, the level of the gzip compressor is shared by all the servers and the clients
+func (m *manager) loadGzipLevel() {
+	value, err := config.GetManager().Get(m.name, "gzip_level")
 	if err != nil {
 		return
 	}
+	level, _ := value.(float64)
+	if err := setGzipLevel(int(level)); err != nil {
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "protobuf.manager", gRPCMaintenanceType, time.Now(), "Setting the gzip level failed, the previous level is kept ...", map[string]interface{}{"gzip_level": level, "error": err.Error()}))
+		} else {
+			log.Printf("Setting the gzip level failed, the previous level is kept: %v\n", err)
+		}
+	}
+}
 
-	serverArray := make([]string, len(servers.([]interface{})))
-	for i, v := range servers.([]interface{}) {
-		serverArray[i] = v.(string)
+// readClients - return the clients of the config by their names
+func (m *manager) readClients() map[string]ClientConfig {
+	result := make(map[string]ClientConfig)
+	clientsCfg, err := config.GetManager().Get(m.name, "clients")
+	if err != nil {
+		return result
 	}
 
-	m.servers = make(map[string]*ServerWrapper)
+	items, _ := clientsCfg.([]interface{})
+	for _, item := range items {
+		jsonBody, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+
+		var obj ClientConfig
+		if err := json.Unmarshal(jsonBody, &obj); err != nil || obj.Name == "" {
+			continue
+		}
+		result[obj.Name] = obj
+	}
+	return result
+}
 
-	for _, item := range serverArray {
+// updateClients - replace the clients of the config, the clients added by the application are kept
+// The connections of the changed and the removed clients are returned to be closed, the next callers get new connections
+func (m *manager) updateClients(configured map[string]ClientConfig) []*grpc.ClientConn {
+	if m.clients == nil {
+		m.clients = make(map[string]ClientConfig)
+	}
+
+	var staleConns []*grpc.ClientConn
+	dropConn := func(name string) {
+		if conn, ok := m.clientConns[name]; ok {
+			staleConns = append(staleConns, conn)
+			delete(m.clientConns, name)
+		}
+	}
+
+	for name := range m.configuredClients {
+		if _, ok := configured[name]; !ok {
+			delete(m.clients, name)
+			dropConn(name)
+		}
+	}
+	for name, clientConfig := range configured {
+		if current, ok := m.clients[name]; ok && reflect.DeepEqual(current, clientConfig) {
+			continue
+		}
+		m.clients[name] = clientConfig
+		dropConn(name)
+	}
+
+	m.configuredClients = make(map[string]struct{}, len(configured))
+	for name := range configured {
+		m.configuredClients[name] = struct{}{}
+	}
+	return staleConns
+}
+
+// closeClientConns - close the connections, the errors are logged
+func (m *manager) closeClientConns(conns []*grpc.ClientConn) {
+	l, _ := logger.GetManager().GetLogger()
+	for _, conn := range conns {
+		if err := conn.Close(); err != nil && l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "protobuf.manager.closeClientConns", gRPCMaintenanceType, time.Now(), "Cannot close the client connection ...", map[string]interface{}{"target": conn.Target(), "error": err.Error()}))
+		}
+	}
+}
+
+// loadServers - create the servers of the config, the lock must be held
+// The updates of the existing servers, the removed servers and the servers added while the others are started
+// are returned to be applied by applyServers, the listeners are opened by StartServers
+func (m *manager) loadServers() pendingServers {
+	pending := pendingServers{started: make(map[string]*ServerWrapper)}
+	if m.servers == nil {
+		m.servers = make(map[string]*ServerWrapper)
+	}
+
+	servers, err := config.GetManager().Get(m.name, "servers")
+	if err != nil {
+		return pending
+	}
+	serverArray, _ := servers.([]interface{})
+	configuredNames := make(map[string]struct{})
+
+	for _, v := range serverArray {
+		item, _ := v.(string)
 		conf, err := config.GetManager().Get(m.name, item)
 		if err != nil {
 			continue
@@ -66,21 +208,61 @@ func (m *manager) init() {
 		}
 
 		var obj ServerConfig
-		err = json.Unmarshal(jsonBody, &obj)
-		if err == nil {
-			s, err := NewServer(m.name+"."+item, obj)
-			if err == nil {
-				m.servers[item] = s
-			}
+		if err := json.Unmarshal(jsonBody, &obj); err != nil {
+			m.logServerError("Reading the gRPC server config failed ...", item, err)
+			continue
+		}
+		configuredNames[item] = struct{}{}
+
+		if s, ok := m.servers[item]; ok {
+			pending.updated = append(pending.updated, serverUpdate{name: item, server: s, config: obj})
+			continue
+		}
+
+		s, err := NewServer(m.name+"."+item, obj)
+		if err != nil {
+			m.logServerError("Creating the gRPC server failed ...", item, err)
+			continue
+		}
+		m.servers[item] = s
+		if m.isStarted {
+			pending.started[item] = s
 		}
 	}
 
-	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
-	if err == nil {
-		wrapper.RegisterChangeCallback(func() interface{} {
-			return nil
-		})
+	for name, s := range m.servers {
+		if _, ok := configuredNames[name]; !ok {
+			pending.stopped = append(pending.stopped, s)
+			delete(m.servers, name)
+		}
+	}
+	return pending
+}
+
+// applyServers - stop the removed servers, rebuild the changed ones and start the new ones, the lock must not be held
+func (m *manager) applyServers(pending pendingServers) {
+	for _, s := range pending.stopped {
+		s.Stop()
+	}
+	for _, item := range pending.updated {
+		if err := item.server.UpdateConfigs(item.config); err != nil {
+			m.logServerError("Updating the gRPC server config failed, the previous config is kept ...", item.name, err)
+		}
+	}
+	for name, s := range pending.started {
+		if err := s.Start(&m.errCh); err != nil {
+			m.logServerError("Starting the gRPC server failed ...", name, err)
+		}
+	}
+}
+
+// logServerError - report the error of the server, it is printed if the logger is not available
+func (m *manager) logServerError(message string, serverName string, err error) {
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(types.NewLogObject(types.ERROR, "protobuf.manager", gRPCMaintenanceType, time.Now(), message, map[string]interface{}{"server": serverName, "error": err.Error()}))
+	} else {
+		log.Printf("%v `%v`: %v\n", message, serverName, err)
 	}
 }
 
@@ -96,58 +278,122 @@ func GetManager() *manager {
 	return managerInstance
 }
 
-// StartServers - This function starts the gRPC servers
-func (m *manager) StartServers() {
+// StartServers - This function opens the listeners of the gRPC servers and serves them in the background
+// The servers which cannot be started are returned in the error, the later errors of serving are logged
+func (m *manager) StartServers() error {
 	l, _ := logger.GetManager().GetLogger()
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	ch := make(chan error, len(m.servers))
-
-	for _, item := range m.servers {
-		if item.IsInitialized() {
-			err := item.Start(&ch)
-			if err != nil {
+	if m.errCh == nil {
+		m.errCh = make(chan error, len(m.servers)+1)
+		go func(ch chan error) {
+			for err := range ch {
 				if l != nil {
-					l.Log(types.NewLogObject(types.ERROR, "protobuf.manager.StartServer", gRPCMaintenanceType, time.Now(), "Cannot start server ...", err))
+					l.Log(types.NewLogObject(types.ERROR, "protobuf.manager.StartServer", gRPCMaintenanceType, time.Now(), "Cannot serve the server ...", err))
 				}
 			}
-		}
+		}(m.errCh)
 	}
 
-	go func(ch1 *chan error) {
-		select {
-		case err := <-*ch1:
-			if l != nil {
-				l.Log(types.NewLogObject(types.ERROR, "protobuf.manager.StartServer", gRPCMaintenanceType, time.Now(), "Cannot start server ...", err))
+	var errs []error
+	for name, item := range m.servers {
+		if item.IsInitialized() {
+			if err := item.Start(&m.errCh); err != nil {
+				m.logServerError("Cannot start server ...", name, err)
+				errs = append(errs, err)
 			}
 		}
-	}(&ch)
+	}
 
 	if l != nil {
 		l.Log(types.NewLogObject(types.INFO, "protobuf.manager.StartServer", gRPCMaintenanceType, time.Now(), "gRPC Engine Started ...", nil))
 	}
 
 	m.isStarted = true
+	return errors.Join(errs...)
 }
 
-// StopServers - This function stops gRPC server
+// StopServers - This function stops gRPC servers gracefully, waits for all of them and closes the connections of the clients
 func (m *manager) StopServers() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	// the servers drain their calls in parallel
+	var wg sync.WaitGroup
 	for _, item := range m.servers {
-		item.Stop()
+		wg.Add(1)
+		go func(server *ServerWrapper) {
+			defer wg.Done()
+			server.Stop()
+		}(item)
 	}
+	wg.Wait()
 	m.isStarted = false
+
+	l, _ := logger.GetManager().GetLogger()
+	for name, conn := range m.clientConns {
+		if err := conn.Close(); err != nil && l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "protobuf.manager.StopServers", gRPCMaintenanceType, time.Now(), "Cannot close the client connection ...", map[string]interface{}{"client": name, "error": err.Error()}))
+		}
+		delete(m.clientConns, name)
+	}
 }
 
 // GetServerByName - get server instance by its name
 func (m *manager) GetServerByName(name string) (*ServerWrapper, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	if v, ok := m.servers[name]; ok {
 		return v, nil
 	}
 
 	return nil, NewGrpcServerNotExistError(name)
 }
+
+// GetClientConn - get the connection of the client by its name, the connection is shared by all the callers
+func (m *manager) GetClientConn(name string) (*grpc.ClientConn, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if conn, ok := m.clientConns[name]; ok {
+		return conn, nil
+	}
+	clientConfig, ok := m.clients[name]
+	if !ok {
+		return nil, NewGrpcClientNotExistError(name)
+	}
+
+	conn, err := NewClientConn(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	if m.clientConns == nil {
+		m.clientConns = make(map[string]*grpc.ClientConn)
+	}
+	m.clientConns[name] = conn
+	return conn, nil
+}
+
+// AddClient - add a client, e.g. a client whose address is discovered at runtime
+// The connection of a client with the same name is closed and replaced
+func (m *manager) AddClient(clientConfig ClientConfig) error {
+	if clientConfig.Name == "" {
+		return NewGrpcDialError(clientConfig.Target, errors.New("the name of the client is empty"))
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.clients == nil {
+		m.clients = make(map[string]ClientConfig)
+	}
+	m.clients[clientConfig.Name] = clientConfig
+	if conn, ok := m.clientConns[clientConfig.Name]; ok {
+		_ = conn.Close()
+		delete(m.clientConns, clientConfig.Name)
+	}
+	return nil
+}
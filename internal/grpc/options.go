@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+// MARK: Variables
+
+const (
+	defaultKeepaliveTime    = 15 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
+)
+
+// MARK: Private functions
+
+// limitOptions - return the options of the limits, the message sizes of the former `configs` are used if they are not set
+func limitOptions(limits ServerLimitsConfig, configs map[string]interface{}) []grpc.ServerOption {
+	if limits.MaxReceiveMessageSize == 0 {
+		limits.MaxReceiveMessageSize = legacyConfigInt(configs, "max_receive_message_size")
+	}
+	if limits.MaxSendMessageSize == 0 {
+		limits.MaxSendMessageSize = legacyConfigInt(configs, "max_send_message_size")
+	}
+
+	var options []grpc.ServerOption
+	if limits.MaxReceiveMessageSize > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(limits.MaxReceiveMessageSize))
+	}
+	if limits.MaxSendMessageSize > 0 {
+		options = append(options, grpc.MaxSendMsgSize(limits.MaxSendMessageSize))
+	}
+	if limits.MaxConcurrentStreams > 0 {
+		options = append(options, grpc.MaxConcurrentStreams(limits.MaxConcurrentStreams))
+	}
+	if limits.ConnectionTimeout > 0 {
+		options = append(options, grpc.ConnectionTimeout(milliseconds(limits.ConnectionTimeout)))
+	}
+	if limits.InitialWindowSize > 0 {
+		options = append(options, grpc.InitialWindowSize(limits.InitialWindowSize))
+	}
+	if limits.InitialConnWindowSize > 0 {
+		options = append(options, grpc.InitialConnWindowSize(limits.InitialConnWindowSize))
+	}
+	return options
+}
+
+// keepaliveOptions - return the options of the keepalive parameters and the enforcement policy
+func keepaliveOptions(config ServerKeepaliveConfig) []grpc.ServerOption {
+	params := keepalive.ServerParameters{
+		Time:                  defaultKeepaliveTime,
+		Timeout:               defaultKeepaliveTimeout,
+		MaxConnectionIdle:     milliseconds(config.MaxConnectionIdle),
+		MaxConnectionAge:      milliseconds(config.MaxConnectionAge),
+		MaxConnectionAgeGrace: milliseconds(config.MaxConnectionAgeGrace),
+	}
+	if config.Time > 0 {
+		params.Time = milliseconds(config.Time)
+	}
+	if config.Timeout > 0 {
+		params.Timeout = milliseconds(config.Timeout)
+	}
+
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(params),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             milliseconds(config.Enforcement.MinTime),
+			PermitWithoutStream: config.Enforcement.PermitWithoutStream,
+		}),
+	}
+}
+
+// compressionInterceptors - return the interceptors which compress the responses in the encoding of the config
+// They are nil if no encoding is set
+func compressionInterceptors(config ServerCompressionConfig) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error) {
+	if config.Encoding == "" {
+		return nil, nil, nil
+	}
+
+	name := strings.ToLower(config.Encoding)
+	if encoding.GetCompressor(name) == nil {
+		return nil, nil, fmt.Errorf("the compression `%v` is not registered", config.Encoding)
+	}
+
+	// the responses of the clients which do not accept the encoding are not compressed
+	setCompressor := func(ctx context.Context) {
+		accepted, _ := grpc.ClientSupportedCompressors(ctx)
+		if utils.ArrayContains(&accepted, name) {
+			_ = grpc.SetSendCompressor(ctx, name)
+		}
+	}
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		setCompressor(ctx)
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		setCompressor(ss.Context())
+		return handler(srv, ss)
+	}
+	return unary, stream, nil
+}
+
+// setGzipLevel - set the level of the gzip compressor, it is global in the process, so it is of the `gzip_level` of the protobuf config
+// Zero keeps the current level
+func setGzipLevel(level int) error {
+	if level == 0 {
+		return nil
+	}
+	return gzip.SetLevel(level)
+}
+
+// legacyConfigInt - return the number of the key in the former `configs`, the keys are compared without the casing and the underscores
+func legacyConfigInt(configs map[string]interface{}, key string) int {
+	normalize := func(value string) string {
+		return strings.ToLower(strings.ReplaceAll(value, "_", ""))
+	}
+	for k, v := range configs {
+		if normalize(k) != normalize(key) {
+			continue
+		}
+		switch value := v.(type) {
+		case float64:
+			return int(value)
+		case int:
+			return value
+		}
+	}
+	return 0
+}
+
+// milliseconds - return the duration of the milliseconds
+func milliseconds(value int) time.Duration {
+	return time.Duration(value) * time.Millisecond
+}
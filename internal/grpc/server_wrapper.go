@@ -1,13 +1,22 @@
 package grpc
 
 import (
-	"fmt"
+	"encoding/json"
+	"github.com/Blocktunium/gonyx/internal/grpc/interceptors"
+	"github.com/Blocktunium/gonyx/internal/i18n"
+	"github.com/Blocktunium/gonyx/internal/listener"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/utils"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 	"net"
+	"reflect"
+	"sync"
 	"time"
 )
 
@@ -17,6 +26,36 @@ var (
 	ServerMaintenanceType = types.NewLogType("PROTOBUF_SERVER_MAINTENANCE")
 )
 
+const defaultShutdownTimeout = 30 * time.Second
+
+// ServerState - the state of the lifecycle of a server
+type ServerState int
+
+const (
+	ServerCreated  ServerState = iota // the server is built, its listener is opened by Start
+	ServerServing                     // the listener is open and the calls are served
+	ServerStopping                    // the in-flight calls are drained
+	ServerStopped                     // the server is stopped, it is built again by Start
+	ServerFailed                      // the listener cannot be opened or it is failed while serving
+)
+
+// String - return the name of the state
+func (s ServerState) String() string {
+	switch s {
+	case ServerCreated:
+		return "created"
+	case ServerServing:
+		return "serving"
+	case ServerStopping:
+		return "stopping"
+	case ServerStopped:
+		return "stopped"
+	case ServerFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
 // ServerWrapper struct
 type ServerWrapper struct {
 	name        string
@@ -24,60 +63,196 @@ type ServerWrapper struct {
 	listener    net.Listener
 	initialized bool
 	config      ServerConfig
-	//authObj     *auth.Authentication
-	//authEnable bool
+
+	lock          sync.Mutex                  // guards the state and the grpc server while it is built again
+	state         ServerState                 // the state of the lifecycle
+	errCh         *chan error                 // the errors of serving, it is given by the first Start
+	registrations []func(server *grpc.Server) // the controllers of the server, they are registered again when the server is built again
+
+	healthServer  *health.Server       // the grpc.health.v1.Health service, it reports the services as serving while the server is started
+	tlsReloader   *certificateReloader // the certificate of the server, it is nil if the server is plaintext
+	localListener *bufconn.Listener    // the in-process listener of the gateways
+	localLock     sync.Mutex
+	localConn     *grpc.ClientConn
 }
 
-// init - initialize the ServerWrapper with the configs
+// init - initialize the ServerWrapper with the configs, the listener is opened by Start
 func (s *ServerWrapper) init(name string, serverConfig ServerConfig) error {
 	s.name = name
 	s.initialized = false
 
 	s.config = serverConfig
 
-	lis, err := net.Listen(s.config.Protocol, fmt.Sprintf("%s:%d", s.config.Host, s.config.Port))
+	grpcServer, healthServer, err := s.build()
 	if err != nil {
 		return err
 	}
+	s.grpcServer = grpcServer
+	s.healthServer = healthServer
+	s.state = ServerCreated
 
-	//authEnable, err := config.GetManager().Get(parts[0], parts[1]+".auth_enable")
-	//if err != nil {
-	//	return err
-	//}
-	//s.authEnable = authEnable.(bool)
-
-	//s.authObj = &auth.Authentication{}
-	//s.authObj.Init()
+	s.initialized = true
+	return nil
+}
 
-	options := s.generateConfigs(s.config.Configs)
+// build - create the grpc server of the config and register the health service, the reflection and the controllers on it
+func (s *ServerWrapper) build() (*grpc.Server, *health.Server, error) {
+	options, err := s.generateConfigs(s.config.Configs)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	s.listener = lis
-	s.grpcServer = grpc.NewServer(options...)
+	grpcServer := grpc.NewServer(options...)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	if s.config.Reflection {
-		reflection.Register(s.grpcServer)
+		reflection.Register(grpcServer)
 	}
-
-	s.initialized = true
-	return nil
+	for _, f := range s.registrations {
+		f(grpcServer)
+	}
+	return grpcServer, healthServer, nil
 }
 
 // generateConfigs - generate grpc.ServerOption array from configs
-func (s *ServerWrapper) generateConfigs(configs map[string]interface{}) []grpc.ServerOption {
-	var options []grpc.ServerOption
-	if v, ok := configs["maxreceivemessagesize"]; ok {
-		options = append(options, grpc.MaxRecvMsgSize(int(v.(float64))))
+func (s *ServerWrapper) generateConfigs(configs map[string]interface{}) ([]grpc.ServerOption, error) {
+	options := limitOptions(s.config.Limits, configs)
+	options = append(options, keepaliveOptions(s.config.Keepalive)...)
+
+	if s.config.TLS.CertFile != "" || s.config.TLS.KeyFile != "" {
+		reloader, err := newCertificateReloader(s.name, s.config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		s.tlsReloader = reloader
+		// the in-process connections of the gateways are not handshaked, see LocalConn
+		options = append(options, grpc.Creds(inProcessCredentials{TransportCredentials: credentials.NewTLS(reloader.tlsConfig())}))
 	}
 
-	if v, ok := configs["maxsendmessagesize"]; ok {
-		options = append(options, grpc.MaxSendMsgSize(int(v.(float64))))
+	// `i18n` enables the i18n interceptor even if it is not listed in the order
+	interceptorOrder := s.config.interceptorOrder()
+	if s.config.I18n && !utils.ArrayContains(&interceptorOrder, "i18n") {
+		interceptorOrder = append(interceptorOrder, "i18n")
+	}
+
+	unary, stream, err := s.createInterceptors(interceptorOrder, s.config.Interceptors)
+	if err != nil {
+		return nil, err
+	}
+
+	compressionUnary, compressionStream, err := compressionInterceptors(s.config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if compressionUnary != nil {
+		unary = append(unary, compressionUnary)
+		stream = append(stream, compressionStream)
 	}
 
-	options = append(options, grpc.KeepaliveParams(keepalive.ServerParameters{
-		Time:    15 * time.Minute,
-		Timeout: 20 * time.Second,
-	}))
+	// the errors of the handlers are converted to their status first, so the other interceptors see their codes
+	unary = append(unary, interceptors.ErrorsUnaryInterceptor())
+	stream = append(stream, interceptors.ErrorsStreamInterceptor())
+	options = append(options, grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
 
-	return options
+	return options, nil
+}
+
+// createInterceptors - create the built-in and registered interceptors in the order of the config
+func (s *ServerWrapper) createInterceptors(orders []string, rawConfig map[string]interface{}) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+
+	for _, item := range orders {
+		if !utils.ArrayContains(&builtinInterceptors, item) {
+			factory, ok := getInterceptorFactory(item)
+			if !ok {
+				return nil, nil, NewUnknownInterceptorErr(item)
+			}
+
+			interceptorConfig, _ := rawConfig[item].(map[string]interface{})
+			unaryInterceptor, streamInterceptor, err := factory(interceptorConfig)
+			if err != nil {
+				return nil, nil, NewInterceptorConfigErr(item, err)
+			}
+			if unaryInterceptor != nil {
+				unary = append(unary, unaryInterceptor)
+			}
+			if streamInterceptor != nil {
+				stream = append(stream, streamInterceptor)
+			}
+			continue
+		}
+
+		switch item {
+		case "logger":
+			var obj interceptors.LoggerInterceptorConfig
+			if err := decodeInterceptorConfig(rawConfig, item, &obj); err != nil {
+				return nil, nil, err
+			}
+			unary = append(unary, interceptors.LoggerUnaryInterceptor(&obj))
+			stream = append(stream, interceptors.LoggerStreamInterceptor(&obj))
+		case "recovery":
+			var obj interceptors.RecoveryInterceptorConfig
+			if err := decodeInterceptorConfig(rawConfig, item, &obj); err != nil {
+				return nil, nil, err
+			}
+			unary = append(unary, interceptors.RecoveryUnaryInterceptor(&obj))
+			stream = append(stream, interceptors.RecoveryStreamInterceptor(&obj))
+		case "requestid":
+			var obj interceptors.RequestIDInterceptorConfig
+			if err := decodeInterceptorConfig(rawConfig, item, &obj); err != nil {
+				return nil, nil, err
+			}
+			unary = append(unary, interceptors.RequestIDUnaryInterceptor(&obj))
+			stream = append(stream, interceptors.RequestIDStreamInterceptor(&obj))
+		case "metrics":
+			var obj interceptors.MetricsInterceptorConfig
+			if err := decodeInterceptorConfig(rawConfig, item, &obj); err != nil {
+				return nil, nil, err
+			}
+			unary = append(unary, interceptors.MetricsUnaryInterceptor(&obj))
+			stream = append(stream, interceptors.MetricsStreamInterceptor(&obj))
+		case "auth":
+			var obj interceptors.AuthInterceptorConfig
+			if err := decodeInterceptorConfig(rawConfig, item, &obj); err != nil {
+				return nil, nil, err
+			}
+			unaryInterceptor, err := interceptors.AuthUnaryInterceptor(&obj)
+			if err != nil {
+				return nil, nil, NewInterceptorConfigErr(item, err)
+			}
+			streamInterceptor, err := interceptors.AuthStreamInterceptor(&obj)
+			if err != nil {
+				return nil, nil, NewInterceptorConfigErr(item, err)
+			}
+			unary = append(unary, unaryInterceptor)
+			stream = append(stream, streamInterceptor)
+		case "validation":
+			unary = append(unary, interceptors.ValidationUnaryInterceptor())
+			stream = append(stream, interceptors.ValidationStreamInterceptor())
+		case "i18n":
+			unary = append(unary, i18n.UnaryServerInterceptor())
+			stream = append(stream, i18n.StreamServerInterceptor())
+		}
+	}
+	return unary, stream, nil
+}
+
+// decodeInterceptorConfig - decode `interceptors.<name>` into obj, it is left as it is if the config is absent
+func decodeInterceptorConfig(rawConfig map[string]interface{}, name string, obj interface{}) error {
+	raw, ok := rawConfig[name]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	jsonBody, err := json.Marshal(raw)
+	if err != nil {
+		return NewInterceptorConfigErr(name, err)
+	}
+	if err := json.Unmarshal(jsonBody, obj); err != nil {
+		return NewInterceptorConfigErr(name, err)
+	}
+	return nil
 }
 
 // MARK: Public functions
@@ -93,35 +268,174 @@ func NewServer(name string, config ServerConfig) (*ServerWrapper, error) {
 	return server, nil
 }
 
-// Start - start the server with option of async capability
+// Start - open the listener and serve it in the background, the error of the listener is returned
+// The later errors of serving are sent to the channel, the server is built again if it is stopped or failed
 func (s *ServerWrapper) Start(ch *chan error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch s.state {
+	case ServerServing:
+		return nil
+	case ServerStopping:
+		return NewServerStateErr(s.name, "start", s.state)
+	case ServerStopped, ServerFailed:
+		grpcServer, healthServer, err := s.build()
+		if err != nil {
+			s.state = ServerFailed
+			return NewGrpcServerStartError(err)
+		}
+		s.grpcServer = grpcServer
+		s.healthServer = healthServer
+	}
+	if ch != nil {
+		s.errCh = ch
+	}
+
+	lis, err := listener.Listen(s.config.Protocol, s.config.address(), s.config.Socket)
+	if err != nil {
+		s.state = ServerFailed
+		return NewGrpcServerStartError(err)
+	}
+	s.listener = lis
+
 	l, _ := logger.GetManager().GetLogger()
 	if l != nil {
 		l.Log(types.NewLogObject(types.INFO, "protobuf.Server.Start", ServerMaintenanceType, time.Now(), "Starting the gRPC server ...", s.listener))
 	}
 
-	if s.config.Async {
-		go func(ch1 *chan error) {
-			err := s.grpcServer.Serve(s.listener)
-			if err != nil && ch1 != nil {
-				*ch <- NewGrpcServerStartError(err)
-			}
-		}(ch)
-	} else {
-		err := s.grpcServer.Serve(s.listener)
-		if err != nil {
-			return NewGrpcServerStartError(err)
+	s.setServing()
+
+	// the in-process listener of the gateways is served beside the listener of the config
+	localListener := bufconn.Listen(localBufferSize)
+	s.localLock.Lock()
+	s.localListener = localListener
+	s.localLock.Unlock()
+
+	grpcServer := s.grpcServer
+	go func() {
+		_ = grpcServer.Serve(inProcessListener{Listener: localListener})
+	}()
+	go func(errCh *chan error) {
+		// Serve returns no error if the server is stopped
+		err := grpcServer.Serve(lis)
+		if err == nil {
+			return
 		}
-	}
 
+		s.lock.Lock()
+		if s.grpcServer == grpcServer {
+			s.state = ServerFailed
+		}
+		s.lock.Unlock()
+
+		if l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "protobuf.Server.Start", ServerMaintenanceType, time.Now(), "The gRPC server is failed ...", map[string]interface{}{"server": s.name, "error": err.Error()}))
+		}
+		if errCh != nil {
+			*errCh <- NewGrpcServerStartError(err)
+		}
+	}(s.errCh)
+
+	s.state = ServerServing
 	return nil
 }
 
-// Stop - stop the server
+// Stop - stop the server gracefully
+// The services are reported as not serving at once, the in-flight calls are cancelled if they do not finish in the shutdown timeout
 func (s *ServerWrapper) Stop() {
-	if s.grpcServer != nil {
-		s.grpcServer.Stop()
+	s.lock.Lock()
+	if s.state != ServerServing && s.state != ServerFailed {
+		s.lock.Unlock()
+		return
+	}
+	s.state = ServerStopping
+	grpcServer, healthServer := s.grpcServer, s.healthServer
+	timeout := defaultShutdownTimeout
+	if s.config.ShutdownTimeout != 0 {
+		timeout = time.Duration(max(s.config.ShutdownTimeout, 0)) * time.Millisecond
 	}
+	s.lock.Unlock()
+
+	healthServer.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(types.NewLogObject(types.WARNING, "protobuf.Server.Stop", ServerMaintenanceType, time.Now(), "The in-flight calls are cancelled after the shutdown timeout ...", s.name))
+		}
+		grpcServer.Stop()
+		<-done
+	}
+
+	s.lock.Lock()
+	s.state = ServerStopped
+	s.lock.Unlock()
+}
+
+// UpdateConfigs - build the server again with the new config, a serving server is stopped and started with it
+// The server is kept as it is if the new config is not valid
+func (s *ServerWrapper) UpdateConfigs(serverConfig ServerConfig) error {
+	s.lock.Lock()
+	if reflect.DeepEqual(s.config, serverConfig) {
+		s.lock.Unlock()
+		return nil
+	}
+
+	previousConfig, previousReloader := s.config, s.tlsReloader
+	s.config = serverConfig
+	grpcServer, healthServer, err := s.build()
+	if err != nil {
+		s.config, s.tlsReloader = previousConfig, previousReloader
+		s.lock.Unlock()
+		return NewCreateServerErr(err)
+	}
+	registered := len(s.registrations)
+	serving := s.state == ServerServing
+	s.lock.Unlock()
+
+	if serving {
+		s.Stop()
+	}
+
+	s.lock.Lock()
+	// the controllers which are registered while the server is stopped
+	for _, f := range s.registrations[registered:] {
+		f(grpcServer)
+	}
+	s.grpcServer = grpcServer
+	s.healthServer = healthServer
+	s.state = ServerCreated
+	s.lock.Unlock()
+
+	if serving {
+		return s.Start(nil)
+	}
+	return nil
+}
+
+// State - return the state of the lifecycle of the server
+func (s *ServerWrapper) State() ServerState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state
+}
+
+// HealthServer - return the grpc.health.v1.Health service of the server, e.g. to report a service as not serving while its dependency is down
+func (s *ServerWrapper) HealthServer() *health.Server {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.healthServer
 }
 
 // IsInitialized - return whether the server is started or not
@@ -129,15 +443,46 @@ func (s *ServerWrapper) IsInitialized() bool {
 	return s.initialized
 }
 
+// Register - register the controllers on the grpc server by the function, it is called again when the server is built again
+// The controllers cannot be registered while the server is serving
+func (s *ServerWrapper) Register(f func(server *grpc.Server)) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.state == ServerServing || s.state == ServerStopping {
+		return NewServerStateErr(s.name, "register a controller on", s.state)
+	}
+	s.registrations = append(s.registrations, f)
+	f(s.grpcServer)
+	return nil
+}
+
 func (s *ServerWrapper) RegisterController(desc *grpc.ServiceDesc, realClass interface{}) error {
 	if realClass != nil {
-		s.grpcServer.RegisterService(desc, realClass)
-		return nil
+		return s.Register(func(server *grpc.Server) {
+			server.RegisterService(desc, realClass)
+		})
 	}
 
 	return NewNilServiceRegistryError()
 }
 
+// GetGrpcServer - return the current grpc server, the services which are registered on it directly are lost when it is built again
 func (s *ServerWrapper) GetGrpcServer() *grpc.Server {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	return s.grpcServer
 }
+
+// setServing - report the server and its registered services as serving
+func (s *ServerWrapper) setServing() {
+	if s.healthServer == nil {
+		return
+	}
+
+	s.healthServer.Resume()
+	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for name := range s.grpcServer.GetServiceInfo() {
+		s.healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+}
@@ -1,8 +1,19 @@
 package grpc
 
 import (
-	"github.com/Blocktunium/gonyx/internal/config"
+	"context"
+	"net"
 	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestServerWrapper_Init(t *testing.T) {
@@ -36,3 +47,268 @@ func makeReadyConfigManager() {
 
 	_ = config.CreateManager(path, initialMode, prefix)
 }
+
+func TestServerWrapper_Interceptors(t *testing.T) {
+	makeReadyConfigManager()
+
+	var calls []string
+	err := RegisterInterceptor("audit", func(rawConfig map[string]interface{}) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error) {
+		calls = append(calls, rawConfig["target"].(string))
+		return nil, nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Registering interceptor --> Expected: %v, but got %v", nil, err)
+	}
+	if err := RegisterInterceptor("audit", nil); err == nil {
+		t.Errorf("Registering nil factory --> Expected an error, but got %v", err)
+	}
+	if err := RegisterInterceptor("logger", func(map[string]interface{}) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error) {
+		return nil, nil, nil
+	}); err == nil {
+		t.Errorf("Registering built-in name --> Expected an error, but got %v", err)
+	}
+
+	s := &ServerWrapper{config: ServerConfig{I18n: true}}
+	unary, stream, err := s.createInterceptors([]string{"requestid", "audit", "recovery"}, map[string]interface{}{
+		"audit":    map[string]interface{}{"target": "db"},
+		"recovery": map[string]interface{}{"stack": true},
+	})
+	if err != nil || len(unary) != 2 || len(stream) != 2 || len(calls) != 1 || calls[0] != "db" {
+		t.Errorf("Creating interceptors --> Expected: %v %v %v, but got %v %v %v", 2, 2, "[db]", len(unary), len(stream), calls)
+	}
+
+	if _, _, err := s.createInterceptors([]string{"unknown"}, nil); err == nil {
+		t.Errorf("Unknown interceptor --> Expected an error, but got %v", err)
+	}
+	if _, _, err := s.createInterceptors([]string{"auth"}, nil); err == nil {
+		t.Errorf("Auth without keys --> Expected an error, but got %v", err)
+	}
+}
+
+func TestServerWrapper_LocalConn(t *testing.T) {
+	makeReadyConfigManager()
+
+	server, err := NewServer("protobuf.local", ServerConfig{
+		Host:         "127.0.0.1",
+		Port:         0,
+		Protocol:     "tcp",
+		Async:        true,
+		Interceptors: map[string]interface{}{"order": []interface{}{"requestid"}},
+	})
+	if err != nil {
+		t.Fatalf("Creating gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	registerEchoService(server.GetGrpcServer())
+	_ = server.Start(nil)
+	defer server.Stop()
+
+	conn, err := server.LocalConn()
+	if err != nil {
+		t.Fatalf("Local connection --> Expected: %v, but got %v", nil, err)
+	}
+	if again, _ := server.LocalConn(); again != conn {
+		t.Errorf("Second local connection --> Expected: %v, but got %v", conn, again)
+	}
+
+	// the call passes through the interceptors of the server
+	var header metadata.MD
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = conn.Invoke(ctx, "/test.Service/Echo", &wrapperspb.BytesValue{}, &wrapperspb.BytesValue{}, grpc.Header(&header))
+	if err != nil {
+		t.Errorf("Call of local connection --> Expected: %v, but got %v", nil, err)
+	}
+	if len(header.Get("x-request-id")) != 1 {
+		t.Errorf("Request id header --> Expected: %v, but got %v", 1, header.Get("x-request-id"))
+	}
+}
+
+func TestServerWrapper_Options(t *testing.T) {
+	makeReadyConfigManager()
+
+	legacy := map[string]interface{}{"maxReceiveMessageSize": float64(10), "max_send_message_size": 20}
+	if got := legacyConfigInt(legacy, "max_receive_message_size"); got != 10 {
+		t.Errorf("Camel case legacy key --> Expected: %v, but got %v", 10, got)
+	}
+	if got := legacyConfigInt(map[string]interface{}{"maxsendmessagesize": float64(20)}, "max_send_message_size"); got != 20 {
+		t.Errorf("Lower case legacy key --> Expected: %v, but got %v", 20, got)
+	}
+
+	if _, _, err := compressionInterceptors(ServerCompressionConfig{Encoding: "zstd"}); err == nil {
+		t.Errorf("Unknown compression --> Expected an error, but got %v", err)
+	}
+	if err := setGzipLevel(42); err == nil {
+		t.Errorf("Invalid gzip level --> Expected an error, but got %v", err)
+	}
+
+	server, err := NewServer("protobuf.options", ServerConfig{
+		Host:        "127.0.0.1",
+		Port:        0,
+		Protocol:    "tcp",
+		Async:       true,
+		Limits:      ServerLimitsConfig{MaxReceiveMessageSize: 1024, MaxConcurrentStreams: 10, ConnectionTimeout: 5000},
+		Keepalive:   ServerKeepaliveConfig{Time: 60000, MaxConnectionAge: 60000, Enforcement: ServerKeepaliveEnforcementConfig{MinTime: 10000}},
+		Compression: ServerCompressionConfig{Encoding: "gzip"},
+	})
+	if err != nil {
+		t.Fatalf("Creating gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	registerEchoService(server.GetGrpcServer())
+	_ = server.Start(nil)
+	defer server.Stop()
+
+	conn, _ := server.LocalConn()
+	call := func(size int, options ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return conn.Invoke(ctx, "/test.Service/Echo", &wrapperspb.BytesValue{Value: make([]byte, size)}, &wrapperspb.BytesValue{}, options...)
+	}
+
+	if err := call(100, grpc.UseCompressor(gzip.Name)); err != nil {
+		t.Errorf("Compressed call --> Expected: %v, but got %v", nil, err)
+	}
+	if err := call(2048); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Call over the receive limit --> Expected: %v, but got %v", codes.ResourceExhausted, err)
+	}
+}
+
+func TestServerWrapper_GracefulStop(t *testing.T) {
+	makeReadyConfigManager()
+
+	for _, item := range []struct {
+		timeout  int
+		expected codes.Code
+	}{{0, codes.OK}, {-1, codes.Unavailable}} {
+		server, err := NewServer("protobuf.graceful", ServerConfig{Host: "127.0.0.1", Port: 0, Protocol: "tcp", Async: true, ShutdownTimeout: item.timeout})
+		if err != nil {
+			t.Fatalf("Creating gRPC Server --> Expected: %v, but got %v", nil, err)
+		}
+		registerEchoService(server.GetGrpcServer())
+		_ = server.Start(nil)
+
+		check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+			response, err := server.HealthServer().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				return healthpb.HealthCheckResponse_UNKNOWN
+			}
+			return response.Status
+		}
+		if got := check("test.Service"); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Health of registered service --> Expected: %v, but got %v", healthpb.HealthCheckResponse_SERVING, got)
+		}
+
+		conn, _ := server.LocalConn()
+		result := make(chan error, 1)
+		go func() {
+			result <- conn.Invoke(context.Background(), "/test.Service/Echo", &wrapperspb.BytesValue{Value: []byte("slow")}, &wrapperspb.BytesValue{})
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		server.Stop()
+		if got := check(""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Health after stop --> Expected: %v, but got %v", healthpb.HealthCheckResponse_NOT_SERVING, got)
+		}
+		if err := <-result; status.Code(err) != item.expected {
+			t.Errorf("In-flight call with shutdown timeout %v --> Expected: %v, but got %v", item.timeout, item.expected, err)
+		}
+	}
+}
+
+func TestServerWrapper_Lifecycle(t *testing.T) {
+	makeReadyConfigManager()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Opening a listener --> Expected: %v, but got %v", nil, err)
+	}
+	defer busy.Close()
+	port := busy.Addr().(*net.TCPAddr).Port
+
+	// the listener is opened by Start, so the busy port fails there
+	server, err := NewServer("protobuf.lifecycle", ServerConfig{Host: "127.0.0.1", Port: port, Protocol: "tcp"})
+	if err != nil {
+		t.Fatalf("Creating gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	if err := server.Register(registerEchoService); err != nil {
+		t.Fatalf("Registering controller --> Expected: %v, but got %v", nil, err)
+	}
+	if err := server.Start(nil); err == nil || server.State() != ServerFailed {
+		t.Errorf("Starting on busy port --> Expected: %v, but got %v %v", ServerFailed, server.State(), err)
+	}
+
+	echo := func() error {
+		conn, _ := server.LocalConn()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return conn.Invoke(ctx, "/test.Service/Echo", &wrapperspb.BytesValue{}, &wrapperspb.BytesValue{}, grpc.WaitForReady(true))
+	}
+
+	if err := server.UpdateConfigs(ServerConfig{Host: "127.0.0.1", Port: 0, Protocol: "tcp"}); err != nil {
+		t.Fatalf("Updating gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	if err := server.Start(nil); err != nil || server.State() != ServerServing {
+		t.Fatalf("Starting gRPC Server --> Expected: %v, but got %v %v", ServerServing, server.State(), err)
+	}
+	if err := server.Register(registerEchoService); err == nil {
+		t.Errorf("Registering while serving --> Expected an error, but got %v", err)
+	}
+	if err := echo(); err != nil {
+		t.Errorf("Call of started server --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the controllers are registered again when the server is rebuilt
+	server.Stop()
+	if server.State() != ServerStopped {
+		t.Errorf("Stopping gRPC Server --> Expected: %v, but got %v", ServerStopped, server.State())
+	}
+	if err := server.Start(nil); err != nil {
+		t.Fatalf("Restarting gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	if err := echo(); err != nil {
+		t.Errorf("Call of restarted server --> Expected: %v, but got %v", nil, err)
+	}
+
+	invalid := ServerConfig{Host: "127.0.0.1", Port: 0, Protocol: "tcp", Interceptors: map[string]interface{}{"order": []interface{}{"unknown"}}}
+	if err := server.UpdateConfigs(invalid); err == nil || server.State() != ServerServing {
+		t.Errorf("Updating with invalid config --> Expected: %v, but got %v %v", ServerServing, server.State(), err)
+	}
+	if err := server.UpdateConfigs(ServerConfig{Host: "127.0.0.1", Port: 0, Protocol: "tcp", ShutdownTimeout: 1000}); err != nil || server.State() != ServerServing {
+		t.Errorf("Updating serving server --> Expected: %v, but got %v %v", ServerServing, server.State(), err)
+	}
+	if err := echo(); err != nil {
+		t.Errorf("Call of updated server --> Expected: %v, but got %v", nil, err)
+	}
+	server.Stop()
+}
+
+// registerEchoService - register the `test.Service/Echo` method which replies the request
+func registerEchoService(server *grpc.Server) {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Service",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Echo",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := &wrapperspb.BytesValue{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					// a "slow" request is an in-flight call while the server is stopped
+					if string(req.(*wrapperspb.BytesValue).Value) == "slow" {
+						select {
+						case <-time.After(300 * time.Millisecond):
+						case <-ctx.Done():
+							return nil, ctx.Err()
+						}
+					}
+					return req, nil
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Echo"}, handler)
+			},
+		}},
+	}, struct{}{})
+}
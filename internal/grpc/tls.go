@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+)
+
+// MARK: Variables
+
+const defaultTLSReloadInterval = 30 * time.Second
+
+// certificateReloader - the certificate and the client CAs of a server, they are reloaded when their files are changed
+type certificateReloader struct {
+	name     string
+	config   TLSConfig
+	interval time.Duration
+
+	lock        sync.Mutex
+	checkedAt   time.Time
+	modTimes    []time.Time
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// MARK: Private functions
+
+// newCertificateReloader - load the files of the config, the server is not created if they are not valid
+func newCertificateReloader(name string, config TLSConfig) (*certificateReloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("the tls needs both cert_file and key_file")
+	}
+	if _, err := clientAuthType(config); err != nil {
+		return nil, err
+	}
+
+	r := &certificateReloader{name: name, config: config, interval: defaultTLSReloadInterval}
+	if config.ReloadInterval != 0 {
+		r.interval = time.Duration(config.ReloadInterval) * time.Second
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// files - return the files of the config
+func (r *certificateReloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+	return files
+}
+
+// load - load the certificate and the client CAs
+func (r *certificateReloader) load() error {
+	modTimes := make([]time.Time, 0, 3)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		data, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificate is found in `%v`", r.config.ClientCAFile)
+		}
+	}
+
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
+
+// maybeReload - reload the files if they are changed, the check is done once per interval
+// The loaded files are kept if the new ones are not valid, e.g. while they are being replaced
+func (r *certificateReloader) maybeReload() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.interval < 0 || time.Since(r.checkedAt) < r.interval {
+		return
+	}
+	r.checkedAt = time.Now()
+
+	changed := false
+	for i, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return
+		}
+		if !info.ModTime().Equal(r.modTimes[i]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	l, _ := logger.GetManager().GetLogger()
+	if err := r.load(); err != nil {
+		if l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "protobuf.Server.TLS", ServerMaintenanceType, time.Now(), "Cannot reload the tls certificate ...", map[string]interface{}{"server": r.name, "error": err.Error()}))
+		}
+		return
+	}
+	if l != nil {
+		l.Log(types.NewLogObject(types.INFO, "protobuf.Server.TLS", ServerMaintenanceType, time.Now(), "The tls certificate is reloaded ...", r.name))
+	}
+}
+
+// tlsConfig - return the tls config of the server, each handshake uses the last loaded files
+func (r *certificateReloader) tlsConfig() *tls.Config {
+	clientAuth, _ := clientAuthType(r.config)
+	minVersion := uint16(tls.VersionTLS12)
+	if r.config.MinVersion == "1.3" {
+		minVersion = tls.VersionTLS13
+	}
+
+	return &tls.Config{
+		MinVersion: minVersion,
+		NextProtos: []string{"h2"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.maybeReload()
+
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			return &tls.Config{
+				Certificates: []tls.Certificate{*r.certificate},
+				ClientCAs:    r.clientCAs,
+				ClientAuth:   clientAuth,
+				MinVersion:   minVersion,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// clientAuthType - return the client certificate policy of the config, the clients must have a verified certificate if client_ca_file is set
+func clientAuthType(config TLSConfig) (tls.ClientAuthType, error) {
+	switch config.ClientAuth {
+	case "":
+		if config.ClientCAFile != "" {
+			return tls.RequireAndVerifyClientCert, nil
+		}
+		return tls.NoClientCert, nil
+	case "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "verify":
+		if config.ClientCAFile == "" {
+			return tls.NoClientCert, errors.New("the `verify` client_auth needs the client_ca_file")
+		}
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("the client_auth `%v` is not supported", config.ClientAuth)
+}
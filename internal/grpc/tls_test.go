@@ -0,0 +1,196 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// writeCertificate - write a certificate of the common name which is signed by the parent, or self-signed if parent is nil
+func writeCertificate(t *testing.T, dir string, name string, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Generating key --> Expected: %v, but got %v", nil, err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	signer, signerKey := template, any(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Creating certificate --> Expected: %v, but got %v", nil, err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	_ = os.WriteFile(filepath.Join(dir, name+".crt"), certPem, 0600)
+	_ = os.WriteFile(filepath.Join(dir, name+".key"), keyPem, 0600)
+
+	certificate, _ := tls.X509KeyPair(certPem, keyPem)
+	certificate.Leaf, _ = x509.ParseCertificate(der)
+	return certificate
+}
+
+func TestServerWrapper_MTLS(t *testing.T) {
+	makeReadyConfigManager()
+
+	dir := t.TempDir()
+	ca := writeCertificate(t, dir, "ca", nil)
+	writeCertificate(t, dir, "server", &ca)
+	client := writeCertificate(t, dir, "client", &ca)
+
+	server, err := NewServer("protobuf.tls", ServerConfig{
+		Host:     "127.0.0.1",
+		Port:     0,
+		Protocol: "tcp",
+		Async:    true,
+		TLS: TLSConfig{
+			CertFile:     filepath.Join(dir, "server.crt"),
+			KeyFile:      filepath.Join(dir, "server.key"),
+			ClientCAFile: filepath.Join(dir, "ca.crt"),
+		},
+		Interceptors: map[string]interface{}{
+			"order": []interface{}{"auth"},
+			"auth":  map[string]interface{}{"mtls": true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating gRPC Server --> Expected: %v, but got %v", nil, err)
+	}
+	registerEchoService(server.GetGrpcServer())
+	_ = server.Start(nil)
+	defer server.Stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+	call := func(certificates []tls.Certificate) error {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: certificates})
+		conn, err := grpc.NewClient(server.listener.Addr().String(), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return conn.Invoke(ctx, "/test.Service/Echo", &wrapperspb.BytesValue{}, &wrapperspb.BytesValue{})
+	}
+
+	// the client certificate is the principal of the call
+	if err := call([]tls.Certificate{client}); err != nil {
+		t.Errorf("Call with client certificate --> Expected: %v, but got %v", nil, err)
+	}
+	if err := call(nil); status.Code(err) != codes.Unavailable {
+		t.Errorf("Call without client certificate --> Expected: %v, but got %v", codes.Unavailable, err)
+	}
+
+	// the in-process connection is served without a client certificate, so it is not authenticated as the server
+	conn, err := server.LocalConn()
+	if err != nil {
+		t.Fatalf("Local connection --> Expected: %v, but got %v", nil, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := conn.Invoke(ctx, "/test.Service/Echo", &wrapperspb.BytesValue{}, &wrapperspb.BytesValue{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Call of local connection --> Expected: %v, but got %v", codes.Unauthenticated, err)
+	}
+}
+
+func TestServerWrapper_CheckLocalExposure(t *testing.T) {
+	makeReadyConfigManager()
+
+	dir := t.TempDir()
+	ca := writeCertificate(t, dir, "ca", nil)
+	writeCertificate(t, dir, "server", &ca)
+	tlsConfig := TLSConfig{CertFile: filepath.Join(dir, "server.crt"), KeyFile: filepath.Join(dir, "server.key")}
+	mtlsConfig := tlsConfig
+	mtlsConfig.ClientCAFile = filepath.Join(dir, "ca.crt")
+
+	testCases := []struct {
+		name         string
+		tls          TLSConfig
+		interceptors map[string]interface{}
+		exposed      bool
+	}{
+		{"tls", tlsConfig, nil, true},
+		{"mtls", mtlsConfig, nil, false},
+		{"mtls with auth", mtlsConfig, map[string]interface{}{"order": []interface{}{"auth"}, "auth": map[string]interface{}{"mtls": true}}, true},
+	}
+	for _, tc := range testCases {
+		server, err := NewServer("protobuf.exposure", ServerConfig{Host: "127.0.0.1", Protocol: "tcp", TLS: tc.tls, Interceptors: tc.interceptors})
+		if err != nil {
+			t.Fatalf("%v: Creating gRPC Server --> Expected: %v, but got %v", tc.name, nil, err)
+		}
+
+		err = server.CheckLocalExposure()
+		var exposureErr *LocalExposureErr
+		if tc.exposed != (err == nil) || (err != nil && !errors.As(err, &exposureErr)) {
+			t.Errorf("%v: Local exposure --> Expected exposed: %v, but got %v", tc.name, tc.exposed, err)
+		}
+		if _, err := server.WebHandler("/rpc"); tc.exposed != (err == nil) {
+			t.Errorf("%v: Web handler --> Expected exposed: %v, but got %v", tc.name, tc.exposed, err)
+		}
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	makeReadyConfigManager()
+
+	dir := t.TempDir()
+	writeCertificate(t, dir, "server", nil)
+	config := TLSConfig{CertFile: filepath.Join(dir, "server.crt"), KeyFile: filepath.Join(dir, "server.key")}
+
+	if _, err := newCertificateReloader("protobuf.tls", TLSConfig{CertFile: config.CertFile}); err == nil {
+		t.Errorf("Reloader without key --> Expected an error, but got %v", err)
+	}
+	if _, err := newCertificateReloader("protobuf.tls", TLSConfig{CertFile: config.CertFile, KeyFile: config.KeyFile, ClientAuth: "verify"}); err == nil {
+		t.Errorf("Verify without client CAs --> Expected an error, but got %v", err)
+	}
+
+	reloader, err := newCertificateReloader("protobuf.tls", config)
+	if err != nil {
+		t.Fatalf("Creating reloader --> Expected: %v, but got %v", nil, err)
+	}
+	reloader.interval = 0
+	first := reloader.certificate.Certificate[0]
+
+	// a new certificate is written with a later modification time
+	writeCertificate(t, dir, "server", nil)
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(config.CertFile, later, later)
+
+	tlsConfig, _ := reloader.tlsConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if string(tlsConfig.Certificates[0].Certificate[0]) == string(first) {
+		t.Errorf("Reloaded certificate --> Expected: %v, but got %v", "a new certificate", "the first one")
+	}
+}
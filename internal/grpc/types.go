@@ -1,10 +1,181 @@
 package grpc
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/listener"
+)
+
 type ServerConfig struct {
-	Host       string                 `json:"host"`
-	Port       int                    `json:"port"`
-	Protocol   string                 `json:"protocol"`
-	Async      bool                   `json:"async"`
-	Reflection bool                   `json:"reflection"`
-	Configs    map[string]interface{} `json:"configs"`
+	Host       string                `json:"host"` // a host name, "unix:///path.sock" or "systemd://<name or index>"
+	Port       int                   `json:"port"`
+	Protocol   string                `json:"protocol"`
+	Async      bool                  `json:"async"` // kept for the former configs, the servers are always served in the background
+	Reflection bool                  `json:"reflection"`
+	Socket     listener.SocketConfig `json:"socket"`
+	TLS        TLSConfig             `json:"tls"`
+
+	Web         WebConfig               `json:"web"`
+	Limits      ServerLimitsConfig      `json:"limits"`
+	Keepalive   ServerKeepaliveConfig   `json:"keepalive"`
+	Compression ServerCompressionConfig `json:"compression"`
+
+	// Configs is the former place of the message sizes of Limits, its keys are read in any casing
+	// e.g. "maxReceiveMessageSize" or "max_receive_message_size", the values of Limits take precedence
+	Configs map[string]interface{} `json:"configs"`
+
+	// ShutdownTimeout is the milliseconds which the in-flight calls are waited for on stop before they are cancelled
+	// a negative value cancels them at once (default: 30000)
+	ShutdownTimeout int `json:"shutdown_timeout"`
+
+	// Interceptors - `order` lists the built-in and registered interceptors, the first one is the outermost
+	// The config of each interceptor is in `interceptors.<name>`
+	Interceptors map[string]interface{} `json:"interceptors"`
+
+	// I18n negotiates the locale of each call by its `locale` or `accept-language` metadata
+	// It adds the i18n interceptor even if it is not listed in the order
+	I18n bool `json:"i18n"`
+}
+
+// TLSConfig - the transport credentials of the server, the server is plaintext if cert_file is empty
+type TLSConfig struct {
+	CertFile     string `json:"cert_file"`
+	KeyFile      string `json:"key_file"`
+	MinVersion   string `json:"min_version"`    // "1.2" or "1.3" (default: 1.2)
+	ClientCAFile string `json:"client_ca_file"` // the CAs of the client certificates, it enables mTLS
+	// ClientAuth is "none", "request", "require", "verify_if_given" or "verify" (default: "verify" if client_ca_file is set)
+	ClientAuth string `json:"client_auth"`
+	// ReloadInterval is the seconds between the checks of the files for change, a negative value disables the reload (default: 30)
+	ReloadInterval int `json:"reload_interval"`
+}
+
+// WebConfig - serve the services of the server to the browsers by gRPC-Web and Connect on the http servers
+// The services are mounted when their controllers are registered by the engine, the CORS of the http servers applies to them
+// (the `grpc-status`, `grpc-message` and `grpc-status-details-bin` headers must be exposed for gRPC-Web)
+// A server which requires the client certificates is served only if it has the auth interceptor, since the calls have no certificate
+type WebConfig struct {
+	Enabled     bool     `json:"enabled"`
+	Prefix      string   `json:"prefix"`       // the path of a method is `<prefix>/<package.Service>/<Method>`, e.g. "/rpc"
+	HttpServers []string `json:"http_servers"` // default: the default http server
+}
+
+// ServerLimitsConfig - the limits of the connections and the messages, a zero value keeps the default of grpc
+// All durations are in milliseconds.
+type ServerLimitsConfig struct {
+	MaxReceiveMessageSize int    `json:"max_receive_message_size"` // bytes (default: 4MB)
+	MaxSendMessageSize    int    `json:"max_send_message_size"`    // bytes (default: unlimited)
+	MaxConcurrentStreams  uint32 `json:"max_concurrent_streams"`   // per connection (default: unlimited)
+
+	// ConnectionTimeout is the deadline of the handshake of the new connections (default: 120000)
+	ConnectionTimeout int `json:"connection_timeout"`
+
+	// InitialWindowSize and InitialConnWindowSize are the flow control windows of each stream and each connection
+	// in bytes, a value below 64KB is ignored by grpc
+	InitialWindowSize     int32 `json:"initial_window_size"`
+	InitialConnWindowSize int32 `json:"initial_conn_window_size"`
+}
+
+// ServerKeepaliveConfig - the pings and the lifetime of the connections of the server
+// All durations are in milliseconds, a zero value keeps the default of grpc unless another one is noted.
+type ServerKeepaliveConfig struct {
+	Time    int `json:"time"`    // the idle time before the server pings the client (default: 900000)
+	Timeout int `json:"timeout"` // the wait for the ping ack before the connection is closed (default: 20000)
+
+	MaxConnectionIdle     int `json:"max_connection_idle"`      // the idle time before the connection is closed by a GOAWAY
+	MaxConnectionAge      int `json:"max_connection_age"`       // the lifetime of a connection, it is jittered by +/-10% by grpc
+	MaxConnectionAgeGrace int `json:"max_connection_age_grace"` // the time of the pending calls after the MaxConnectionAge
+
+	Enforcement ServerKeepaliveEnforcementConfig `json:"enforcement"`
+}
+
+// ServerKeepaliveEnforcementConfig - the policy of the pings of the clients, the connection of a client which pings too often is closed
+type ServerKeepaliveEnforcementConfig struct {
+	MinTime             int  `json:"min_time"` // the minimum time between the pings of a client (default: 300000)
+	PermitWithoutStream bool `json:"permit_without_stream"`
+}
+
+// ServerCompressionConfig - the compression of the responses, the requests are decompressed by any registered compressor
+type ServerCompressionConfig struct {
+	// Encoding compresses the responses of the clients which accept it, e.g. "gzip", an empty value replies in the encoding of the request
+	Encoding string `json:"encoding"`
+}
+
+// interceptorOrder - return the names of `interceptors.order`
+func (c ServerConfig) interceptorOrder() []string {
+	items, _ := c.Interceptors["order"].([]interface{})
+	order := make([]string, 0, len(items))
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// address - return the listen address of the server
+func (c ServerConfig) address() string {
+	if strings.Contains(c.Host, "://") {
+		return c.Host
+	}
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// ClientConfig - defines an outbound connection in the `clients` of protobuf config.
+// All durations are in milliseconds.
+type ClientConfig struct {
+	Name string `json:"name"`
+
+	// Target is the address of the service, e.g. "users:7777" or "dns:///users.internal:7777" to resolve all the addresses of the name
+	Target string `json:"target"`
+
+	// Addresses is a static list of the addresses of the service, it is used instead of Target
+	Addresses []string `json:"addresses"`
+
+	// LoadBalancing is "round_robin" or "pick_first" (default: "round_robin" if there are several addresses or the target is dns)
+	LoadBalancing string `json:"load_balancing"`
+
+	// Timeout is the deadline of the calls whose context has none, zero means no deadline
+	Timeout int `json:"timeout"`
+
+	// Metadata is added to the outgoing calls, e.g. "x-client": "gateway"
+	Metadata map[string]string `json:"metadata"`
+
+	// Propagate lists the metadata of the incoming call which are sent on the outgoing calls
+	// (default: x-request-id, x-correlation-id, traceparent, tracestate and baggage)
+	Propagate []string `json:"propagate"`
+
+	TLS       ClientTLSConfig       `json:"tls"`
+	Keepalive ClientKeepaliveConfig `json:"keepalive"`
+	Retry     ClientRetryConfig     `json:"retry"`
+}
+
+// ClientTLSConfig - the transport credentials of the connection, it is plaintext if it is not enabled
+type ClientTLSConfig struct {
+	Enabled            bool   `json:"enabled"`
+	CAFile             string `json:"ca_file"`   // the CAs of the server certificate (default: the system CAs)
+	CertFile           string `json:"cert_file"` // the client certificate of mTLS
+	KeyFile            string `json:"key_file"`
+	ServerName         string `json:"server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// ClientKeepaliveConfig - the pings of the idle connection, they are disabled if Time is zero
+type ClientKeepaliveConfig struct {
+	Time                int  `json:"time"`
+	Timeout             int  `json:"timeout"` // default: 20000
+	PermitWithoutStream bool `json:"permit_without_stream"`
+}
+
+// ClientRetryConfig - the retry policy of the service config, the calls are retried by grpc transparently
+type ClientRetryConfig struct {
+	// MaxAttempts is the number of attempts including the first one, a value below 2 disables the retries (the maximum of grpc is 5)
+	MaxAttempts int `json:"max_attempts"`
+
+	InitialBackoff    int     `json:"initial_backoff"`    // default: 100
+	MaxBackoff        int     `json:"max_backoff"`        // default: 2000
+	BackoffMultiplier float64 `json:"backoff_multiplier"` // default: 2
+
+	// Codes are the status codes which are retried (default: ["UNAVAILABLE"])
+	Codes []string `json:"codes"`
 }
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// MARK: Variables
+
+// rawCodec - pass the encoded messages through the connection, the server decodes them by its proto codec
+type rawCodec struct{}
+
+// Marshal - return the encoded message
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	data, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("the message of type %T is not encoded", v)
+	}
+	return *data, nil
+}
+
+// Unmarshal - keep a copy of the encoded message
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	out, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("the message of type %T cannot keep the encoded message", v)
+	}
+	*out = append([]byte(nil), data...)
+	return nil
+}
+
+// Name - return the name of the proto codec, so the content type of the call is `application/grpc+proto`
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// messageCodec - convert the json messages of the method to protobuf and back, it passes the protobuf messages as they are
+type messageCodec struct {
+	input  protoreflect.MessageType
+	output protoreflect.MessageType
+}
+
+// MARK: Private functions
+
+// newMessageCodec - return the codec of the method, the types of the messages are looked up in the registered files for json
+func newMessageCodec(method string, isJSON bool) (*messageCodec, error) {
+	if !isJSON {
+		return &messageCodec{}, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(method, "/"), "/")
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("`%v` is not a service", parts[0])
+	}
+	methodDescriptor := service.Methods().ByName(protoreflect.Name(parts[1]))
+	if methodDescriptor == nil {
+		return nil, fmt.Errorf("the method `%v` is not found", method)
+	}
+
+	input, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+	return &messageCodec{input: input, output: output}, nil
+}
+
+// request - return the protobuf encoding of the request
+func (c *messageCodec) request(data []byte) ([]byte, error) {
+	if c.input == nil {
+		return data, nil
+	}
+	if len(data) == 0 {
+		data = []byte("{}")
+	}
+
+	msg := c.input.New().Interface()
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// response - return the response in the encoding of the request
+func (c *messageCodec) response(data []byte) ([]byte, error) {
+	if c.output == nil {
+		return data, nil
+	}
+
+	msg := c.output.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(msg)
+}
@@ -0,0 +1,200 @@
+package web
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MARK: Variables
+
+// connectEndStreamFlag - the flag of the last message of a Connect stream, it carries the error and the trailers in json
+const connectEndStreamFlag = 0x02
+
+// connectError - the json of an error in the Connect protocol
+type connectError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message,omitempty"`
+	Details []connectErrorDetail `json:"details,omitempty"`
+}
+
+// connectErrorDetail - a detail of the status, the value is the base64 of the protobuf message
+type connectErrorDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// connectEndStream - the json of the last message of a Connect stream
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+// MARK: Private functions
+
+// serveConnectUnary - serve the unary request of the Connect protocol, the trailers are sent as the headers with the `trailer-` prefix
+func (h *Handler) serveConnectUnary(w http.ResponseWriter, r *http.Request, method string, isJSON bool) {
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		writeConnectError(w, status.Newf(codes.Unimplemented, "the content encoding `%v` is not supported", encoding))
+		return
+	}
+
+	ctx, cancel := outgoingContext(r, connectTimeout(r.Header.Get("Connect-Timeout-Ms")))
+	defer cancel()
+
+	codec, err := newMessageCodec(method, isJSON)
+	if err != nil {
+		writeConnectError(w, status.New(codes.Unimplemented, err.Error()))
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxFrameSize+1))
+	if err != nil || len(body) > maxFrameSize {
+		writeConnectError(w, status.New(codes.ResourceExhausted, "the request is larger than "+strconv.Itoa(maxFrameSize)+" bytes"))
+		return
+	}
+	request, err := codec.request(body)
+	if err != nil {
+		writeConnectError(w, status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	stream, err := h.newStream(ctx, method, [][]byte{request})
+	if err != nil {
+		writeConnectError(w, status.Convert(err))
+		return
+	}
+	var responses [][]byte
+	for {
+		var msg []byte
+		if err = stream.RecvMsg(&msg); err != nil {
+			break
+		}
+		responses = append(responses, msg)
+	}
+
+	header, _ := stream.Header()
+	writeMetadata(w.Header(), header, "")
+	writeMetadata(w.Header(), stream.Trailer(), "Trailer-")
+	if !errors.Is(err, io.EOF) {
+		writeConnectError(w, status.Convert(err))
+		return
+	}
+	if len(responses) != 1 {
+		writeConnectError(w, status.New(codes.Unimplemented, "the streaming method needs the streaming protocol"))
+		return
+	}
+
+	response, err := codec.response(responses[0])
+	if err != nil {
+		writeConnectError(w, status.New(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response)
+}
+
+// serveConnectStream - serve the streaming request of the Connect protocol, the status is sent in the last message of the body
+func (h *Handler) serveConnectStream(w http.ResponseWriter, r *http.Request, method string, isJSON bool) {
+	ctx, cancel := outgoingContext(r, connectTimeout(r.Header.Get("Connect-Timeout-Ms")))
+	defer cancel()
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+
+	st, trailer := func() (*status.Status, metadata.MD) {
+		codec, err := newMessageCodec(method, isJSON)
+		if err != nil {
+			return status.New(codes.Unimplemented, err.Error()), nil
+		}
+		requests, err := readFrames(r.Body)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error()), nil
+		}
+		for i := range requests {
+			if requests[i], err = codec.request(requests[i]); err != nil {
+				return status.New(codes.InvalidArgument, err.Error()), nil
+			}
+		}
+		stream, err := h.newStream(ctx, method, requests)
+		if err != nil {
+			return status.Convert(err), nil
+		}
+
+		headerWritten := false
+		for {
+			var msg []byte
+			err := stream.RecvMsg(&msg)
+			if !headerWritten {
+				header, _ := stream.Header()
+				writeMetadata(w.Header(), header, "")
+				w.WriteHeader(http.StatusOK)
+				headerWritten = true
+			}
+			if errors.Is(err, io.EOF) {
+				return status.New(codes.OK, ""), stream.Trailer()
+			}
+			if err != nil {
+				return status.Convert(err), stream.Trailer()
+			}
+
+			response, err := codec.response(msg)
+			if err != nil {
+				return status.New(codes.Internal, err.Error()), stream.Trailer()
+			}
+			_, _ = w.Write(frame(0, response))
+			flush(w)
+		}
+	}()
+
+	endStream := connectEndStream{Metadata: map[string][]string{}}
+	for key, values := range trailer {
+		for _, value := range values {
+			endStream.Metadata[key] = append(endStream.Metadata[key], metadataValue(key, value))
+		}
+	}
+	if st.Code() != codes.OK {
+		endStream.Error = newConnectError(st)
+	}
+	data, _ := json.Marshal(endStream)
+	_, _ = w.Write(frame(connectEndStreamFlag, data))
+	flush(w)
+}
+
+// writeConnectError - write the error of a unary call in json with the http status of its code
+func writeConnectError(w http.ResponseWriter, st *status.Status) {
+	data, _ := json.Marshal(newConnectError(st))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errs.Code(st.Code()).HTTPStatus())
+	_, _ = w.Write(data)
+}
+
+// newConnectError - return the Connect error of the status
+func newConnectError(st *status.Status) *connectError {
+	// the names of the codes are the ones of the Connect protocol
+	result := &connectError{Code: errs.Code(st.Code()).String(), Message: st.Message()}
+	for _, item := range st.Proto().GetDetails() {
+		result.Details = append(result.Details, connectErrorDetail{
+			Type:  strings.TrimPrefix(item.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(item.GetValue()),
+		})
+	}
+	return result
+}
+
+// connectTimeout - return the duration of the `connect-timeout-ms` header, it is zero if the header is not valid
+func connectTimeout(value string) time.Duration {
+	milliseconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || milliseconds <= 0 {
+		return 0
+	}
+	return time.Duration(milliseconds) * time.Millisecond
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MARK: Variables
+
+// grpcWebTrailerFlag - the flag of the frame which carries the trailers of gRPC-Web
+const grpcWebTrailerFlag = 0x80
+
+// grpcTimeoutUnits - the units of the `grpc-timeout` header
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour, 'M': time.Minute, 'S': time.Second, 'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+}
+
+// MARK: Private functions
+
+// serveGrpcWeb - serve the gRPC-Web request, the status and the trailers are sent in the last frame of the body
+// The frames of the text protocol are base64 encoded one by one
+func (h *Handler) serveGrpcWeb(w http.ResponseWriter, r *http.Request, method string, text bool) {
+	var body io.Reader = r.Body
+	contentType := "application/grpc-web+proto"
+	if text {
+		body = base64.NewDecoder(base64.StdEncoding, r.Body)
+		contentType = "application/grpc-web-text+proto"
+	}
+	write := func(data []byte) {
+		if text {
+			data = []byte(base64.StdEncoding.EncodeToString(data))
+		}
+		_, _ = w.Write(data)
+		flush(w)
+	}
+
+	ctx, cancel := outgoingContext(r, grpcTimeout(r.Header.Get("Grpc-Timeout")))
+	defer cancel()
+	w.Header().Set("Content-Type", contentType)
+
+	st, trailer := func() (*status.Status, metadata.MD) {
+		requests, err := readFrames(body)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error()), nil
+		}
+		stream, err := h.newStream(ctx, method, requests)
+		if err != nil {
+			return status.Convert(err), nil
+		}
+
+		headerWritten := false
+		for {
+			var msg []byte
+			err := stream.RecvMsg(&msg)
+			if !headerWritten {
+				header, _ := stream.Header()
+				writeMetadata(w.Header(), header, "")
+				w.WriteHeader(http.StatusOK)
+				headerWritten = true
+			}
+			if errors.Is(err, io.EOF) {
+				return status.New(codes.OK, ""), stream.Trailer()
+			}
+			if err != nil {
+				return status.Convert(err), stream.Trailer()
+			}
+			write(frame(0, msg))
+		}
+	}()
+
+	write(frame(grpcWebTrailerFlag, grpcWebTrailer(st, trailer)))
+}
+
+// grpcWebTrailer - return the trailers of the call in the http/1 header format
+func grpcWebTrailer(st *status.Status, trailer metadata.MD) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "grpc-status: %d\r\n", st.Code())
+	if st.Message() != "" {
+		fmt.Fprintf(&buf, "grpc-message: %s\r\n", encodeGrpcMessage(st.Message()))
+	}
+	if len(st.Details()) > 0 {
+		if data, err := proto.Marshal(st.Proto()); err == nil {
+			fmt.Fprintf(&buf, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(data))
+		}
+	}
+	for key, values := range trailer {
+		for _, value := range values {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, metadataValue(key, value))
+		}
+	}
+	return buf.Bytes()
+}
+
+// grpcTimeout - return the duration of the `grpc-timeout` header, e.g. "100m", it is zero if the header is not valid
+func grpcTimeout(value string) time.Duration {
+	if len(value) < 2 {
+		return 0
+	}
+	unit, ok := grpcTimeoutUnits[value[len(value)-1]]
+	if !ok {
+		return 0
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount <= 0 {
+		return 0
+	}
+	return time.Duration(amount) * unit
+}
+
+// encodeGrpcMessage - return the percent encoding of the message, the printable ascii characters except `%` are kept
+func encodeGrpcMessage(message string) string {
+	var buf strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
+
+// metadataValue - return the value of the metadata for a header, the binary values are base64 encoded
+func metadataValue(key string, value string) string {
+	if strings.HasSuffix(key, "-bin") {
+		return base64.RawStdEncoding.EncodeToString([]byte(value))
+	}
+	return value
+}
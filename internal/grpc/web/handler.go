@@ -0,0 +1,166 @@
+package web
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MARK: Variables
+
+const (
+	frameHeaderSize = 5
+	maxFrameSize    = 4 * 1024 * 1024
+)
+
+// skippedHeaders - the headers of the http request which are not sent as the metadata of the call
+var skippedHeaders = map[string]bool{
+	"accept-encoding": true, "connection": true, "content-encoding": true, "content-length": true, "content-type": true,
+	"host": true, "keep-alive": true, "te": true, "trailer": true, "transfer-encoding": true, "upgrade": true,
+	"user-agent": true, "x-grpc-web": true, "x-user-agent": true,
+}
+
+// Handler - an http.Handler which calls the grpc methods of the connection for the gRPC-Web and the Connect clients
+// The path of a request is `<prefix>/<package.Service>/<Method>`, the unary and the server streaming methods are supported
+type Handler struct {
+	conn   *grpc.ClientConn
+	prefix string
+}
+
+// MARK: Public functions
+
+// NewHandler - return a new instance of Handler which calls the methods on the connection
+func NewHandler(conn *grpc.ClientConn, prefix string) *Handler {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return &Handler{conn: conn, prefix: prefix}
+}
+
+// ServeHTTP - serve the request by the protocol of its content type
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, h.prefix)
+	if strings.Count(method, "/") != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch contentType {
+	case "application/grpc-web", "application/grpc-web+proto":
+		h.serveGrpcWeb(w, r, method, false)
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		h.serveGrpcWeb(w, r, method, true)
+	case "application/proto", "application/json":
+		h.serveConnectUnary(w, r, method, contentType == "application/json")
+	case "application/connect+proto", "application/connect+json":
+		h.serveConnectStream(w, r, method, contentType == "application/connect+json")
+	default:
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+	}
+}
+
+// MARK: Private functions
+
+// newStream - open the stream of the method and send the requests, the stream is closed for sending
+// All the methods are called as bidirectional streams since the kind of the method is not known
+func (h *Handler) newStream(ctx context.Context, method string, requests [][]byte) (grpc.ClientStream, error) {
+	stream, err := h.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, method, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range requests {
+		// the error of sending is returned by the receiving of the response
+		if err := stream.SendMsg(&requests[i]); err != nil {
+			break
+		}
+	}
+	_ = stream.CloseSend()
+	return stream, nil
+}
+
+// outgoingContext - return the context of the call with the headers of the request as the metadata and the timeout of the header
+func outgoingContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		key = strings.ToLower(key)
+		if skippedHeaders[key] || strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, "connect-") {
+			continue
+		}
+		md.Append(key, values...)
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// writeMetadata - write the metadata as the headers of the response, the key of each header is prefixed
+func writeMetadata(header http.Header, md metadata.MD, prefix string) {
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(prefix+key, metadataValue(key, value))
+		}
+	}
+}
+
+// readFrames - read the length-prefixed messages of the body, the compressed ones are not supported
+func readFrames(body io.Reader) ([][]byte, error) {
+	var frames [][]byte
+	header := make([]byte, frameHeaderSize)
+	for {
+		if _, err := io.ReadFull(body, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return frames, nil
+			}
+			return nil, err
+		}
+		if header[0]&1 != 0 {
+			return nil, errors.New("the compressed messages are not supported")
+		}
+
+		size := binary.BigEndian.Uint32(header[1:])
+		if size > maxFrameSize {
+			return nil, errors.New("the message is larger than " + strconv.Itoa(maxFrameSize) + " bytes")
+		}
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(body, frame); err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// frame - return the length-prefixed message with the flags
+func frame(flags byte, data []byte) []byte {
+	buf := make([]byte, frameHeaderSize+len(data))
+	buf[0] = flags
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(data)))
+	copy(buf[frameHeaderSize:], data)
+	return buf
+}
+
+// flush - send the written part of the response to the client
+func flush(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
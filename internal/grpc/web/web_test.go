@@ -0,0 +1,153 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestHandler - return the handler of a server with the health service, the `x-tenant` metadata is sent back in the header
+func newTestHandler(t *testing.T) *Handler {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-tenant", strings.Join(md.Get("x-tenant"), ",")))
+		return handler(ctx, req)
+	}))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("users", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///web",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Creating connection --> Expected: %v, but got %v", nil, err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewHandler(conn, "/rpc")
+}
+
+func serve(h http.Handler, method string, path string, contentType string, body []byte) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, bytes.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	r.Header.Set("X-Tenant", "acme")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler_GrpcWeb(t *testing.T) {
+	h := newTestHandler(t)
+	request, _ := proto.Marshal(&healthpb.HealthCheckRequest{Service: "users"})
+
+	for _, text := range []bool{false, true} {
+		contentType, body := "application/grpc-web+proto", frame(0, request)
+		if text {
+			contentType, body = "application/grpc-web-text", []byte(base64.StdEncoding.EncodeToString(body))
+		}
+
+		w := serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", contentType, body)
+		if w.Code != http.StatusOK || w.Header().Get("X-Tenant") != "acme" {
+			t.Errorf("gRPC-Web response (text: %v) --> Expected: %v %v, but got %v %v", text, http.StatusOK, "acme", w.Code, w.Header().Get("X-Tenant"))
+		}
+
+		// each frame of the text protocol is encoded by itself, so the groups of 4 characters are decoded one by one
+		body = w.Body.Bytes()
+		if text {
+			var decoded []byte
+			for i := 0; i+4 <= len(body); i += 4 {
+				data, _ := base64.StdEncoding.DecodeString(string(body[i : i+4]))
+				decoded = append(decoded, data...)
+			}
+			body = decoded
+		}
+		var frames [][]byte
+		for len(body) >= frameHeaderSize {
+			size := int(binary.BigEndian.Uint32(body[1:frameHeaderSize]))
+			frames = append(frames, body[:frameHeaderSize+size])
+			body = body[frameHeaderSize+size:]
+		}
+		if len(frames) != 2 {
+			t.Fatalf("gRPC-Web frames (text: %v) --> Expected: %v, but got %v", text, 2, len(frames))
+		}
+		trailer := frames[1]
+
+		var response healthpb.HealthCheckResponse
+		if err := proto.Unmarshal(frames[0][frameHeaderSize:], &response); err != nil || response.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("gRPC-Web message (text: %v) --> Expected: %v, but got %v %v", text, healthpb.HealthCheckResponse_NOT_SERVING, response.Status, err)
+		}
+		if trailer[0] != grpcWebTrailerFlag || !strings.Contains(string(trailer), "grpc-status: 0\r\n") {
+			t.Errorf("gRPC-Web trailer (text: %v) --> Expected: %v, but got %q", text, "grpc-status: 0", trailer)
+		}
+	}
+
+	request, _ = proto.Marshal(&healthpb.HealthCheckRequest{Service: "unknown"})
+	w := serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/grpc-web+proto", frame(0, request))
+	if !strings.Contains(w.Body.String(), "grpc-status: 5\r\n") {
+		t.Errorf("gRPC-Web error trailer --> Expected: %v, but got %q", "grpc-status: 5", w.Body.String())
+	}
+}
+
+func TestHandler_Connect(t *testing.T) {
+	h := newTestHandler(t)
+
+	w := serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/json", []byte(`{"service": "users"}`))
+	if w.Code != http.StatusOK || w.Body.String() != `{"status":"NOT_SERVING"}` {
+		t.Errorf("Connect json response --> Expected: %v %v, but got %v %v", http.StatusOK, `{"status":"NOT_SERVING"}`, w.Code, w.Body.String())
+	}
+	if w.Header().Get("X-Tenant") != "acme" {
+		t.Errorf("Connect header --> Expected: %v, but got %v", "acme", w.Header().Get("X-Tenant"))
+	}
+
+	request, _ := proto.Marshal(&healthpb.HealthCheckRequest{Service: "users"})
+	w = serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/proto", request)
+	var response healthpb.HealthCheckResponse
+	if err := proto.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Connect proto response --> Expected: %v, but got %v %v", healthpb.HealthCheckResponse_NOT_SERVING, response.Status, err)
+	}
+
+	w = serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/json", []byte(`{"service": "unknown"}`))
+	var connectErr connectError
+	_ = json.Unmarshal(w.Body.Bytes(), &connectErr)
+	if w.Code != http.StatusNotFound || connectErr.Code != "not_found" {
+		t.Errorf("Connect error --> Expected: %v %v, but got %v %v", http.StatusNotFound, "not_found", w.Code, connectErr.Code)
+	}
+
+	w = serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/json", []byte(`{"unknown_field": 1}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Connect invalid json --> Expected: %v, but got %v", http.StatusBadRequest, w.Code)
+	}
+
+	// the status of a stream is sent in its last message
+	w = serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "application/connect+json", frame(0, []byte(`{"service": "users"}`)))
+	body := w.Body.Bytes()
+	size := int(binary.BigEndian.Uint32(body[1:frameHeaderSize]))
+	if string(body[frameHeaderSize:frameHeaderSize+size]) != `{"status":"NOT_SERVING"}` || body[frameHeaderSize+size] != connectEndStreamFlag {
+		t.Errorf("Connect stream --> Expected: %v, but got %q", `{"status":"NOT_SERVING"}`, body)
+	}
+
+	if w := serve(h, http.MethodGet, "/rpc/grpc.health.v1.Health/Check", "application/json", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET request --> Expected: %v, but got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+	if w := serve(h, http.MethodPost, "/rpc/grpc.health.v1.Health/Check", "text/plain", nil); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Unknown content type --> Expected: %v, but got %v", http.StatusUnsupportedMediaType, w.Code)
+	}
+}
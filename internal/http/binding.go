@@ -0,0 +1,153 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/i18n"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError - the translated validation error of a field of the request
+type FieldError struct {
+	Field   string // the json path of the field, e.g. "items[0].name"
+	Tag     string // the validation tag which is failed, e.g. "required"
+	Message string
+}
+
+// BindingErrorResponse - the response of a request which is not bound
+type BindingErrorResponse struct {
+	middlewares.ErrorHttpResponse
+	Fields []FieldError `json:",omitempty"`
+}
+
+// MARK: Public functions
+
+// BindRequest - bind the request into obj by its content type and validate it
+// The validation errors are translated to the locale of the request by the "validation.<tag>" messages
+// The messages which validate themselves, e.g. the protobuf messages shared with the grpc services, are validated
+// by utils.ValidateMessage too and their violations are returned with the "validate" tag
+func BindRequest(c *gin.Context, obj any) error {
+	err := c.ShouldBind(obj)
+	if err == nil {
+		return validateMessage(obj)
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return NewBindingErr(nil, err)
+	}
+
+	locale := i18n.LocaleFromContext(c)
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, item := range validationErrors {
+		field := fieldPath(reflect.TypeOf(obj), item.StructNamespace())
+		fields = append(fields, FieldError{
+			Field:   field,
+			Tag:     item.Tag(),
+			Message: validationMessage(locale, field, item),
+		})
+	}
+	return NewBindingErr(fields, err)
+}
+
+// AbortWithBindingError - answer 400 with the translated validation errors of the fields
+func AbortWithBindingError(c *gin.Context, err error) {
+	response := BindingErrorResponse{ErrorHttpResponse: middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(http.StatusBadRequest),
+		Status:      http.StatusBadRequest,
+		Description: err.Error(),
+	}}
+	var bindingErr *BindingErr
+	if errors.As(err, &bindingErr) {
+		response.Fields = bindingErr.Fields
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, response)
+}
+
+// MARK: Private functions
+
+// validateMessage - validate the bound message by utils.ValidateMessage
+func validateMessage(obj any) error {
+	violations, err := utils.ValidateMessage(obj)
+	if err == nil {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(violations))
+	for _, item := range violations {
+		fields = append(fields, FieldError{Field: item.Field, Tag: "validate", Message: item.Reason})
+	}
+	return NewBindingErr(fields, err)
+}
+
+// validationMessage - translate the error of the field, the label of the field is translated by the "fields.<field>" message
+func validationMessage(locale string, field string, fieldErr validator.FieldError) string {
+	labelKey := "fields." + withoutIndexes(field)
+	label := i18n.GetManager().Translate(locale, labelKey, nil)
+	if label == labelKey {
+		label = field
+	}
+
+	params := map[string]any{"field": label, "param": fieldErr.Param(), "value": fieldErr.Value()}
+	key := "validation." + fieldErr.Tag()
+	if msg := i18n.GetManager().Translate(locale, key, params); msg != key {
+		return msg
+	}
+	return i18n.GetManager().Translate(locale, "validation.default", params)
+}
+
+// withoutIndexes - remove the indexes of the slices and maps from the path of a field, e.g. "items[0].name" -> "items.name"
+func withoutIndexes(field string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range field {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fieldPath - convert the struct namespace of a field to its json path, e.g. "Order.Items[0].Name" -> "items[0].name"
+func fieldPath(t reflect.Type, namespace string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) > 1 {
+		parts = parts[1:] // the name of the type
+	}
+
+	for i, part := range parts {
+		name, index := part, ""
+		if n := strings.Index(part, "["); n >= 0 {
+			name, index = part[:n], part[n:]
+		}
+
+		for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			continue
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			t = nil
+			continue
+		}
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+			name = tag
+		}
+		parts[i] = name + index
+		t = field.Type
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+type bindingItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type bindingRequest struct {
+	Email string        `json:"email" binding:"required,email"`
+	Age   int           `json:"age" binding:"gte=18"`
+	Items []bindingItem `json:"items" binding:"dive"`
+}
+
+// validatedBindingRequest - a request which validates itself like the generated protobuf messages
+type validatedBindingRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *validatedBindingRequest) Validate() error {
+	if len(r.Name) < 3 {
+		return nameLengthErr{}
+	}
+	return nil
+}
+
+// nameLengthErr - the error of a field like the ones generated by protoc-gen-validate
+type nameLengthErr struct{}
+
+func (nameLengthErr) Error() string {
+	return "invalid Request.Name: value length must be at least 3 runes"
+}
+func (nameLengthErr) Field() string  { return "name" }
+func (nameLengthErr) Reason() string { return "value length must be at least 3 runes" }
+
+func TestBindRequest_TranslatedErrors(t *testing.T) {
+	makeReadyConfigManager()
+	err := i18n.GetManager().AddMessages("fa", map[string]any{
+		"fields":     map[string]any{"email": "ایمیل"},
+		"validation": map[string]any{"required": "{field} الزامی است", "default": "{field} معتبر نیست"},
+	})
+	if err != nil {
+		t.Fatalf("Adding messages --> Expected: %v, but got %v", nil, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middlewares.I18nMiddleware(&types.I18nMiddlewareConfig{QueryParam: "lang"}))
+	engine.POST("/users", func(c *gin.Context) {
+		req := bindingRequest{}
+		if err := BindRequest(c, &req); err != nil {
+			AbortWithBindingError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	serve := func(target string, acceptLanguage string, body string) (*httptest.ResponseRecorder, BindingErrorResponse) {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+
+		response := BindingErrorResponse{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	w, response := serve("/users", "fa-IR,en;q=0.5", `{"age": 10, "items": [{"name": "a"}, {}]}`)
+	expected := []FieldError{
+		{Field: "email", Tag: "required", Message: "ایمیل الزامی است"},
+		{Field: "age", Tag: "gte", Message: "The age field must be greater than or equal to 18"},
+		{Field: "items[1].name", Tag: "required", Message: "items[1].name الزامی است"},
+	}
+	if w.Code != http.StatusBadRequest || w.Header().Get("Content-Language") != "fa" || len(response.Fields) != len(expected) {
+		t.Fatalf("Invalid request --> Expected: %v fa %v, but got %v %v %v", http.StatusBadRequest, expected, w.Code, w.Header().Get("Content-Language"), response.Fields)
+	}
+	for i, item := range expected {
+		if response.Fields[i] != item {
+			t.Errorf("Field error --> Expected: %v, but got %v", item, response.Fields[i])
+		}
+	}
+
+	// the query param takes precedence over the header
+	_, response = serve("/users?lang=en", "fa", `{"email": "not-an-email", "age": 20}`)
+	if len(response.Fields) != 1 || response.Fields[0].Message != "The email field must be a valid email address" {
+		t.Errorf("English error --> Expected: %v, but got %v", "The email field must be a valid email address", response.Fields)
+	}
+
+	if w, response = serve("/users", "", `{"email": `); w.Code != http.StatusBadRequest || len(response.Fields) != 0 || response.Status != http.StatusBadRequest {
+		t.Errorf("Malformed body --> Expected: %v without fields, but got %v %v", http.StatusBadRequest, w.Code, response)
+	}
+	if w, _ = serve("/users", "", `{"email": "ali@example.com", "age": 20}`); w.Code != http.StatusOK {
+		t.Errorf("Valid request --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestBindRequest_ValidatedMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/users", func(c *gin.Context) {
+		req := validatedBindingRequest{}
+		if err := BindRequest(c, &req); err != nil {
+			AbortWithBindingError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	serve := func(body string) (*httptest.ResponseRecorder, BindingErrorResponse) {
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+
+		response := BindingErrorResponse{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	w, response := serve(`{"name": "al"}`)
+	expected := FieldError{Field: "name", Tag: "validate", Message: "value length must be at least 3 runes"}
+	if w.Code != http.StatusBadRequest || len(response.Fields) != 1 || response.Fields[0] != expected {
+		t.Errorf("Invalid message --> Expected: %v %v, but got %v %v", http.StatusBadRequest, expected, w.Code, response.Fields)
+	}
+	if w, _ = serve(`{"name": "ali"}`); w.Code != http.StatusOK {
+		t.Errorf("Valid message --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
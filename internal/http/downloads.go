@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	defaultDownloadsPrefix = "/files"
+	defaultDownloadsTTL    = 3600
+)
+
+// MARK: Public functions
+
+// SignedDownloadURL - return the path of the downloads route which serves the object until the ttl is passed
+// The default ttl of the config is used if the given ttl is zero
+func (s *GinServer) SignedDownloadURL(key string, ttl time.Duration) (string, error) {
+	s.lock.Lock()
+	downloads := s.config.Downloads
+	s.lock.Unlock()
+
+	if !downloads.Enabled {
+		return "", NewDownloadsConfigErr(fmt.Sprintf("the downloads are not enabled on the server `%v`", s.name))
+	}
+	if ttl <= 0 {
+		ttl = time.Duration(downloadsTTL(downloads)) * time.Second
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, item := range segments {
+		segments[i] = url.PathEscape(item)
+	}
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", signDownload(downloads.Secret, key, expires))
+	return joinPaths(downloadsPrefix(downloads), strings.Join(segments, "/")) + "?" + query.Encode(), nil
+}
+
+// MARK: Private functions
+
+// addDownloadsRoute - register the route which serves the objects of the storage backend by signed urls
+func (s *GinServer) addDownloadsRoute(config types.DownloadsConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if config.Secret == "" {
+		return NewDownloadsConfigErr("the secret is empty")
+	}
+
+	handler := func(c *gin.Context) {
+		s.serveDownload(c, config)
+	}
+	routePath := joinPaths(downloadsPrefix(config), "*key")
+	s.handle(&s.baseRouter.RouterGroup, http.MethodGet, routePath, "", handler)
+	s.handle(&s.baseRouter.RouterGroup, http.MethodHead, routePath, "", handler)
+	return nil
+}
+
+// serveDownload - verify the signature of the url and serve the object, ranges are supported by seekable objects
+func (s *GinServer) serveDownload(c *gin.Context, config types.DownloadsConfig) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires := c.Query("expires")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt ||
+		!hmac.Equal([]byte(c.Query("signature")), []byte(signDownload(config.Secret, key, expires))) {
+		abortDownload(c, http.StatusForbidden, "The download url is not valid or it is expired")
+		return
+	}
+
+	backend, err := storage.GetManager().GetBackend(config.Backend)
+	if err != nil {
+		abortDownload(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	reader, info, err := backend.Get(c.Request.Context(), key)
+	if err != nil {
+		var notFoundErr *storage.NotFoundErr
+		var invalidKeyErr *storage.InvalidKeyErr
+		if errors.As(err, &notFoundErr) || errors.As(err, &invalidKeyErr) {
+			abortDownload(c, http.StatusNotFound, "The file is not found")
+		} else {
+			abortDownload(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	defer reader.Close()
+
+	if info.Checksum != "" {
+		c.Header("ETag", `"`+info.Checksum+`"`)
+	}
+	if fileName := info.Metadata["filename"]; fileName != "" {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	}
+	c.Header("Cache-Control", "private, max-age="+strconv.FormatInt(max(expiresAt-time.Now().Unix(), 0), 10))
+	c.Header("Content-Type", info.ContentType)
+
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, "", info.ModTime, seeker)
+		return
+	}
+	c.DataFromReader(http.StatusOK, info.Size, info.ContentType, reader, nil)
+}
+
+// signDownload - return the hex encoded HMAC-SHA256 of the key and the expiry
+func signDownload(secret string, key string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// downloadsPrefix - return the prefix of the downloads route
+func downloadsPrefix(config types.DownloadsConfig) string {
+	if config.Prefix == "" {
+		return defaultDownloadsPrefix
+	}
+	return config.Prefix
+}
+
+// downloadsTTL - return the default lifetime of the urls in seconds
+func downloadsTTL(config types.DownloadsConfig) int {
+	if config.TTL <= 0 {
+		return defaultDownloadsTTL
+	}
+	return config.TTL
+}
+
+// abortDownload - answer the download request with the error
+func abortDownload(c *gin.Context, status int, description string) {
+	c.AbortWithStatusJSON(status, middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: description,
+	})
+}
@@ -1,6 +1,12 @@
 package http
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/Blocktunium/gonyx/internal/utils"
+)
 
 // NotImplementedErr Error
 type NotImplementedErr struct {
@@ -11,6 +17,11 @@ func (err *NotImplementedErr) Error() string {
 	return fmt.Sprintf("Not Implemented Yet")
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotImplementedErr) ErrorCode() errs.Code {
+	return errs.Unimplemented
+}
+
 // NewNotImplementedErr - return a new instance of NotImplementedErr
 func NewNotImplementedErr() error {
 	return &NotImplementedErr{}
@@ -26,6 +37,11 @@ func (err *CreateServerErr) Error() string {
 	return fmt.Sprintf("Create a new server encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CreateServerErr) Unwrap() error {
+	return err.Err
+}
+
 // NewCreateServerErr - return a new instance of CreateServerErr
 func NewCreateServerErr(err error) error {
 	return &CreateServerErr{Err: err}
@@ -42,6 +58,16 @@ func (err *StartServerErr) Error() string {
 	return fmt.Sprintf("Starting server on `%v` encounterred an error: %v", err.ListenAddress, err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *StartServerErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *StartServerErr) ErrorCode() errs.Code {
+	return errs.Unavailable
+}
+
 // NewStartServerErr - return a new instance of StartServerErr
 func NewStartServerErr(addr string, err error) error {
 	return &StartServerErr{Err: err, ListenAddress: addr}
@@ -57,6 +83,11 @@ func (err *ShutdownServerErr) Error() string {
 	return fmt.Sprintf("Shutting down server encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *ShutdownServerErr) Unwrap() error {
+	return err.Err
+}
+
 // NewShutdownServerErr - return a new instance of StartServerErr
 func NewShutdownServerErr(err error) error {
 	return &ShutdownServerErr{Err: err}
@@ -72,6 +103,11 @@ func (err *NotSupportedHttpMethodErr) Error() string {
 	return fmt.Sprintf("This method '%v' is not supported", err.method)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotSupportedHttpMethodErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
 // NewNotSupportedHttpMethodErr - return a new instance of NotSupportedHttpMethodErr
 func NewNotSupportedHttpMethodErr(method string) error {
 	return &NotSupportedHttpMethodErr{method: method}
@@ -102,6 +138,11 @@ func (err *GetRouteByNameErr) Error() string {
 	return fmt.Sprintf("There is no route be the name: %v", err.name)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GetRouteByNameErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewGetRouteByNameErr - return a new instance of GetRouteByNameErr
 func NewGetRouteByNameErr(name string) error {
 	return &GetRouteByNameErr{name: name}
@@ -145,6 +186,11 @@ func (err *GroupRouteNotExistErr) Error() string {
 	return fmt.Sprintf("There is no group route be the name: %v", err.name)
 }
 
+// ErrorCode method - satisfying errs.Coder interface
+func (err *GroupRouteNotExistErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
 // NewGroupRouteNotExistErr - return a new instance of GroupRouteNotExistErr
 func NewGroupRouteNotExistErr(name string) error {
 	return &GroupRouteNotExistErr{name: name}
@@ -190,7 +236,182 @@ func (err *UpdateServerConfigErr) Error() string {
 	return fmt.Sprintf("Create a new server encounterred an error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *UpdateServerConfigErr) Unwrap() error {
+	return err.Err
+}
+
 // NewUpdateServerConfigErr - return a new instance of UpdateServerConfigErr
 func NewUpdateServerConfigErr(err error) error {
 	return &UpdateServerConfigErr{Err: err}
 }
+
+// UnknownMiddlewareErr Error
+type UnknownMiddlewareErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *UnknownMiddlewareErr) Error() string {
+	return fmt.Sprintf("The middleware '%v' is neither built-in nor registered", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *UnknownMiddlewareErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewUnknownMiddlewareErr - return a new instance of UnknownMiddlewareErr
+func NewUnknownMiddlewareErr(name string) error {
+	return &UnknownMiddlewareErr{Name: name}
+}
+
+// MiddlewareConfigErr Error
+type MiddlewareConfigErr struct {
+	Name string
+	Err  error
+}
+
+// Error method - satisfying error interface
+func (err *MiddlewareConfigErr) Error() string {
+	return fmt.Sprintf("The config of the middleware '%v' is not valid: %v", err.Name, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *MiddlewareConfigErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MiddlewareConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewMiddlewareConfigErr - return a new instance of MiddlewareConfigErr
+func NewMiddlewareConfigErr(name string, err error) error {
+	return &MiddlewareConfigErr{Name: name, Err: err}
+}
+
+// RegisterMiddlewareErr Error
+type RegisterMiddlewareErr struct {
+	Name   string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *RegisterMiddlewareErr) Error() string {
+	return fmt.Sprintf("Cannot register the middleware '%v': %v", err.Name, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *RegisterMiddlewareErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewRegisterMiddlewareErr - return a new instance of RegisterMiddlewareErr
+func NewRegisterMiddlewareErr(name string, reason string) error {
+	return &RegisterMiddlewareErr{Name: name, Reason: reason}
+}
+
+// VersioningConfigErr Error
+type VersioningConfigErr struct {
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *VersioningConfigErr) Error() string {
+	return fmt.Sprintf("The versioning config is not valid: %v", err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *VersioningConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewVersioningConfigErr - return a new instance of VersioningConfigErr
+func NewVersioningConfigErr(reason string) error {
+	return &VersioningConfigErr{Reason: reason}
+}
+
+// DownloadsConfigErr Error
+type DownloadsConfigErr struct {
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *DownloadsConfigErr) Error() string {
+	return fmt.Sprintf("The downloads config is not valid: %v", err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *DownloadsConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewDownloadsConfigErr - return a new instance of DownloadsConfigErr
+func NewDownloadsConfigErr(reason string) error {
+	return &DownloadsConfigErr{Reason: reason}
+}
+
+// BindingErr Error - the request is not bound, Fields has the translated validation errors
+type BindingErr struct {
+	Fields []FieldError
+	Err    error
+}
+
+// Error method - satisfying error interface
+func (err *BindingErr) Error() string {
+	if len(err.Fields) == 0 {
+		return fmt.Sprintf("The request is not valid: %v", err.Err)
+	}
+	messages := make([]string, len(err.Fields))
+	for i, item := range err.Fields {
+		messages[i] = item.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap - return the error of the binding
+func (err *BindingErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *BindingErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// FieldViolations method - satisfying errs.Violator interface
+func (err *BindingErr) FieldViolations() []utils.FieldViolation {
+	violations := make([]utils.FieldViolation, len(err.Fields))
+	for i, item := range err.Fields {
+		violations[i] = utils.FieldViolation{Field: item.Field, Reason: item.Message}
+	}
+	return violations
+}
+
+// NewBindingErr - return a new instance of BindingErr
+func NewBindingErr(fields []FieldError, err error) error {
+	return &BindingErr{Fields: fields, Err: err}
+}
+
+// MountHandlerErr Error
+type MountHandlerErr struct {
+	Prefix string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *MountHandlerErr) Error() string {
+	return fmt.Sprintf("Cannot mount the handler on '%v': %v", err.Prefix, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *MountHandlerErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewMountHandlerErr - return a new instance of MountHandlerErr
+func NewMountHandlerErr(prefix string, reason string) error {
+	return &MountHandlerErr{Prefix: prefix, Reason: reason}
+}
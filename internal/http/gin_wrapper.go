@@ -5,16 +5,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Blocktunium/gonyx/internal/config"
 	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/proxy"
+	"github.com/Blocktunium/gonyx/internal/http/realtime"
 	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/http/views"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
 	"github.com/Blocktunium/gonyx/internal/utils"
@@ -42,6 +50,23 @@ type GinServer struct {
 	supportedMiddlewares  []string
 	defaultRequestMethods []string
 	cachedSwaggerJSON     []byte // Cache for processed swagger JSON
+	liveConnections       *realtime.Tracker
+	routeNames            *routeNameTable
+	versionResolver       *versionResolver
+	proxies               []*proxy.Proxy
+	templates             *views.Renderer
+	assets                *views.Assets
+	errorHandler          func(ctx *gin.Context, err any)
+
+	// the sources of the templates and the static files set by the application, they are kept on reload
+	templatesFS   fs.FS
+	staticFS      fs.FS
+	templateFuncs template.FuncMap
+
+	lock        sync.Mutex                   // serializes the reload and the route registration
+	router      atomic.Pointer[servingState] // the engine that serves the requests, swapped on reload
+	isRunning   atomic.Bool                  // set by Start and Stop, read by the reload
+	http3Server HTTP3Server
 
 	predefinedGroups []struct {
 		name       string
@@ -57,6 +82,9 @@ type GinServer struct {
 		versions  []string
 		groups    []string
 	}
+
+	// the handlers mounted by the application, e.g. the gRPC gateways, they are kept on reload
+	mounts []mountedHandler
 }
 
 // init - Server Constructor - It initializes the server
@@ -67,7 +95,7 @@ func (s *GinServer) init(name string, serverConfig types.GinServerConfig, rawCon
 
 	if s.config.Config.RequestMethods != nil {
 		if s.config.Config.RequestMethods[0] == "ALL" {
-			s.defaultRequestMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"}
+			s.defaultRequestMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH", "HEAD"}
 		} else {
 			s.defaultRequestMethods = s.config.Config.RequestMethods
 		}
@@ -83,46 +111,173 @@ func (s *GinServer) init(name string, serverConfig types.GinServerConfig, rawCon
 	gin.SetMode(ginMode)
 
 	s.baseRouter = gin.New()
+	s.baseRouter.Use(middlewares.ProblemMiddleware())
+
+	if s.liveConnections == nil {
+		s.liveConnections = realtime.NewTracker()
+	}
 
 	s.groups = make(map[string]*gin.RouterGroup)
-	s.supportedMiddlewares = []string{
-		"logger",
-		"favicon",
-		"cors",
+	s.routeNames = newRouteNameTable()
+
+	versionResolver, err := newVersionResolver(serverConfig.Versions, serverConfig.Versioning)
+	if err != nil {
+		return err
+	}
+	s.versionResolver = versionResolver
+	s.supportedMiddlewares = builtinMiddlewares
+
+	// `conf.etag` enables the etag middleware even if it is not listed in the order
+	middlewareOrder := serverConfig.Middlewares.Order
+	if serverConfig.Config.Etag && !utils.ArrayContains(&middlewareOrder, "etag") {
+		middlewareOrder = append(append([]string{}, middlewareOrder...), "etag")
 	}
 
 	// get middleware objects and pass it to the attachMiddlewares function
-	if v, ok := rawConfig["middlewares"].(map[string]interface{}); ok {
-		s.attachMiddlewares(serverConfig.Middlewares.Order, v)
+	middlewaresConfig, _ := rawConfig["middlewares"].(map[string]interface{})
+	if err := s.attachMiddlewares(middlewareOrder, middlewaresConfig); err != nil {
+		return err
+	}
+
+	if s.errorHandler != nil {
+		s.baseRouter.Use(gin.CustomRecovery(s.errorHandler))
 	}
 
 	s.createVersionGroups(serverConfig.Versions)
 
-	// if predefined before and just restarting
-	if len(s.predefinedGroups) > 0 {
-		for _, item := range s.predefinedGroups {
-			s.AddGroup(item.name, item.f, item.groupNames...)
+	// if predefined before and just reloading, they are registered again without being recorded twice
+	for _, item := range s.predefinedGroups {
+		s.addGroupToRouters(item.name, item.f, item.groupNames...)
+	}
+
+	// a route which is not accepted by the new config fails the reload, so the previous engine keeps serving
+	for _, item := range s.predefinedRoutes {
+		var err error
+		if len(item.f) > 1 {
+			err = s.addRouteWithMultiHandlers(item.method, item.path, item.f, item.routeName, item.versions, item.groups)
+		} else {
+			err = s.addRoute(item.method, item.path, item.f[0], item.routeName, item.versions, item.groups)
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	if len(s.predefinedRoutes) > 0 {
-		for _, item := range s.predefinedRoutes {
-			if len(item.f) > 1 {
-				s.AddRouteWithMultiHandlers(item.method, item.path, item.f, item.routeName, item.versions, item.groups)
-			} else {
-				s.AddRoute(item.method, item.path, item.f[0], item.routeName, item.versions, item.groups)
-			}
+	s.attachMounts()
+
+	// the proxy routes of the config are created again, the previous ones are closed by the caller
+	s.proxies = nil
+	for _, item := range serverConfig.Proxies {
+		if err := s.addProxyRoute(item); err != nil {
+			return err
 		}
 	}
 
+	if err := s.addDownloadsRoute(serverConfig.Downloads); err != nil {
+		return err
+	}
+
+	// the templates and the static files are loaded again, the previous ones are closed by the caller
+	if err := s.setupStatic(ginMode == gin.DebugMode); err != nil {
+		return err
+	}
+
 	// Add Swagger documentation if enabled
 	if s.config.Swagger.Enabled {
 		s.addSwagger()
 	}
 
+	// the new engine serves the next requests, the in-flight ones are finished by the previous engine
+	s.router.Store(&servingState{engine: s.baseRouter, versions: s.versionResolver})
 	return nil
 }
 
+// servingState - the engine and the version resolver that serve the requests, they are swapped together
+type servingState struct {
+	engine   *gin.Engine
+	versions *versionResolver
+}
+
+// ginServerState - the state rebuilt by init, kept to be restored if a reload fails
+type ginServerState struct {
+	config                types.GinServerConfig
+	baseRouter            *gin.Engine
+	versionGroups         map[string]*gin.RouterGroup
+	groups                map[string]*gin.RouterGroup
+	routeNames            *routeNameTable
+	versionResolver       *versionResolver
+	proxies               []*proxy.Proxy
+	templates             *views.Renderer
+	assets                *views.Assets
+	supportedMiddlewares  []string
+	defaultRequestMethods []string
+	cachedSwaggerJSON     []byte
+}
+
+// snapshot - return the current state
+func (s *GinServer) snapshot() ginServerState {
+	return ginServerState{
+		config:                s.config,
+		baseRouter:            s.baseRouter,
+		versionGroups:         s.versionGroups,
+		groups:                s.groups,
+		routeNames:            s.routeNames,
+		versionResolver:       s.versionResolver,
+		proxies:               s.proxies,
+		templates:             s.templates,
+		assets:                s.assets,
+		supportedMiddlewares:  s.supportedMiddlewares,
+		defaultRequestMethods: s.defaultRequestMethods,
+		cachedSwaggerJSON:     s.cachedSwaggerJSON,
+	}
+}
+
+// restore - go back to the state
+func (s *GinServer) restore(state ginServerState) {
+	s.config = state.config
+	s.baseRouter = state.baseRouter
+	s.versionGroups = state.versionGroups
+	s.groups = state.groups
+	s.routeNames = state.routeNames
+	s.versionResolver = state.versionResolver
+	s.proxies = state.proxies
+	s.templates = state.templates
+	s.assets = state.assets
+	s.supportedMiddlewares = state.supportedMiddlewares
+	s.defaultRequestMethods = state.defaultRequestMethods
+	s.cachedSwaggerJSON = state.cachedSwaggerJSON
+	s.router.Store(&servingState{engine: state.baseRouter, versions: state.versionResolver})
+}
+
+// close - stop the health checks of the proxy routes and the watchers of the templates and the static files
+func (state ginServerState) close() {
+	for _, item := range state.proxies {
+		item.Close()
+	}
+	state.templates.Close()
+	state.assets.Close()
+}
+
+// isListenerChanged - check whether the new config needs a new listener
+func isListenerChanged(old types.GinServerConfig, new types.GinServerConfig) bool {
+	return old.ListenAddress != new.ListenAddress ||
+		old.Config.ReadTimeout != new.Config.ReadTimeout ||
+		old.Config.WriteTimeout != new.Config.WriteTimeout ||
+		old.Config.MaxHeaderBytes != new.Config.MaxHeaderBytes ||
+		old.TLS != new.TLS ||
+		old.Protocols != new.Protocols ||
+		old.Socket != new.Socket
+}
+
+// serveHTTP - select the api version of the request and serve it by the current engine
+func (s *GinServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	state := s.router.Load()
+	if r = state.versions.resolve(w, r); r == nil {
+		return
+	}
+	state.engine.ServeHTTP(w, r)
+}
+
 func (s *GinServer) createVersionGroups(versions []string) {
 	s.versionGroups = make(map[string]*gin.RouterGroup)
 	for _, item := range versions {
@@ -130,61 +285,306 @@ func (s *GinServer) createVersionGroups(versions []string) {
 	}
 }
 
-func (s *GinServer) attachMiddlewares(orders []string, rawConfig map[string]interface{}) {
+// attachMiddlewares - attach the built-in and registered middlewares in the order of the config
+func (s *GinServer) attachMiddlewares(orders []string, rawConfig map[string]interface{}) error {
 	for _, item := range orders {
-		if utils.ArrayContains(&s.supportedMiddlewares, item) {
-			switch item {
-			case "logger":
-				{
-					// check which logger must be used
-					loggerType, err := config.GetManager().Get("logger", "type")
-					if err == nil {
-						if loggerType == "zap" {
-							s.baseRouter.Use(middlewares.ZapLogger())
-							s.baseRouter.Use(middlewares.ZapRecoveryLogger())
-						} else if loggerType == "logme" {
-							s.baseRouter.Use(middlewares.LogMeLogger())
-							s.baseRouter.Use(middlewares.LogMeRecoveryLogger())
-						}
-					}
-				}
-			case "favicon":
-				if loggerCfg, ok := rawConfig[item].(map[string]interface{}); ok {
-					jsonBody, err2 := json.Marshal(loggerCfg)
-					if err2 == nil {
-						var obj types.FaviconMiddlewareConfig
-						err := json.Unmarshal(jsonBody, &obj)
-						if err != nil {
-							s.baseRouter.Use(middlewares.FaviconMiddleware(obj))
-							break
-						}
+		if !utils.ArrayContains(&s.supportedMiddlewares, item) {
+			factory, ok := getMiddlewareFactory(item)
+			if !ok {
+				return NewUnknownMiddlewareErr(item)
+			}
+
+			middlewareConfig, _ := rawConfig[item].(map[string]interface{})
+			handler, err := factory(middlewareConfig)
+			if err != nil {
+				return NewMiddlewareConfigErr(item, err)
+			}
+			if handler != nil {
+				s.baseRouter.Use(handler)
+			}
+			continue
+		}
+
+		switch item {
+		case "logger":
+			{
+				// check which logger must be used
+				loggerType, err := config.GetManager().Get("logger", "type")
+				if err == nil {
+					if loggerType == "zap" {
+						s.baseRouter.Use(middlewares.ZapLogger())
+						s.baseRouter.Use(middlewares.ZapRecoveryLogger())
+					} else if loggerType == "logme" {
+						s.baseRouter.Use(middlewares.LogMeLogger())
+						s.baseRouter.Use(middlewares.LogMeRecoveryLogger())
 					}
 				}
-			case "cors":
-				if corsConfig, ok := rawConfig[item].(map[string]interface{}); ok {
-					jsonBody, err2 := json.Marshal(corsConfig)
-					if err2 == nil {
-						var obj types.CorsMiddlewareConfig
-						err := json.Unmarshal(jsonBody, &obj)
-						if err == nil {
-							s.baseRouter.Use(middlewares.CorsMiddleware(&obj))
-						} else {
-							// Fallback to default CORS if configuration parsing fails
-							s.baseRouter.Use(middlewares.CorsMiddleware(nil))
-						}
-					}
-				} else {
-					// Fallback to default CORS if no configuration is provided
-					s.baseRouter.Use(middlewares.CorsMiddleware(nil))
+			}
+		case "favicon":
+			var obj types.FaviconMiddlewareConfig
+			if _, err := decodeMiddlewareConfig(rawConfig, item, &obj); err != nil {
+				return err
+			}
+
+			handler, err := middlewares.FaviconMiddleware(obj)
+			if err != nil {
+				return NewMiddlewareConfigErr(item, err)
+			}
+			s.baseRouter.Use(handler)
+		case "cors":
+			var obj types.CorsMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.CorsMiddleware(&obj))
+			} else {
+				s.baseRouter.Use(middlewares.CorsMiddleware(nil))
+			}
+		case "compress":
+			var static *middlewares.CompressStaticConfig
+			if s.config.SupportStatic {
+				static = &middlewares.CompressStaticConfig{
+					Prefix:               s.config.Static.Prefix,
+					Root:                 s.config.Static.Root,
+					CompressedFileSuffix: s.config.Config.CompressedFileSuffix,
 				}
+			}
+
+			var obj types.CompressMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.CompressMiddleware(&obj, static))
+			} else {
+				s.baseRouter.Use(middlewares.CompressMiddleware(nil, static))
+			}
+		case "etag":
+			var obj types.EtagMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.EtagMiddleware(&obj))
+			} else {
+				s.baseRouter.Use(middlewares.EtagMiddleware(nil))
+			}
+		case "responsecache":
+			var obj types.ResponseCacheMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.ResponseCacheMiddleware(&obj, s.routeNames.lookup))
+			} else {
+				s.baseRouter.Use(middlewares.ResponseCacheMiddleware(nil, s.routeNames.lookup))
+			}
+		case "security":
+			var obj types.SecurityHeadersMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.SecurityHeadersMiddleware(&obj))
+			} else {
+				s.baseRouter.Use(middlewares.SecurityHeadersMiddleware(nil))
+			}
+		case "csrf":
+			var obj types.CsrfMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
 
+			if found {
+				s.baseRouter.Use(middlewares.CsrfMiddleware(&obj))
+			} else {
+				s.baseRouter.Use(middlewares.CsrfMiddleware(nil))
 			}
+		case "timeout":
+			var obj types.TimeoutMiddlewareConfig
+			found, err := decodeMiddlewareConfig(rawConfig, item, &obj)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				s.baseRouter.Use(middlewares.TimeoutMiddleware(&obj))
+			} else {
+				s.baseRouter.Use(middlewares.TimeoutMiddleware(nil))
+			}
+		case "limits":
+			var obj types.LimitsMiddlewareConfig
+			if _, err := decodeMiddlewareConfig(rawConfig, item, &obj); err != nil {
+				return err
+			}
+
+			// `conf.body_limit` is the default of the body size limit
+			if obj.MaxBodySize == 0 {
+				obj.MaxBodySize = s.config.Config.BodyLimit
+			}
+			s.baseRouter.Use(middlewares.LimitsMiddleware(&obj))
+		case "i18n":
+			var obj types.I18nMiddlewareConfig
+			if _, err := decodeMiddlewareConfig(rawConfig, item, &obj); err != nil {
+				return err
+			}
+			s.baseRouter.Use(middlewares.I18nMiddleware(&obj))
+		case "tenancy":
+			var obj types.TenancyMiddlewareConfig
+			if _, err := decodeMiddlewareConfig(rawConfig, item, &obj); err != nil {
+				return err
+			}
+			handler, err := middlewares.TenancyMiddleware(&obj)
+			if err != nil {
+				return NewMiddlewareConfigErr(item, err)
+			}
+			s.baseRouter.Use(handler)
+		}
+	}
+	return nil
+}
+
+// decodeMiddlewareConfig - decode `middlewares.<name>` into obj, it returns false if the config is absent
+func decodeMiddlewareConfig(rawConfig map[string]interface{}, name string, obj interface{}) (bool, error) {
+	raw, ok := rawConfig[name]
+	if !ok || raw == nil {
+		return false, nil
+	}
+
+	jsonBody, err := json.Marshal(raw)
+	if err != nil {
+		return false, NewMiddlewareConfigErr(name, err)
+	}
+	if err := json.Unmarshal(jsonBody, obj); err != nil {
+		return false, NewMiddlewareConfigErr(name, err)
+	}
+	return true, nil
+}
+
+// handle - register the handlers on the router and remember the route name of the full path
+func (s *GinServer) handle(router *gin.RouterGroup, method string, path string, routeName string, handlers ...gin.HandlerFunc) {
+	router.Handle(method, path, handlers...)
+
+	fullPath := joinPaths(router.BasePath(), path)
+	s.versionResolver.addRoute(method, fullPath)
+	if routeName != "" {
+		s.routeNames.set(method+" "+fullPath, routeName)
+	}
+}
+
+// routeNameTable - the route names by "<method> <full path>" of an engine
+type routeNameTable struct {
+	lock  sync.RWMutex
+	names map[string]string
+}
+
+// newRouteNameTable - create an empty table
+func newRouteNameTable() *routeNameTable {
+	return &routeNameTable{names: make(map[string]string)}
+}
+
+// set - remember the route name
+func (t *routeNameTable) set(key string, routeName string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.names[key] = routeName
+}
+
+// lookup - return the name of the route that matched the request
+func (t *routeNameTable) lookup(c *gin.Context) string {
+	return t.get(c.Request.Method + " " + c.FullPath())
+}
+
+// get - return the route name of "<method> <full path>"
+func (t *routeNameTable) get(key string) string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.names[key]
+}
+
+// joinPaths - join the base path of the group and the relative path the same way gin does
+func joinPaths(basePath string, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+	finalPath := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
+
+// addProxyRoute - create the proxy of the config and register it for its methods
+func (s *GinServer) addProxyRoute(config types.ProxyRouteConfig) error {
+	p, err := proxy.New(config)
+	if err != nil {
+		return err
+	}
+	s.proxies = append(s.proxies, p)
+
+	methods := config.Methods
+	if len(methods) == 0 {
+		methods = s.defaultRequestMethods
+	}
+	for _, method := range methods {
+		if err := s.addRoute(strings.ToUpper(method), config.Path, p.Handler(), config.Name, config.Versions, config.Groups); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-func (s *GinServer) setupStatic() {
-	// Add static file serving functionality here if needed
+// setupStatic - load the html templates and register the route of the static files, they are watched if reload is set
+func (s *GinServer) setupStatic(reload bool) error {
+	s.templates, s.assets = nil, nil
+
+	if s.config.SupportStatic {
+		assets, err := views.NewAssets(s.config.Static, s.staticFS)
+		if err != nil {
+			return err
+		}
+		s.assets = assets
+		if reload {
+			if err := assets.Watch(); err != nil {
+				return err
+			}
+		}
+
+		routePath := joinPaths(assets.Prefix(), "*filepath")
+		s.handle(&s.baseRouter.RouterGroup, http.MethodGet, routePath, "", assets.Handler())
+		s.handle(&s.baseRouter.RouterGroup, http.MethodHead, routePath, "", assets.Handler())
+	}
+
+	if s.config.Templates.Enabled {
+		funcs := template.FuncMap{"asset": s.assets.URL}
+		for name, f := range s.templateFuncs {
+			funcs[name] = f
+		}
+		templates, err := views.NewRenderer(s.config.Templates, s.templatesFS, funcs)
+		if err != nil {
+			return err
+		}
+		s.templates = templates
+		if reload || s.config.Templates.Reload {
+			if err := templates.Watch(); err != nil {
+				return err
+			}
+		}
+		s.baseRouter.HTMLRender = templates
+	}
+	return nil
 }
 
 func (s *GinServer) addGroup(keyName string, groupName string, router *gin.RouterGroup, f gin.HandlerFunc) {
@@ -250,39 +650,35 @@ func (s *GinServer) loadSwaggerJSON() ([]byte, error) {
 // isPathSupportedByServer checks if a given API path is supported by this server
 // based on the server's configured versions and actual registered routes
 func (s *GinServer) isPathSupportedByServer(path string) bool {
-	// Extract version from path (e.g., /api/v1/users -> v1)
-	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// swagger writes the parameters as "{id}" and gin as ":id"
+	routePath := swaggerParamPattern.ReplaceAllString(path, ":$1")
+	candidates := []string{routePath}
 
-	// Check for version pattern like /api/v1/, /api/v2/, etc.
+	// Check for version segment like /v1/users or /api/v2/users
 	pathVersion := ""
-	for _, part := range parts {
-		if strings.HasPrefix(part, "v") && len(part) > 1 {
-			// Check if it's a version like v1, v2, etc.
-			if part[1:] != "" {
-				pathVersion = part
-				break
-			}
+	for _, part := range strings.Split(strings.Trim(routePath, "/"), "/") {
+		if utils.ArrayContains(&s.config.Versions, part) || versionSegmentPattern.MatchString(part) {
+			pathVersion = part
+			break
 		}
 	}
 
-	// If path has a version, check if server supports it
 	if pathVersion != "" {
-		supported := false
-		for _, supportedVersion := range s.config.Versions {
-			if supportedVersion == pathVersion {
-				supported = true
-				break
-			}
-		}
-		if !supported {
+		// If path has a version, check if server supports it
+		if !utils.ArrayContains(&s.config.Versions, pathVersion) {
 			return false
 		}
+	} else if s.versionResolver.strategy != VersioningPathStrategy {
+		// the versions are selected by the header or the media type, so the documented path has no version
+		for _, version := range s.config.Versions {
+			candidates = append(candidates, joinPaths("/"+version, routePath))
+		}
 	}
 
 	// Check if the path actually exists in the server's registered routes
 	allRoutes := s.GetAllRoutes()
 	for _, route := range allRoutes {
-		if route.Path == path {
+		if utils.ArrayContains(&candidates, route.Path) {
 			return true
 		}
 	}
@@ -325,7 +721,7 @@ func (s *GinServer) addSwagger() {
 	serverName := s.config.Name
 
 	// Create endpoint to serve swagger JSON from docs/swagger.json file
-	s.baseRouter.GET(fmt.Sprintf("/%s/swagger.json", serverName), func(c *gin.Context) {
+	s.handle(&s.baseRouter.RouterGroup, http.MethodGet, fmt.Sprintf("/%s/swagger.json", serverName), "", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 
 		// Check if swagger JSON is cached
@@ -363,7 +759,7 @@ func (s *GinServer) addSwagger() {
 
 	// Register Swagger UI handler with custom JSON URL including server name
 	swaggerURL := ginSwagger.URL(fmt.Sprintf("http://%s:%s/%s/swagger.json", host, port, serverName))
-	s.baseRouter.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, swaggerURL))
+	s.handle(&s.baseRouter.RouterGroup, http.MethodGet, "/swagger/*any", "", ginSwagger.WrapHandler(swaggerFiles.Handler, swaggerURL))
 }
 
 // MARK: Public functions
@@ -373,45 +769,80 @@ func NewGinServer(name string, config types.GinServerConfig, rawConfig map[strin
 	server := &GinServer{}
 	err := server.init(name, config, rawConfig)
 	if err != nil {
+		server.snapshot().close()
 		return nil, NewCreateServerErr(err)
 	}
 	return server, nil
 }
 
+// UpdateConfigs - rebuild the engine with the new config and swap it behind the running listener
+// The listener is restarted only if its own settings are changed, on error the previous config keeps serving
 func (s *GinServer) UpdateConfigs(config types.GinServerConfig, rawConfig map[string]interface{}) error {
+	s.lock.Lock()
+	previous := s.snapshot()
+	restart := s.isRunning.Load() && isListenerChanged(previous.config, config)
 	err := s.init(s.name, config, rawConfig)
+	if err != nil {
+		s.snapshot().close()
+		s.restore(previous)
+	} else {
+		previous.close()
+	}
+	routesCount := len(s.baseRouter.Routes())
+	s.lock.Unlock()
+
 	if err != nil {
 		return NewUpdateServerConfigErr(err)
 	}
 
+	if restart {
+		if err := s.Stop(); err != nil {
+			return NewUpdateServerConfigErr(err)
+		}
+		if err := s.Start(); err != nil {
+			return NewUpdateServerConfigErr(err)
+		}
+	}
+
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(logTypes.NewLogObject(logTypes.INFO, "http.Server.UpdateConfigs", HttpServerMaintenanceType, time.Now(), "Reloading the Http server config ...", map[string]interface{}{
+			"server":             config.Name,
+			"routes":             routesCount,
+			"listener_restarted": restart,
+		}))
+	}
 	return nil
 }
 
 // Start - start the server and listen to provided address
 func (s *GinServer) Start() error {
-	s.app = &http.Server{
-		Addr:         s.config.ListenAddress,
-		Handler:      s.baseRouter,
-		ReadTimeout:  s.config.Config.ReadTimeout,
-		WriteTimeout: s.config.Config.WriteTimeout,
-	}
-
-	errCh := make(chan error)
-	go func(ch chan error) {
-		if err := s.app.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			close(ch)
-		} else {
-			ch <- err
-			close(ch)
+	serverListener, err := newListener(s.config, http.HandlerFunc(s.serveHTTP))
+	if err != nil {
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(logTypes.NewLogObject(logTypes.ERROR, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Creating the Http listener failed ...", err))
 		}
-	}(errCh)
+		return NewStartServerErr(s.config.ListenAddress, err)
+	}
+	s.app = serverListener.server
+	s.http3Server = serverListener.http3Server
 
-	time.AfterFunc(3*time.Second, func() {
-		errCh <- nil
-	})
-	err := <-errCh
+	// the listener is opened here, so the address errors are reported by Start
+	err = serverListener.Listen()
+	if err == nil {
+		go func() {
+			if err := serverListener.Serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				l, _ := logger.GetManager().GetLogger()
+				if l != nil {
+					l.Log(logTypes.NewLogObject(logTypes.ERROR, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Serving the Http server failed ...", err))
+				}
+			}
+		}()
+	}
 
 	if err == nil {
+		s.isRunning.Store(true)
 		l, _ := logger.GetManager().GetLogger()
 		if l != nil {
 			l.Log(logTypes.NewLogObject(logTypes.INFO, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Starting the Http server ...", s.config.ListenAddress))
@@ -428,6 +859,18 @@ func (s *GinServer) Start() error {
 
 // Stop - stop the server
 func (s *GinServer) Stop() error {
+	// hijacked and streaming connections are not closed by Shutdown, so close them first
+	s.liveConnections.CloseAll()
+
+	if s.app == nil {
+		return nil
+	}
+
+	s.isRunning.Store(false)
+	if s.http3Server != nil {
+		_ = s.http3Server.Close()
+		s.http3Server = nil
+	}
 	err := s.app.Shutdown(context.Background())
 	if err != nil {
 		return NewShutdownServerErr(err)
@@ -435,18 +878,30 @@ func (s *GinServer) Stop() error {
 	return nil
 }
 
-// AttachErrorHandler - attach a custom error handler to the server
+// AttachErrorHandler - attach a custom error handler to the server, it is kept on reload
 func (s *GinServer) AttachErrorHandler(f func(ctx *gin.Context, err any)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.errorHandler = f
 	s.baseRouter.Use(gin.CustomRecovery(f))
 }
 
 func (s *GinServer) AddGroup(groupName string, f gin.HandlerFunc, groups ...string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.predefinedGroups = append(s.predefinedGroups, struct {
 		name       string
 		f          gin.HandlerFunc
 		groupNames []string
 	}{name: groupName, groupNames: groups, f: f})
 
+	return s.addGroupToRouters(groupName, f, groups...)
+}
+
+// addGroupToRouters - create the group on the base and version routers
+func (s *GinServer) addGroupToRouters(groupName string, f gin.HandlerFunc, groups ...string) error {
 	if len(groups) > 0 {
 
 	} else {
@@ -461,7 +916,11 @@ func (s *GinServer) AddGroup(groupName string, f gin.HandlerFunc, groups ...stri
 	return nil
 }
 
+// AddRoute - add a route to the server, it is registered again on reload
 func (s *GinServer) AddRoute(method string, path string, f func(c *gin.Context), routeName string, versions []string, groups []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.predefinedRoutes = append(s.predefinedRoutes, struct {
 		method    string
 		path      string
@@ -471,6 +930,11 @@ func (s *GinServer) AddRoute(method string, path string, f func(c *gin.Context),
 		groups    []string
 	}{method: method, path: path, f: []func(c *gin.Context){f}, routeName: routeName, versions: versions, groups: groups})
 
+	return s.addRoute(method, path, f, routeName, versions, groups)
+}
+
+// addRoute - register the route on the routers
+func (s *GinServer) addRoute(method string, path string, f func(c *gin.Context), routeName string, versions []string, groups []string) error {
 	if utils.ArrayContains(&s.defaultRequestMethods, method) {
 		groupsExist := false
 		if groups != nil {
@@ -494,25 +958,25 @@ func (s *GinServer) AddRoute(method string, path string, f func(c *gin.Context),
 							for k := range s.versionGroups {
 								newKey := fmt.Sprintf("%s.%s", k, g)
 								if router, ok := s.groups[newKey]; ok {
-									router.Handle(method, path, f)
+									s.handle(router, method, path, routeName, f)
 								}
 							}
 							break
 						} else if v == "" {
 							if router, ok := s.groups[g]; ok {
-								router.Handle(method, path, f)
+								s.handle(router, method, path, routeName, f)
 							}
 							break
 						} else {
 							newKey := fmt.Sprintf("%s.%s", v, g)
 							if router, ok := s.groups[newKey]; ok {
-								router.Handle(method, path, f)
+								s.handle(router, method, path, routeName, f)
 							}
 						}
 					}
 				} else {
 					if savedGroup, ok := s.groups[g]; ok {
-						savedGroup.Handle(method, path, f)
+						s.handle(savedGroup, method, path, routeName, f)
 					}
 				}
 			}
@@ -520,21 +984,21 @@ func (s *GinServer) AddRoute(method string, path string, f func(c *gin.Context),
 			if versionsExist {
 				for _, v := range versions {
 					if router, ok := s.versionGroups[v]; ok {
-						router.Handle(method, path, f)
+						s.handle(router, method, path, routeName, f)
 					} else {
 						if v == "all" {
 							for _, router1 := range s.versionGroups {
-								router1.Handle(method, path, f)
+								s.handle(router1, method, path, routeName, f)
 							}
 							break
 						} else if v == "" {
-							s.baseRouter.Handle(method, path, f)
+							s.handle(&s.baseRouter.RouterGroup, method, path, routeName, f)
 							break
 						}
 					}
 				}
 			} else {
-				s.baseRouter.Handle(method, path, f)
+				s.handle(&s.baseRouter.RouterGroup, method, path, routeName, f)
 			}
 		}
 		return nil
@@ -545,6 +1009,9 @@ func (s *GinServer) AddRoute(method string, path string, f func(c *gin.Context),
 
 // AddRouteWithMultiHandlers - add a route to the server
 func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []func(c *gin.Context), routeName string, versions []string, groups []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.predefinedRoutes = append(s.predefinedRoutes, struct {
 		method    string
 		path      string
@@ -554,6 +1021,11 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 		groups    []string
 	}{method: method, path: path, f: f, routeName: routeName, versions: versions, groups: groups})
 
+	return s.addRouteWithMultiHandlers(method, path, f, routeName, versions, groups)
+}
+
+// addRouteWithMultiHandlers - register the route with multiple handlers on the routers
+func (s *GinServer) addRouteWithMultiHandlers(method string, path string, f []func(c *gin.Context), routeName string, versions []string, groups []string) error {
 	// check that whether is acceptable to add this route method
 	if utils.ArrayContains(&s.defaultRequestMethods, method) {
 		if len(groups) > 0 {
@@ -568,7 +1040,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 									for _, item := range f {
 										p = append(p, item)
 									}
-									router.Handle(method, path, p...)
+									s.handle(router, method, path, routeName, p...)
 								}
 							}
 							break
@@ -578,7 +1050,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 								for _, item := range f {
 									p = append(p, item)
 								}
-								router.Handle(method, path, p...)
+								s.handle(router, method, path, routeName, p...)
 							}
 							break
 						} else {
@@ -589,7 +1061,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 									p = append(p, item)
 								}
 
-								router.Handle(method, path, p...)
+								s.handle(router, method, path, routeName, p...)
 							}
 						}
 					}
@@ -600,7 +1072,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 							p = append(p, item)
 						}
 
-						savedGroup.Handle(method, path, p...)
+						s.handle(savedGroup, method, path, routeName, p...)
 					}
 				}
 			}
@@ -612,7 +1084,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 						for _, item := range f {
 							p = append(p, item)
 						}
-						router.Handle(method, path, p...)
+						s.handle(router, method, path, routeName, p...)
 					} else {
 						if v == "all" {
 							for _, router1 := range s.versionGroups {
@@ -620,7 +1092,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 								for _, item := range f {
 									p = append(p, item)
 								}
-								router1.Handle(method, path, p...)
+								s.handle(router1, method, path, routeName, p...)
 							}
 							break
 						} else if v == "" {
@@ -629,7 +1101,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 								p = append(p, item)
 							}
 
-							s.baseRouter.Handle(method, path, p...)
+							s.handle(&s.baseRouter.RouterGroup, method, path, routeName, p...)
 						}
 					}
 				}
@@ -638,7 +1110,7 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 				for _, item := range f {
 					p = append(p, item)
 				}
-				s.baseRouter.Handle(method, path, p...)
+				s.handle(&s.baseRouter.RouterGroup, method, path, routeName, p...)
 			}
 		}
 		return nil
@@ -647,7 +1119,110 @@ func (s *GinServer) AddRouteWithMultiHandlers(method string, path string, f []fu
 	return NewNotSupportedHttpMethodErr(method)
 }
 
+// AddWebSocketRoute - add a websocket route, the handler owns the connection until it returns
+func (s *GinServer) AddWebSocketRoute(path string, f func(conn *realtime.Conn), options *realtime.WebSocketOptions, routeName string, versions []string, groups []string) error {
+	return s.AddRoute(http.MethodGet, path, func(c *gin.Context) {
+		opts := realtime.NewWebSocketOptions(s.config.WebSocket)
+		if options != nil {
+			opts = *options
+		}
+
+		conn, err := realtime.Upgrade(c.Writer, c.Request, opts)
+		if err != nil {
+			c.Abort()
+			return
+		}
+
+		s.liveConnections.Add(conn)
+		defer s.liveConnections.Remove(conn)
+		defer conn.Close()
+
+		f(conn)
+	}, routeName, versions, groups)
+}
+
+// AddSSERoute - add a server-sent events route, the handler owns the stream until it returns
+func (s *GinServer) AddSSERoute(path string, f func(stream *realtime.SSEStream), options *realtime.SSEOptions, routeName string, versions []string, groups []string) error {
+	return s.AddRoute(http.MethodGet, path, func(c *gin.Context) {
+		opts := realtime.NewSSEOptions(s.config.SSE)
+		if options != nil {
+			opts = *options
+		}
+
+		stream, err := realtime.NewSSEStream(c.Writer, c.Request, opts)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+
+		s.liveConnections.Add(stream)
+		defer s.liveConnections.Remove(stream)
+		defer stream.Close()
+
+		f(stream)
+	}, routeName, versions, groups)
+}
+
 // GetAllRoutes - Get all Routes
 func (s *GinServer) GetAllRoutes() gin.RoutesInfo {
 	return s.baseRouter.Routes()
 }
+
+// GetVersionedRoutes - return the routes with their api versions and deprecation dates
+func (s *GinServer) GetVersionedRoutes() []VersionedRoute {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.versionResolver.routes(s.name, s.baseRouter, s.routeNames)
+}
+
+// SetTemplatesFS - read the html templates from the files instead of the disk, e.g. an embed.FS of a production build
+// The root of the templates config is the directory in the files, they are kept on reload
+func (s *GinServer) SetTemplatesFS(fsys fs.FS) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.templatesFS = fsys
+	if s.templates == nil {
+		return nil
+	}
+	return s.templates.SetFS(fsys)
+}
+
+// SetStaticFS - read the static files from the files instead of the disk, e.g. an embed.FS of a production build
+// The root of the static config is the directory in the files, they are kept on reload
+func (s *GinServer) SetStaticFS(fsys fs.FS) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.staticFS = fsys
+	if s.assets == nil {
+		return nil
+	}
+	return s.assets.SetFS(fsys)
+}
+
+// AddTemplateFuncs - add the functions to the html templates, they are kept on reload
+func (s *GinServer) AddTemplateFuncs(funcs template.FuncMap) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.templateFuncs == nil {
+		s.templateFuncs = template.FuncMap{}
+	}
+	for name, f := range funcs {
+		s.templateFuncs[name] = f
+	}
+	if s.templates == nil {
+		return nil
+	}
+	return s.templates.AddFuncs(funcs)
+}
+
+// AssetURL - return the url of the static file, it is the fingerprinted url if the fingerprint is enabled
+func (s *GinServer) AssetURL(name string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.assets.URL(name)
+}
@@ -0,0 +1,265 @@
+package http
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../..", "test", "Gonyx")
+}
+
+func TestGinServer_UpdateConfigs(t *testing.T) {
+	makeReadyConfigManager()
+
+	serverConfig := types.GinServerConfig{Name: "reload", ListenAddress: "127.0.0.1:0", Versions: []string{"v1"}}
+	serverConfig.Config.RequestMethods = []string{"ALL"}
+
+	server, err := NewGinServer("http", serverConfig, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Creating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+	_ = server.AddRoute(http.MethodGet, "/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") }, "ping", []string{"all"}, nil)
+
+	serve := func(path string) int {
+		w := httptest.NewRecorder()
+		server.serveHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	serverConfig.Versions = []string{"v1", "v2"}
+	for i := 0; i < 2; i++ {
+		if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err != nil {
+			t.Fatalf("Updating Http Server --> Expected: %v, but got %v", nil, err)
+		}
+	}
+	if len(server.predefinedRoutes) != 1 {
+		t.Errorf("Recorded routes after reload --> Expected: %v, but got %v", 1, len(server.predefinedRoutes))
+	}
+	if code := serve("/v2/ping"); code != http.StatusOK {
+		t.Errorf("Route of the new version --> Expected: %v, but got %v", http.StatusOK, code)
+	}
+
+	serverConfig.Middlewares.Order = []string{"not-registered"}
+	if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err == nil {
+		t.Errorf("Updating with unknown middleware --> Expected an error, but got %v", err)
+	}
+	if code := serve("/v2/ping"); code != http.StatusOK {
+		t.Errorf("Route after failed reload --> Expected: %v, but got %v", http.StatusOK, code)
+	}
+
+	// the recorded route is not accepted by the methods of the new config
+	serverConfig.Middlewares.Order = nil
+	serverConfig.Config.RequestMethods = []string{http.MethodPost}
+	if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err == nil {
+		t.Errorf("Updating with unsupported route method --> Expected an error, but got %v", err)
+	}
+	if code := serve("/v2/ping"); code != http.StatusOK {
+		t.Errorf("Route after failed route replay --> Expected: %v, but got %v", http.StatusOK, code)
+	}
+}
+
+func TestGinServer_ProxyRoutes(t *testing.T) {
+	makeReadyConfigManager()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer upstream.Close()
+
+	serverConfig := types.GinServerConfig{Name: "gateway", ListenAddress: "127.0.0.1:0"}
+	serverConfig.Config.RequestMethods = []string{"ALL"}
+	serverConfig.Proxies = []types.ProxyRouteConfig{{
+		Name:      "users",
+		Path:      "/api/users/*path",
+		Methods:   []string{"get"},
+		Upstreams: []string{upstream.URL},
+		Rewrite:   types.ProxyRewriteConfig{StripPrefix: "/api"},
+	}}
+
+	server, err := NewGinServer("http", serverConfig, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Creating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.serveHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+		return w
+	}
+
+	if w := serve(); w.Code != http.StatusOK || w.Body.String() != "/users/1" {
+		t.Errorf("Proxy route --> Expected: %v %v, but got %v %v", http.StatusOK, "/users/1", w.Code, w.Body.String())
+	}
+
+	serverConfig.Proxies = []types.ProxyRouteConfig{{Name: "broken", Path: "/broken"}}
+	if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err == nil {
+		t.Errorf("Updating with invalid proxy --> Expected an error, but got %v", err)
+	}
+	if w := serve(); w.Code != http.StatusOK {
+		t.Errorf("Proxy route after failed reload --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestGinServer_SignedDownloads(t *testing.T) {
+	makeReadyConfigManager()
+
+	backend, err := storage.NewLocalBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Creating local backend --> Expected: %v, but got %v", nil, err)
+	}
+	storage.GetManager().RegisterBackend("downloads-test", backend)
+	_, _ = backend.Put(context.Background(), "reports/2024 q1.txt", strings.NewReader("quarterly report"), storage.PutOptions{})
+
+	serverConfig := types.GinServerConfig{Name: "files", ListenAddress: "127.0.0.1:0"}
+	serverConfig.Config.RequestMethods = []string{"ALL"}
+	serverConfig.Downloads = types.DownloadsConfig{Enabled: true, Backend: "downloads-test", Secret: "secret"}
+
+	server, err := NewGinServer("http", serverConfig, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Creating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+
+	serve := func(method string, target string, header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, nil)
+		for name, values := range header {
+			r.Header[name] = values
+		}
+		server.serveHTTP(w, r)
+		return w
+	}
+
+	signedURL, err := server.SignedDownloadURL("reports/2024 q1.txt", time.Minute)
+	if err != nil || !strings.HasPrefix(signedURL, "/files/reports/2024%20q1.txt?") {
+		t.Fatalf("Signed url --> Expected: %v, but got %v %v", "/files/reports/2024%20q1.txt?...", signedURL, err)
+	}
+
+	w := serve(http.MethodGet, signedURL, nil)
+	if w.Code != http.StatusOK || w.Body.String() != "quarterly report" || w.Header().Get("ETag") == "" {
+		t.Errorf("Download --> Expected: %v %v, but got %v %v", http.StatusOK, "quarterly report", w.Code, w.Body.String())
+	}
+
+	w = serve(http.MethodGet, signedURL, http.Header{"Range": []string{"bytes=0-8"}})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "quarterly" {
+		t.Errorf("Download range --> Expected: %v %v, but got %v %v", http.StatusPartialContent, "quarterly", w.Code, w.Body.String())
+	}
+
+	if w = serve(http.MethodHead, signedURL, nil); w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("Download head --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+
+	tampered := strings.Replace(signedURL, "q1", "q2", 1)
+	if w = serve(http.MethodGet, tampered, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Tampered url --> Expected: %v, but got %v", http.StatusForbidden, w.Code)
+	}
+
+	past := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	expired := "/files/reports/2024%20q1.txt?expires=" + past + "&signature=" + signDownload("secret", "reports/2024 q1.txt", past)
+	if w = serve(http.MethodGet, expired, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expired url --> Expected: %v, but got %v", http.StatusForbidden, w.Code)
+	}
+
+	missing, _ := server.SignedDownloadURL("reports/missing.txt", 0)
+	if w = serve(http.MethodGet, missing, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Missing file --> Expected: %v, but got %v", http.StatusNotFound, w.Code)
+	}
+
+	serverConfig.Downloads.Secret = ""
+	if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err == nil {
+		t.Errorf("Downloads without secret --> Expected an error, but got %v", err)
+	}
+}
+
+func TestGinServer_TemplatesAndStatic(t *testing.T) {
+	makeReadyConfigManager()
+
+	serverConfig := types.GinServerConfig{Name: "web", ListenAddress: "127.0.0.1:0", SupportStatic: true}
+	serverConfig.Config.RequestMethods = []string{"ALL"}
+	serverConfig.Static = types.StaticConfig{Root: "public", Fingerprint: true}
+	serverConfig.Templates = types.TemplatesConfig{Enabled: true, Root: "templates"}
+
+	// the directories of the config do not exist on the disk, so the files are set from an embedded fs
+	fsys := fstest.MapFS{
+		"public/app.css":       &fstest.MapFile{Data: []byte("body{}")},
+		"templates/index.html": &fstest.MapFile{Data: []byte(`<link href="{{asset "app.css"}}">{{title .}}`)},
+	}
+
+	server, err := NewGinServer("http", serverConfig, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Creating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+	if err := server.SetStaticFS(fsys); err != nil {
+		t.Fatalf("Setting static files --> Expected: %v, but got %v", nil, err)
+	}
+	_ = server.AddTemplateFuncs(template.FuncMap{"title": strings.ToUpper})
+	if err := server.SetTemplatesFS(fsys); err != nil {
+		t.Fatalf("Setting templates --> Expected: %v, but got %v", nil, err)
+	}
+	_ = server.AddRoute(http.MethodGet, "/", func(c *gin.Context) { c.HTML(http.StatusOK, "index.html", "gonyx") }, "index", nil, nil)
+
+	// the files and the functions are kept on reload
+	if err := server.UpdateConfigs(serverConfig, map[string]interface{}{}); err != nil {
+		t.Fatalf("Updating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.serveHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	assetURL := server.AssetURL("app.css")
+	if w := serve("/"); w.Body.String() != `<link href="`+assetURL+`">GONYX` {
+		t.Errorf("Rendering template --> Expected: %v, but got %v", `<link href="`+assetURL+`">GONYX`, w.Body.String())
+	}
+	if w := serve(assetURL); w.Code != http.StatusOK || w.Body.String() != "body{}" {
+		t.Errorf("Serving fingerprinted file --> Expected: %v %v, but got %v %v", http.StatusOK, "body{}", w.Code, w.Body.String())
+	}
+}
+
+func TestGinServer_MountHandler(t *testing.T) {
+	s := newTestServer()
+	s.baseRouter.Use(func(c *gin.Context) { c.Header("X-Middleware", "on") })
+	s.baseRouter.GET("/api/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+
+	mount := func(body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(body)) })
+	}
+	if err := s.MountHandler("/api/", mount("gateway")); err != nil {
+		t.Fatalf("Mounting handler --> Expected: %v, but got %v", nil, err)
+	}
+	_ = s.MountHandler("/api/v2", mount("gateway v2"))
+	if err := s.MountHandler("api", mount("duplicate")); err == nil {
+		t.Errorf("Mounting duplicate prefix --> Expected an error, but got %v", err)
+	}
+	if err := s.MountHandler("/other", nil); err == nil {
+		t.Errorf("Mounting nil handler --> Expected an error, but got %v", err)
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.baseRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	for path, body := range map[string]string{"/api/ping": "pong", "/api/users/1": "gateway", "/api/v2/users": "gateway v2"} {
+		if w := serve(path); w.Code != http.StatusOK || w.Body.String() != body || w.Header().Get("X-Middleware") != "on" {
+			t.Errorf("Serving %v --> Expected: %v %v, but got %v %v %v", path, http.StatusOK, body, w.Code, w.Body.String(), w.Header().Get("X-Middleware"))
+		}
+	}
+	if w := serve("/apix"); w.Code != http.StatusNotFound {
+		t.Errorf("Serving unmounted path --> Expected: %v, but got %v", http.StatusNotFound, w.Code)
+	}
+}
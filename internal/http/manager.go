@@ -3,11 +3,19 @@ package http
 import (
 	"encoding/json"
 	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/http/proxy"
+	"github.com/Blocktunium/gonyx/internal/http/realtime"
 	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
 	"github.com/Blocktunium/gonyx/internal/utils"
 	"github.com/gin-gonic/gin"
+	"html/template"
+	"io/fs"
 	"log"
+	"net/http"
 	"sync"
+	"time"
 )
 
 // Mark: manager
@@ -19,6 +27,8 @@ type manager struct {
 	servers          map[string]*GinServer
 	defaultServer    string
 	isServersStarted bool
+	hubs             map[string]*realtime.Hub
+	routeProxies     []*proxy.Proxy // the proxies of the routes added by the application, they are closed with the servers
 }
 
 // MARK: Module variables
@@ -47,6 +57,7 @@ func (m *manager) init() {
 	if m.servers == nil {
 		m.servers = make(map[string]*GinServer)
 	}
+	configuredNames := make(map[string]struct{})
 
 	for _, item := range serversCfg.([]interface{}) {
 		jsonBody, err2 := json.Marshal(item)
@@ -57,36 +68,48 @@ func (m *manager) init() {
 		var obj types.GinServerConfig
 		err := json.Unmarshal(jsonBody, &obj)
 		if err == nil {
+			configuredNames[obj.Name] = struct{}{}
+
 			//first check server existed -> if not exist -> create a new one
 			if server1, ok := m.servers[obj.Name]; ok {
-				// just update the server with new config
+				// just update the server with new config, the engine is swapped behind the running listener
 				err1 := server1.UpdateConfigs(obj, item.(map[string]interface{}))
 				if err1 != nil {
-					server1.Stop()
-					delete(m.servers, obj.Name)
-					var k int
-					for i, v := range serverNames {
-						if v == obj.Name {
-							k = i
-							break
-						}
-					}
-
-					if k >= 0 {
-						serverNames = append(serverNames[:k], serverNames[k+1:]...)
-					}
+					m.logServerError("http.manager.init", "Updating the Http server config failed, the previous config is kept ...", obj.Name, err1)
 				}
+				serverNames = append(serverNames, obj.Name)
 			} else {
 				server, err1 := NewGinServer(m.name, obj, item.(map[string]interface{}))
 				if err1 == nil {
 					m.servers[obj.Name] = server
 
 					serverNames = append(serverNames, obj.Name)
+
+					// a server added to the config while the others are running
+					if m.isServersStarted {
+						go func(s *GinServer, name string) {
+							if err := s.Start(); err != nil {
+								m.logServerError("http.manager.init", "Starting the Http server failed ...", name, err)
+							}
+						}(server, obj.Name)
+					}
+				} else {
+					m.logServerError("http.manager.init", "Creating the Http server failed ...", obj.Name, err1)
 				}
 			}
 		}
 	}
 
+	// the servers removed from the config are stopped
+	for name, server := range m.servers {
+		if _, ok := configuredNames[name]; !ok {
+			if err := server.Stop(); err != nil {
+				m.logServerError("http.manager.init", "Stopping the removed Http server failed ...", name, err)
+			}
+			delete(m.servers, name)
+		}
+	}
+
 	defaultS, err := config.GetManager().Get(m.name, "default")
 	if err == nil {
 		if utils.ArrayContains(&serverNames, defaultS.(string)) {
@@ -95,8 +118,16 @@ func (m *manager) init() {
 			m.defaultServer = serverNames[0]
 		}
 	}
+}
 
-	m.isServersStarted = false
+// logServerError - report the error of the server, it is printed if the logger is not available
+func (m *manager) logServerError(section string, message string, serverName string, err error) {
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(logTypes.NewLogObject(logTypes.ERROR, section, HttpServerMaintenanceType, time.Now(), message, map[string]interface{}{"server": serverName, "error": err.Error()}))
+	} else {
+		log.Printf("%v `%v`: %v\n", message, serverName, err)
+	}
 }
 
 // restartOnChangeConfig - subscribe a function for when the config is changed
@@ -105,11 +136,8 @@ func (m *manager) restartOnChangeConfig() {
 	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
 	if err == nil {
 		wrapper.RegisterChangeCallback(func() interface{} {
-			if m.isServersStarted {
-				m.StopServers()
-				m.init()
-				m.StartServers()
-			}
+			// the servers are updated in place, only a server with changed listener settings is restarted
+			m.init()
 			return nil
 		})
 	} else {
@@ -177,10 +205,26 @@ func (m *manager) StopServers() error {
 				}
 			}(item)
 		}
+		m.isServersStarted = false
+	}
+
+	// the health checks of the proxies are stopped
+	for _, item := range m.routeProxies {
+		item.Close()
 	}
+	m.routeProxies = nil
 	return nil
 }
 
+// AddRouteProxy - keep the proxy of a route added by the application, it is closed by StopServers
+// The routes are registered again on reload, so their proxies are kept until the servers are stopped
+func (m *manager) AddRouteProxy(p *proxy.Proxy) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.routeProxies = append(m.routeProxies, p)
+}
+
 // AddRoute - add a route to the server with specified name
 func (m *manager) AddRoute(method string, path string, f func(c *gin.Context), routeName string, versions []string, groupNames []string, serverName ...string) error {
 	if len(serverName) > 0 {
@@ -197,6 +241,128 @@ func (m *manager) AddRoute(method string, path string, f func(c *gin.Context), r
 	return NewAddRouteToNilServerErr(path)
 }
 
+// AddWebSocketRoute - add a websocket route to the server with specified name
+func (m *manager) AddWebSocketRoute(path string, f func(conn *realtime.Conn), options *realtime.WebSocketOptions, routeName string, versions []string, groupNames []string, serverName ...string) error {
+	if len(serverName) > 0 {
+		for _, sn := range serverName {
+			if s, ok := m.servers[sn]; ok {
+				return s.AddWebSocketRoute(path, f, options, routeName, versions, groupNames)
+			}
+		}
+	} else {
+		if m.defaultServer != "" {
+			return m.servers[m.defaultServer].AddWebSocketRoute(path, f, options, routeName, versions, groupNames)
+		}
+	}
+	return NewAddRouteToNilServerErr(path)
+}
+
+// AddSSERoute - add a server-sent events route to the server with specified name
+func (m *manager) AddSSERoute(path string, f func(stream *realtime.SSEStream), options *realtime.SSEOptions, routeName string, versions []string, groupNames []string, serverName ...string) error {
+	if len(serverName) > 0 {
+		for _, sn := range serverName {
+			if s, ok := m.servers[sn]; ok {
+				return s.AddSSERoute(path, f, options, routeName, versions, groupNames)
+			}
+		}
+	} else {
+		if m.defaultServer != "" {
+			return m.servers[m.defaultServer].AddSSERoute(path, f, options, routeName, versions, groupNames)
+		}
+	}
+	return NewAddRouteToNilServerErr(path)
+}
+
+// SignedDownloadURL - return a signed url of the downloads route of the server with specified name
+func (m *manager) SignedDownloadURL(key string, ttl time.Duration, serverName ...string) (string, error) {
+	if len(serverName) > 0 {
+		for _, sn := range serverName {
+			if s, ok := m.servers[sn]; ok {
+				return s.SignedDownloadURL(key, ttl)
+			}
+		}
+	} else {
+		if m.defaultServer != "" {
+			return m.servers[m.defaultServer].SignedDownloadURL(key, ttl)
+		}
+	}
+	return "", NewFromNilServerErr()
+}
+
+// SetTemplatesFS - read the html templates of the servers with specified names from the files, e.g. an embed.FS
+func (m *manager) SetTemplatesFS(fsys fs.FS, serverName ...string) error {
+	return m.forEachServer(func(s *GinServer) error { return s.SetTemplatesFS(fsys) }, serverName...)
+}
+
+// SetStaticFS - read the static files of the servers with specified names from the files, e.g. an embed.FS
+func (m *manager) SetStaticFS(fsys fs.FS, serverName ...string) error {
+	return m.forEachServer(func(s *GinServer) error { return s.SetStaticFS(fsys) }, serverName...)
+}
+
+// AddTemplateFuncs - add the functions to the html templates of the servers with specified names
+func (m *manager) AddTemplateFuncs(funcs template.FuncMap, serverName ...string) error {
+	return m.forEachServer(func(s *GinServer) error { return s.AddTemplateFuncs(funcs) }, serverName...)
+}
+
+// AssetURL - return the url of the static file of the server with specified name
+func (m *manager) AssetURL(name string, serverName ...string) string {
+	if len(serverName) > 0 {
+		for _, sn := range serverName {
+			if s, ok := m.servers[sn]; ok {
+				return s.AssetURL(name)
+			}
+		}
+	} else {
+		if m.defaultServer != "" {
+			return m.servers[m.defaultServer].AssetURL(name)
+		}
+	}
+	return name
+}
+
+// forEachServer - call f for the servers with specified names, or for the default server if no name is given
+func (m *manager) forEachServer(f func(s *GinServer) error, serverName ...string) error {
+	if len(serverName) == 0 {
+		if m.defaultServer == "" {
+			return NewFromNilServerErr()
+		}
+		serverName = []string{m.defaultServer}
+	}
+
+	for _, sn := range serverName {
+		s, ok := m.servers[sn]
+		if !ok {
+			return NewFromNilServerErr()
+		}
+		if err := f(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MountHandler - serve the requests of the prefix which are not matched by a route of the servers with specified names by the handler
+func (m *manager) MountHandler(prefix string, handler http.Handler, serverName ...string) error {
+	return m.forEachServer(func(s *GinServer) error { return s.MountHandler(prefix, handler) }, serverName...)
+}
+
+// GetHub - return the hub with specified name, it is created on first use and shared between servers
+func (m *manager) GetHub(name string) *realtime.Hub {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.hubs == nil {
+		m.hubs = make(map[string]*realtime.Hub)
+	}
+
+	hub, ok := m.hubs[name]
+	if !ok {
+		hub = realtime.NewHub(name)
+		m.hubs[name] = hub
+	}
+	return hub
+}
+
 //func (m *manager) GetRouteByName(routeName string, serverName ...string) (*fiber.Route, error) {
 //	if len(serverName) > 1 {
 //		return nil, NewFromMultipleServerErr()
@@ -276,3 +442,17 @@ func (m *manager) GetAllRoutes() []gin.RouteInfo {
 	}
 	return routes
 }
+
+// GetVersionedRoutes - return the routes of the servers with their api versions, only of the versions if any is given
+func (m *manager) GetVersionedRoutes(versions ...string) []VersionedRoute {
+	var routes []VersionedRoute
+
+	for _, item := range m.servers {
+		for _, route := range item.GetVersionedRoutes() {
+			if len(versions) == 0 || utils.ArrayContains(&versions, route.Version) {
+				routes = append(routes, route)
+			}
+		}
+	}
+	return routes
+}
@@ -1 +1,41 @@
 package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/proxy"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+)
+
+func TestManager_StopServersClosesRouteProxies(t *testing.T) {
+	var checks int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&checks, 1)
+	}))
+	defer upstream.Close()
+
+	p, err := proxy.New(types.ProxyRouteConfig{
+		Name:        "users",
+		Upstreams:   []string{upstream.URL},
+		HealthCheck: types.ProxyHealthCheckConfig{Path: "/health", Interval: 10},
+	})
+	if err != nil {
+		t.Fatalf("Creating proxy --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := &manager{}
+	m.AddRouteProxy(p)
+	time.Sleep(50 * time.Millisecond)
+	_ = m.StopServers()
+
+	// the health check of the closed proxy does not run anymore
+	stopped := atomic.LoadInt32(&checks)
+	time.Sleep(50 * time.Millisecond)
+	if stopped == 0 || atomic.LoadInt32(&checks) > stopped+1 || len(m.routeProxies) != 0 {
+		t.Errorf("Health checks after stop --> Expected: %v, but got %v", stopped, atomic.LoadInt32(&checks))
+	}
+}
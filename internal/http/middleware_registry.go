@@ -0,0 +1,65 @@
+package http
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MiddlewareFactory - create a middleware from its raw config in `middlewares.<name>`, the config is nil if it is absent
+type MiddlewareFactory func(rawConfig map[string]interface{}) (gin.HandlerFunc, error)
+
+// builtinMiddlewares - the middlewares configured by the server itself
+var builtinMiddlewares = []string{
+	"logger",
+	"favicon",
+	"cors",
+	"compress",
+	"etag",
+	"responsecache",
+	"security",
+	"csrf",
+	"timeout",
+	"limits",
+	"i18n",
+	"tenancy",
+}
+
+var (
+	middlewareRegistryLock sync.RWMutex
+	middlewareRegistry     = make(map[string]MiddlewareFactory)
+)
+
+// RegisterMiddleware - register a middleware to be referenced by name in `middlewares.order`
+// It must be called before the servers are created, the servers are recreated on config change
+func RegisterMiddleware(name string, factory MiddlewareFactory) error {
+	if name == "" {
+		return NewRegisterMiddlewareErr(name, "the name is empty")
+	}
+	if factory == nil {
+		return NewRegisterMiddlewareErr(name, "the factory is nil")
+	}
+	for _, item := range builtinMiddlewares {
+		if item == name {
+			return NewRegisterMiddlewareErr(name, "the name is used by a built-in middleware")
+		}
+	}
+
+	middlewareRegistryLock.Lock()
+	defer middlewareRegistryLock.Unlock()
+
+	if _, ok := middlewareRegistry[name]; ok {
+		return NewRegisterMiddlewareErr(name, "the name is already registered")
+	}
+	middlewareRegistry[name] = factory
+	return nil
+}
+
+// getMiddlewareFactory - return the registered middleware factory
+func getMiddlewareFactory(name string) (MiddlewareFactory, bool) {
+	middlewareRegistryLock.RLock()
+	defer middlewareRegistryLock.RUnlock()
+
+	factory, ok := middlewareRegistry[name]
+	return factory, ok
+}
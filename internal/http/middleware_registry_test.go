@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestServer() *GinServer {
+	gin.SetMode(gin.TestMode)
+	return &GinServer{baseRouter: gin.New(), supportedMiddlewares: builtinMiddlewares}
+}
+
+func TestRegisterMiddleware_AttachByOrder(t *testing.T) {
+	err := RegisterMiddleware("test-header", func(rawConfig map[string]interface{}) (gin.HandlerFunc, error) {
+		value, _ := rawConfig["value"].(string)
+		if value == "" {
+			return nil, errors.New("value is required")
+		}
+		return func(c *gin.Context) {
+			c.Header("X-Test", value)
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Register middleware --> Expected: %v, but got %v", nil, err)
+	}
+
+	if err := RegisterMiddleware("test-header", func(map[string]interface{}) (gin.HandlerFunc, error) { return nil, nil }); err == nil {
+		t.Errorf("Register duplicate middleware --> Expected an error, but got %v", err)
+	}
+	if err := RegisterMiddleware("cors", func(map[string]interface{}) (gin.HandlerFunc, error) { return nil, nil }); err == nil {
+		t.Errorf("Register built-in middleware name --> Expected an error, but got %v", err)
+	}
+
+	s := newTestServer()
+	err = s.attachMiddlewares([]string{"test-header"}, map[string]interface{}{
+		"test-header": map[string]interface{}{"value": "on"},
+	})
+	if err != nil {
+		t.Fatalf("Attach middlewares --> Expected: %v, but got %v", nil, err)
+	}
+	s.baseRouter.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	s.baseRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Header().Get("X-Test") != "on" {
+		t.Errorf("Registered middleware header --> Expected: %v, but got %v", "on", w.Header().Get("X-Test"))
+	}
+
+	err = newTestServer().attachMiddlewares([]string{"test-header"}, nil)
+	if _, ok := err.(*MiddlewareConfigErr); !ok {
+		t.Errorf("Attach without config --> Expected: %T, but got %v", &MiddlewareConfigErr{}, err)
+	}
+}
+
+func TestAttachMiddlewares_Errors(t *testing.T) {
+	err := newTestServer().attachMiddlewares([]string{"not-registered"}, nil)
+	if _, ok := err.(*UnknownMiddlewareErr); !ok {
+		t.Errorf("Unknown middleware --> Expected: %T, but got %v", &UnknownMiddlewareErr{}, err)
+	}
+
+	err = newTestServer().attachMiddlewares([]string{"cors"}, map[string]interface{}{
+		"cors": map[string]interface{}{"allow_origins": 10},
+	})
+	if _, ok := err.(*MiddlewareConfigErr); !ok {
+		t.Errorf("Invalid cors config --> Expected: %T, but got %v", &MiddlewareConfigErr{}, err)
+	}
+
+	err = newTestServer().attachMiddlewares([]string{"favicon"}, map[string]interface{}{
+		"favicon": map[string]interface{}{"file": "./not-exist.ico"},
+	})
+	if _, ok := err.(*MiddlewareConfigErr); !ok {
+		t.Errorf("Missing favicon file --> Expected: %T, but got %v", &MiddlewareConfigErr{}, err)
+	}
+}
+
+func TestAttachMiddlewares_FaviconWithoutFile(t *testing.T) {
+	s := newTestServer()
+	err := s.attachMiddlewares([]string{"favicon"}, map[string]interface{}{
+		"favicon": map[string]interface{}{"file": "", "url": "/favicon.ico"},
+	})
+	if err != nil {
+		t.Fatalf("Favicon without file --> Expected: %v, but got %v", nil, err)
+	}
+
+	w := httptest.NewRecorder()
+	s.baseRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/favicon.ico", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Favicon request --> Expected: %v, but got %v", http.StatusNoContent, w.Code)
+	}
+}
@@ -0,0 +1,410 @@
+package middlewares
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// MARK: Encoders
+
+// CompressionEncoderFactory - create a streaming encoder writing to w with the level (zero means default)
+type CompressionEncoderFactory func(w io.Writer, level int) (io.WriteCloser, error)
+
+var (
+	encodersLock sync.RWMutex
+	encoders     = map[string]CompressionEncoderFactory{
+		"gzip": func(w io.Writer, level int) (io.WriteCloser, error) {
+			if level == 0 {
+				level = gzip.DefaultCompression
+			}
+			return gzip.NewWriterLevel(w, level)
+		},
+		"zstd": func(w io.Writer, level int) (io.WriteCloser, error) {
+			encoderLevel := zstd.SpeedDefault
+			if level != 0 {
+				encoderLevel = zstd.EncoderLevelFromZstd(level)
+			}
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(encoderLevel), zstd.WithEncoderConcurrency(1))
+		},
+		"br": func(w io.Writer, level int) (io.WriteCloser, error) {
+			if level == 0 {
+				level = brotli.DefaultCompression
+			}
+			return brotli.NewWriterLevel(w, level), nil
+		},
+	}
+
+	defaultCompressEncodings    = []string{"zstd", "br", "gzip"}
+	defaultCompressContentTypes = []string{
+		"text/*",
+		"application/json",
+		"application/*+json",
+		"application/javascript",
+		"application/xml",
+		"application/*+xml",
+		"image/svg+xml",
+	}
+)
+
+// precompressedSuffixes - the encoding of precompressed static files by the file suffix
+var precompressedSuffixes = map[string]string{
+	".gz":  "gzip",
+	".zst": "zstd",
+	".br":  "br",
+}
+
+// RegisterCompressionEncoder - register an encoding (e.g. "deflate") to be usable in the compress middleware config
+func RegisterCompressionEncoder(name string, factory CompressionEncoderFactory) {
+	encodersLock.Lock()
+	defer encodersLock.Unlock()
+
+	encoders[strings.ToLower(name)] = factory
+}
+
+// getCompressionEncoder - return the registered encoder factory
+func getCompressionEncoder(name string) (CompressionEncoderFactory, bool) {
+	encodersLock.RLock()
+	defer encodersLock.RUnlock()
+
+	f, ok := encoders[name]
+	return f, ok
+}
+
+// MARK: Middleware
+
+// CompressStaticConfig - the static files config used to serve precompressed files
+type CompressStaticConfig struct {
+	Prefix               string
+	Root                 string
+	CompressedFileSuffix string
+}
+
+// CompressMiddleware creates a response compression middleware based on the provided configuration
+// If config is nil, the default encodings, content types and a 1KB threshold are used
+func CompressMiddleware(config *types.CompressMiddlewareConfig, static *CompressStaticConfig) gin.HandlerFunc {
+	cfg := types.CompressMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if len(cfg.Encodings) == 0 {
+		cfg.Encodings = defaultCompressEncodings
+	}
+	if len(cfg.ContentTypes) == 0 {
+		cfg.ContentTypes = defaultCompressContentTypes
+	}
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = 1024
+	}
+
+	var supported []string
+	for _, item := range cfg.Encodings {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if _, ok := getCompressionEncoder(item); ok {
+			supported = append(supported, item)
+		}
+	}
+
+	return func(c *gin.Context) {
+		for _, prefix := range cfg.ExcludedPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		if static != nil && servePrecompressed(c, static) {
+			return
+		}
+
+		if c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), supported)
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			config:         &cfg,
+			encoding:       encoding,
+			status:         http.StatusOK,
+		}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+// servePrecompressed - serve `<file><suffix>` instead of the static file if it exists and the client accepts the encoding
+func servePrecompressed(c *gin.Context, static *CompressStaticConfig) bool {
+	if static.CompressedFileSuffix == "" || static.Root == "" {
+		return false
+	}
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	encoding, ok := precompressedSuffixes[static.CompressedFileSuffix]
+	if !ok || negotiateEncoding(c.GetHeader("Accept-Encoding"), []string{encoding}) == "" {
+		return false
+	}
+
+	prefix := "/" + strings.Trim(static.Prefix, "/")
+	requestPath := path.Clean(c.Request.URL.Path)
+	if prefix != "/" && requestPath != prefix && !strings.HasPrefix(requestPath, prefix+"/") {
+		return false
+	}
+
+	relative := strings.TrimPrefix(strings.TrimPrefix(requestPath, prefix), "/")
+	if relative == "" {
+		return false
+	}
+
+	filePath := filepath.Join(static.Root, filepath.FromSlash(relative))
+	info, err := os.Stat(filePath + static.CompressedFileSuffix)
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	if contentType := mime.TypeByExtension(filepath.Ext(filePath)); contentType != "" {
+		c.Header("Content-Type", contentType)
+	}
+	c.Header("Content-Encoding", encoding)
+	c.Writer.Header().Add("Vary", "Accept-Encoding")
+	http.ServeFile(c.Writer, c.Request, filePath+static.CompressedFileSuffix)
+	c.Abort()
+	return true
+}
+
+// negotiateEncoding - select the first server encoding accepted by the client with non-zero quality
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		accepted[name] = quality
+	}
+
+	for _, item := range supported {
+		if q, ok := accepted[item]; ok {
+			if q > 0 {
+				return item
+			}
+			continue
+		}
+		if q, ok := accepted["*"]; ok && q > 0 {
+			return item
+		}
+	}
+	return ""
+}
+
+// matchContentType - check whether the content type is in the list, a trailing "*" matches a prefix
+func matchContentType(contentType string, patterns []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.Contains(pattern, "*") {
+			parts := strings.SplitN(pattern, "*", 2)
+			if strings.HasPrefix(mediaType, parts[0]) && strings.HasSuffix(mediaType, parts[1]) {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// MARK: compressWriter
+
+// compressWriter - buffer the beginning of the response to decide about compression
+type compressWriter struct {
+	gin.ResponseWriter
+	config   *types.CompressMiddlewareConfig
+	encoding string
+	status   int
+	buffer   []byte
+	decided  bool
+	encoder  io.WriteCloser
+}
+
+// WriteHeader - keep the status code until the compression is decided
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow - force the decision and write the headers
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+}
+
+// Status - return the response status code
+func (w *compressWriter) Status() int {
+	if !w.decided {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written - return whether the response headers are written
+func (w *compressWriter) Written() bool {
+	return w.decided || len(w.buffer) > 0
+}
+
+// Write - write the data, compressed if it is decided to compress
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) < w.config.MinLength {
+			return len(data), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString - write the string data
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush - flush the encoder and the underlying writer, used by streaming responses
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap - return the underlying writer, used by http.ResponseController
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack - the connection is taken over so compression is not applied
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// shouldCompress - check the response to see whether it can be compressed
+func (w *compressWriter) shouldCompress() bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusPartialContent || w.status == http.StatusNotModified {
+		return false
+	}
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" || strings.Contains(header.Get("Cache-Control"), "no-transform") {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buffer)
+	}
+	return matchContentType(contentType, w.config.ContentTypes)
+}
+
+// decide - decide about compression, write the headers and the buffered data
+func (w *compressWriter) decide(enough bool) error {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+
+	if enough && w.shouldCompress() {
+		if factory, ok := getCompressionEncoder(w.encoding); ok {
+			encoder, err := factory(w.ResponseWriter, w.config.Level)
+			if err == nil {
+				w.encoder = encoder
+				header.Set("Content-Encoding", w.encoding)
+				header.Del("Content-Length")
+				// the compressed representation is not byte-identical anymore
+				if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+					header.Set("ETag", "W/"+etag)
+				}
+			}
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buffer) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return nil
+	}
+
+	buffer := w.buffer
+	w.buffer = nil
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buffer)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffer)
+	return err
+}
+
+// finish - write the remaining data and close the encoder
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if len(w.buffer) == 0 && w.status == http.StatusOK && !w.ResponseWriter.Written() {
+			// nothing is written by the handlers
+			w.decided = true
+			return
+		}
+		_ = w.decide(false)
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+	}
+}
@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+func newCompressRouter(config *types.CompressMiddlewareConfig, body string, contentType string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CompressMiddleware(config, nil))
+	router.GET("/data", func(c *gin.Context) {
+		c.Data(http.StatusOK, contentType, []byte(body))
+	})
+	return router
+}
+
+func TestCompressMiddleware_Gzip(t *testing.T) {
+	body := strings.Repeat("gonyx ", 500)
+	router := newCompressRouter(nil, body, "text/plain; charset=utf-8")
+
+	r := httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding --> Expected: %v, but got %v", "gzip", w.Header().Get("Content-Encoding"))
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Gzip reader --> Expected: %v, but got %v", nil, err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != body {
+		t.Errorf("Decoded body --> Expected length: %v, but got %v", len(body), len(decoded))
+	}
+}
+
+func TestCompressMiddleware_Brotli(t *testing.T) {
+	body := strings.Repeat("gonyx ", 500)
+	router := newCompressRouter(nil, body, "application/json")
+
+	r := httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("Accept-Encoding", "gzip, br")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Header().Get("Content-Encoding") != "br" {
+		t.Fatalf("Content-Encoding --> Expected: %v, but got %v", "br", w.Header().Get("Content-Encoding"))
+	}
+	decoded, _ := io.ReadAll(brotli.NewReader(w.Body))
+	if string(decoded) != body {
+		t.Errorf("Decoded body --> Expected length: %v, but got %v", len(body), len(decoded))
+	}
+}
+
+func TestCompressMiddleware_SkipSmallAndBinary(t *testing.T) {
+	router := newCompressRouter(&types.CompressMiddlewareConfig{MinLength: 100}, "small", "text/plain")
+	r := httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != "small" {
+		t.Errorf("Small response --> Expected uncompressed body, but got encoding %q", w.Header().Get("Content-Encoding"))
+	}
+
+	router = newCompressRouter(&types.CompressMiddlewareConfig{MinLength: 1}, strings.Repeat("x", 200), "image/png")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Binary response --> Expected uncompressed body, but got encoding %q", w.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected string
+	}{
+		{"gzip", "gzip"},
+		{"gzip, zstd", "zstd"},
+		{"zstd;q=0, gzip", "gzip"},
+		{"*", "zstd"},
+		{"identity", ""},
+		{"", ""},
+	}
+
+	for _, item := range cases {
+		result := negotiateEncoding(item.header, []string{"zstd", "gzip"})
+		if result != item.expected {
+			t.Errorf("Negotiate %q --> Expected: %q, but got %q", item.header, item.expected, result)
+		}
+	}
+}
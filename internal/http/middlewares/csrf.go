@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// CsrfTokenKey - the key of the CSRF token in the gin context
+const CsrfTokenKey = "csrf_token"
+
+// CsrfMiddleware creates a CSRF protection middleware using double-submit cookies
+// Safe requests receive the token cookie, unsafe requests must send the same token in the header or the form field
+// If config is nil, the defaults are used
+func CsrfMiddleware(config *types.CsrfMiddlewareConfig) gin.HandlerFunc {
+	cfg := types.CsrfMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.CookieName == "" {
+		cfg.CookieName = "_csrf"
+	}
+	if cfg.HeaderName == "" {
+		cfg.HeaderName = "X-CSRF-Token"
+	}
+	if cfg.FormField == "" {
+		cfg.FormField = "_csrf"
+	}
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 12 * 60 * 60
+	}
+
+	sameSite := http.SameSiteLaxMode
+	switch strings.ToLower(cfg.SameSite) {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return func(c *gin.Context) {
+		for _, prefix := range cfg.ExcludedPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		// the value is empty if the cookie does not exist
+		token, _ := c.Cookie(cfg.CookieName)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			if token == "" {
+				token = newCsrfToken()
+				http.SetCookie(c.Writer, &http.Cookie{
+					Name:     cfg.CookieName,
+					Value:    token,
+					Path:     cfg.CookiePath,
+					Domain:   cfg.CookieDomain,
+					MaxAge:   cfg.MaxAge,
+					Secure:   cfg.CookieSecure,
+					HttpOnly: false, // the page scripts read the cookie to send it back in the header
+					SameSite: sameSite,
+				})
+			}
+		default:
+			submitted := c.GetHeader(cfg.HeaderName)
+			if submitted == "" {
+				submitted = c.PostForm(cfg.FormField)
+			}
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(submitted)) != 1 {
+				c.AbortWithStatusJSON(http.StatusForbidden, ErrorHttpResponse{
+					Message:     "Forbidden",
+					Status:      http.StatusForbidden,
+					Description: "CSRF token is missing or invalid",
+				})
+				return
+			}
+		}
+
+		c.Set(CsrfTokenKey, token)
+		c.Next()
+	}
+}
+
+// CsrfToken - return the CSRF token of the request to be rendered in forms
+func CsrfToken(c *gin.Context) string {
+	return c.GetString(CsrfTokenKey)
+}
+
+// newCsrfToken - generate a random token
+func newCsrfToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
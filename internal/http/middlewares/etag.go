@@ -0,0 +1,221 @@
+package middlewares
+
+import (
+	"bufio"
+	"fmt"
+	"hash/crc32"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultEtagMaxBodySize - the maximum body size which is buffered to generate the validator
+const DefaultEtagMaxBodySize = 1 << 20
+
+// EtagMiddleware creates a middleware that generates ETag headers for GET/HEAD responses and
+// answers conditional requests (`If-None-Match`, `If-Modified-Since`) with 304 Not Modified
+// If config is nil, strong validators are generated for the bodies up to DefaultEtagMaxBodySize
+func EtagMiddleware(config *types.EtagMiddlewareConfig) gin.HandlerFunc {
+	weak := config != nil && config.Weak
+	maxBodySize := DefaultEtagMaxBodySize
+	if config != nil && config.MaxBodySize > 0 {
+		maxBodySize = config.MaxBodySize
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &etagWriter{ResponseWriter: c.Writer, status: http.StatusOK, maxBodySize: maxBodySize}
+		c.Writer = writer
+		c.Next()
+
+		if writer.streaming {
+			return
+		}
+		writer.finish(c.Request, weak)
+	}
+}
+
+// GenerateEtag - generate a validator for the body
+func GenerateEtag(body []byte, weak bool) string {
+	etag := fmt.Sprintf("\"%x-%x\"", len(body), crc32.ChecksumIEEE(body))
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// etagMatches - weak comparison of the validator against the `If-None-Match` header value
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, item := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(item), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince - check the `Last-Modified` response header against the `If-Modified-Since` request header
+func notModifiedSince(ifModifiedSince string, lastModified string) bool {
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// MARK: etagWriter
+
+// etagWriter - buffer the response body to compute the validator
+type etagWriter struct {
+	gin.ResponseWriter
+	status      int
+	body        []byte
+	maxBodySize int
+	streaming   bool
+}
+
+// WriteHeader - keep the status code until the response is finished
+func (w *etagWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// WriteHeaderNow - the headers are written when the response is finished
+func (w *etagWriter) WriteHeaderNow() {
+	if w.streaming {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Status - return the response status code
+func (w *etagWriter) Status() int {
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+// Size - return the size of the body
+func (w *etagWriter) Size() int {
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	return len(w.body)
+}
+
+// Written - return whether anything is written
+func (w *etagWriter) Written() bool {
+	return w.streaming || len(w.body) > 0 || w.status != http.StatusOK
+}
+
+// Write - buffer the data, a body larger than the maximum size is streamed without a validator
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if !w.streaming && len(w.body)+len(data) > w.maxBodySize {
+		w.startStreaming()
+	}
+	if w.streaming {
+		return w.ResponseWriter.Write(data)
+	}
+	w.body = append(w.body, data...)
+	return len(data), nil
+}
+
+// WriteString - buffer the string data
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush - a flushed response is a stream, so no validator is generated
+func (w *etagWriter) Flush() {
+	w.startStreaming()
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap - return the underlying writer, used by http.ResponseController
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack - the connection is taken over so no validator is generated
+func (w *etagWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.streaming = true
+	return w.ResponseWriter.Hijack()
+}
+
+// startStreaming - write the buffered response and pass the rest through
+func (w *etagWriter) startStreaming() {
+	if w.streaming {
+		return
+	}
+	w.streaming = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.body) > 0 {
+		_, _ = w.ResponseWriter.Write(w.body)
+		w.body = nil
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// finish - set the validator, answer the conditional request and write the response
+func (w *etagWriter) finish(r *http.Request, weak bool) {
+	header := w.ResponseWriter.Header()
+
+	if w.status == http.StatusOK {
+		etag := header.Get("ETag")
+		if etag == "" && len(w.body) > 0 {
+			etag = GenerateEtag(w.body, weak)
+			header.Set("ETag", etag)
+		}
+
+		notModified := false
+		if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+			notModified = etag != "" && etagMatches(ifNoneMatch, etag)
+		} else {
+			notModified = notModifiedSince(r.Header.Get("If-Modified-Since"), header.Get("Last-Modified"))
+		}
+
+		if notModified {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+			return
+		}
+	}
+
+	if len(w.body) == 0 {
+		if w.status != http.StatusOK {
+			w.ResponseWriter.WriteHeader(w.status)
+			w.ResponseWriter.WriteHeaderNow()
+		}
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body)
+}
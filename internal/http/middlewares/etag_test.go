@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+func TestEtagMiddleware_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(EtagMiddleware(nil))
+	router.GET("/data", func(c *gin.Context) {
+		c.String(http.StatusOK, "hello")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/data", nil))
+	etag := w.Header().Get("ETag")
+	if etag != GenerateEtag([]byte("hello"), false) {
+		t.Fatalf("ETag --> Expected: %v, but got %v", GenerateEtag([]byte("hello"), false), etag)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Conditional request --> Expected: %v with empty body, but got %v with %q", http.StatusNotModified, w.Code, w.Body.String())
+	}
+}
+
+func TestEtagMiddleware_WeakAndLastModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	router := gin.New()
+	router.Use(EtagMiddleware(&types.EtagMiddlewareConfig{Weak: true}))
+	router.GET("/data", func(c *gin.Context) {
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+		c.String(http.StatusOK, "hello")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("If-Modified-Since", lastModified.Add(time.Hour).Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since --> Expected: %v, but got %v", http.StatusNotModified, w.Code)
+	}
+	if w.Header().Get("ETag") != GenerateEtag([]byte("hello"), true) {
+		t.Errorf("Weak ETag --> Expected: %v, but got %v", GenerateEtag([]byte("hello"), true), w.Header().Get("ETag"))
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/data", nil)
+	r.Header.Set("If-None-Match", `"other"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "hello" {
+		t.Errorf("Changed resource --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestEtagMiddleware_MaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(EtagMiddleware(&types.EtagMiddlewareConfig{MaxBodySize: 10}))
+	router.GET("/data", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+		for _, chunk := range c.QueryArray("chunk") {
+			_, _ = c.Writer.WriteString(chunk)
+		}
+	})
+
+	testCases := []struct {
+		path string
+		body string
+		etag string
+	}{
+		{"/data?chunk=hello", "hello", GenerateEtag([]byte("hello"), false)},
+		{"/data?chunk=hello&chunk=world!", "helloworld!", ""},
+	}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != http.StatusOK || w.Body.String() != tc.body {
+			t.Errorf("Response of %v --> Expected: %v %q, but got %v %q", tc.path, http.StatusOK, tc.body, w.Code, w.Body.String())
+		}
+		if etag := w.Header().Get("ETag"); etag != tc.etag {
+			t.Errorf("ETag of %v --> Expected: %q, but got %q", tc.path, tc.etag, etag)
+		}
+	}
+}
@@ -1,14 +1,25 @@
 package middlewares
 
 import (
+	"os"
+
 	"github.com/Blocktunium/gonyx/internal/http/types"
 	"github.com/fufuok/favicon"
 	"github.com/gin-gonic/gin"
 )
 
-func FaviconMiddleware(config types.FaviconMiddlewareConfig) gin.HandlerFunc {
+// FaviconMiddleware creates a middleware that serves the favicon file from memory
+// If the file is empty, `/favicon.ico` is answered with 204 No Content
+func FaviconMiddleware(config types.FaviconMiddlewareConfig) (gin.HandlerFunc, error) {
+	// favicon.New panics if the file cannot be read
+	if config.File != "" {
+		if _, err := os.Stat(config.File); err != nil {
+			return nil, err
+		}
+	}
+
 	return favicon.New(favicon.Config{
 		File:         config.File,
 		CacheControl: config.CacheControl,
-	})
+	}), nil
 }
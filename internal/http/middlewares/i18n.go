@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// I18nMiddleware creates a middleware that negotiates the locale of the request
+// The locale is put into the request context and the gin keys, and it is answered in the Content-Language header
+// If config is nil, only the `Accept-Language` header is used
+func I18nMiddleware(config *types.I18nMiddlewareConfig) gin.HandlerFunc {
+	cfg := types.I18nMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+
+	return func(c *gin.Context) {
+		requested := ""
+		if cfg.QueryParam != "" {
+			requested = c.Query(cfg.QueryParam)
+		}
+		if requested == "" && cfg.Cookie != "" {
+			requested, _ = c.Cookie(cfg.Cookie)
+		}
+		if requested == "" {
+			requested = c.GetHeader("Accept-Language")
+		}
+
+		locale := i18n.GetManager().Negotiate(requested)
+		c.Set(i18n.LocaleKey, locale)
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
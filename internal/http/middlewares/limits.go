@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// LimitsMiddleware creates a middleware that rejects oversized requests
+// The body is limited with http.MaxBytesReader, so a body without Content-Length fails while it is read
+// If config is nil or a limit is zero, the limit is not checked
+func LimitsMiddleware(config *types.LimitsMiddlewareConfig) gin.HandlerFunc {
+	cfg := types.LimitsMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+
+	return func(c *gin.Context) {
+		if cfg.MaxURLLength > 0 && len(c.Request.RequestURI) > cfg.MaxURLLength {
+			abortWithLimit(c, http.StatusRequestURITooLong, "The request URI is too long")
+			return
+		}
+
+		if cfg.MaxHeaderSize > 0 {
+			size := 0
+			for name, values := range c.Request.Header {
+				for _, value := range values {
+					// name, ": ", value and CRLF
+					size += len(name) + len(value) + 4
+				}
+			}
+			if size > cfg.MaxHeaderSize {
+				abortWithLimit(c, http.StatusRequestHeaderFieldsTooLarge, "The request headers are too large")
+				return
+			}
+		}
+
+		if cfg.MaxBodySize > 0 && c.Request.Body != nil {
+			if c.Request.ContentLength > cfg.MaxBodySize {
+				abortWithLimit(c, http.StatusRequestEntityTooLarge, "The request body is too large")
+				return
+			}
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxBodySize)
+		}
+
+		c.Next()
+	}
+}
+
+// abortWithLimit - abort the request with the status of the exceeded limit
+func abortWithLimit(c *gin.Context, status int, description string) {
+	c.AbortWithStatusJSON(status, ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: description,
+	})
+}
@@ -1,9 +1,13 @@
 package middlewares
 
 import (
+	"fmt"
 	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
 	"github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"time"
 )
 
@@ -14,7 +18,16 @@ func ZapLogger() gin.HandlerFunc {
 	}
 	logger1 := logge.(*logger.ZapWrapper).Instance()
 
-	return ginzap.Ginzap(logger1, time.RFC3339, true)
+	return ginzap.GinzapWithConfig(logger1, &ginzap.Config{
+		TimeFormat: time.RFC3339,
+		UTC:        true,
+		Context: func(c *gin.Context) []zapcore.Field {
+			if tenantID := c.GetString(tenancy.TenantKey); tenantID != "" {
+				return []zapcore.Field{zap.String("tenant", tenantID)}
+			}
+			return nil
+		},
+	})
 }
 
 func ZapRecoveryLogger() gin.HandlerFunc {
@@ -34,7 +47,7 @@ func LogMeLogger() gin.HandlerFunc {
 	}
 	logger1 := logge.(*logger.LogMeWrapper).Instance()
 
-	return gin.LoggerWithWriter(logger1.Writer())
+	return gin.LoggerWithConfig(gin.LoggerConfig{Output: logger1.Writer(), Formatter: logMeFormatter})
 }
 
 func LogMeRecoveryLogger() gin.HandlerFunc {
@@ -46,3 +59,31 @@ func LogMeRecoveryLogger() gin.HandlerFunc {
 
 	return gin.RecoveryWithWriter(logger1.Writer())
 }
+
+// logMeFormatter - the default format of gin with the tenant of the request
+func logMeFormatter(param gin.LogFormatterParams) string {
+	var statusColor, methodColor, resetColor string
+	if param.IsOutputColor() {
+		statusColor = param.StatusCodeColor()
+		methodColor = param.MethodColor()
+		resetColor = param.ResetColor()
+	}
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+
+	tenant := ""
+	if tenantID, ok := param.Keys[tenancy.TenantKey].(string); ok && tenantID != "" {
+		tenant = " | tenant=" + tenantID
+	}
+	return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v%s\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		statusColor, param.StatusCode, resetColor,
+		param.Latency,
+		param.ClientIP,
+		methodColor, param.Method, resetColor,
+		param.Path,
+		tenant,
+		param.ErrorMessage,
+	)
+}
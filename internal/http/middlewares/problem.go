@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"encoding/json"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Public functions
+
+// ProblemMiddleware - answer the last error which the handlers add by `c.Error` with problem+json if nothing is written
+// The http status is of the code of the error, e.g. `db.SelectQueryErr` of a missing record is answered with 404
+func ProblemMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		AbortWithProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// AbortWithProblem - answer the error with problem+json and the http status of its code
+func AbortWithProblem(c *gin.Context, err error) {
+	problem := errs.NewProblem(err, c.Request.URL.Path)
+	data, _ := json.Marshal(problem)
+	c.Abort()
+	c.Data(problem.Status, errs.ProblemContentType, data)
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"github.com/gin-gonic/gin"
+)
+
+func TestProblemMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ProblemMiddleware())
+	router.GET("/users/:id", func(c *gin.Context) {
+		_ = c.Error(errs.Wrap(errs.NotFound, errors.New("no rows"), "the user is not found"))
+	})
+	router.GET("/written", func(c *gin.Context) {
+		_ = c.Error(errors.New("logged only"))
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	problem := errs.Problem{}
+	_ = json.Unmarshal(w.Body.Bytes(), &problem)
+	if w.Code != http.StatusNotFound || w.Header().Get("Content-Type") != errs.ProblemContentType {
+		t.Errorf("Problem response --> Expected: %v %v, but got %v %v", http.StatusNotFound, errs.ProblemContentType, w.Code, w.Header().Get("Content-Type"))
+	}
+	if problem.Code != "not_found" || problem.Detail != "the user is not found" || problem.Instance != "/users/42" {
+		t.Errorf("Problem --> Expected: %v %v %v, but got %+v", "not_found", "the user is not found", "/users/42", problem)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/written", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf("Written response --> Expected: %v %v, but got %v %v", http.StatusOK, "ok", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,447 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+)
+
+// MARK: Variables
+
+var (
+	ResponseCacheMaintenanceType = logTypes.NewLogType("HTTP_RESPONSE_CACHE")
+)
+
+// RouteNameResolver - return the name of the route that handles the request
+type RouteNameResolver func(c *gin.Context) string
+
+// cachedResponse - the stored representation of a response
+type cachedResponse struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt int64       `json:"stored_at"`
+}
+
+// capturedResponse - the result of the handlers shared by the concurrent requests
+type capturedResponse struct {
+	entry     *cachedResponse
+	key       string
+	vary      []string // the request headers of the `Vary` header of the response
+	ttl       time.Duration
+	cacheable bool
+}
+
+// responseCache - the state of a response cache instance
+type responseCache struct {
+	config    types.ResponseCacheMiddlewareConfig
+	ttl       time.Duration
+	routeName RouteNameResolver
+	group     singleflight.Group
+}
+
+// ResponseCacheMiddleware creates a middleware that caches GET responses in a cache connection
+// If config is nil, the in-process "memory" store with 60 seconds TTL is used
+func ResponseCacheMiddleware(config *types.ResponseCacheMiddlewareConfig, routeName RouteNameResolver) gin.HandlerFunc {
+	rc := newResponseCache(config, routeName)
+	return func(c *gin.Context) {
+		rc.serve(c, c.Next, true)
+	}
+}
+
+// ResponseCacheHandler - wrap a single route handler with the response cache
+func ResponseCacheHandler(config *types.ResponseCacheMiddlewareConfig, routeName string, f func(c *gin.Context)) func(c *gin.Context) {
+	rc := newResponseCache(config, func(c *gin.Context) string { return routeName })
+	return func(c *gin.Context) {
+		rc.serve(c, func() { f(c) }, false)
+	}
+}
+
+// newResponseCache - apply the defaults to the config
+func newResponseCache(config *types.ResponseCacheMiddlewareConfig, routeName RouteNameResolver) *responseCache {
+	cfg := types.ResponseCacheMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Store == "" {
+		cfg.Store = "memory"
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 60
+	}
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = "httpcache"
+	}
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = []int{http.StatusOK}
+	}
+	useResponseCacheStore(cfg.Store)
+
+	return &responseCache{
+		config:    cfg,
+		ttl:       time.Duration(cfg.TTL) * time.Second,
+		routeName: routeName,
+	}
+}
+
+// isPathIncluded - check the path against the paths and the excluded paths
+func (rc *responseCache) isPathIncluded(path string) bool {
+	for _, prefix := range rc.config.ExcludedPaths {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	if len(rc.config.Paths) == 0 {
+		return true
+	}
+	for _, prefix := range rc.config.Paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serve - answer from the cache or run the handlers once for all concurrent requests with the same key
+func (rc *responseCache) serve(c *gin.Context, next func(), abort bool) {
+	requestCacheControl := strings.ToLower(c.GetHeader("Cache-Control"))
+	if c.Request.Method != http.MethodGet || strings.Contains(requestCacheControl, "no-store") || !rc.isPathIncluded(c.Request.URL.Path) {
+		next()
+		return
+	}
+	// the responses of the credentials are of their user, they are not shared with the others
+	if !rc.config.CacheCredentials && (c.GetHeader("Authorization") != "" || c.GetHeader("Cookie") != "") {
+		next()
+		return
+	}
+
+	store, err := getResponseCacheStore(rc.config.Store)
+	if err != nil {
+		logResponseCacheError("middlewares.ResponseCache", "Cannot find the response cache store ...", err)
+		next()
+		return
+	}
+
+	ctx := c.Request.Context()
+	routeName := ""
+	if rc.routeName != nil {
+		routeName = rc.routeName(c)
+	}
+	baseKey := rc.generateKey(ctx, store, c.Request, routeName)
+	key := baseKey
+	// the response is stored by the headers of its `Vary` header, they are known after the first response
+	var vary string
+	if err := store.Get(ctx, responseCacheVaryKey(baseKey), &vary); err == nil && vary != "" {
+		key = variedKey(baseKey, strings.Split(vary, ","), c.Request)
+	}
+
+	// `no-cache` asks for a fresh response, it is stored again for the next requests
+	if !strings.Contains(requestCacheControl, "no-cache") {
+		var data []byte
+		if err := store.Get(ctx, key, &data); err == nil {
+			var entry cachedResponse
+			if err := json.Unmarshal(data, &entry); err == nil {
+				writeCachedResponse(c, &entry, "HIT")
+				if abort {
+					c.Abort()
+				}
+				return
+			}
+		}
+	}
+
+	leader := false
+	result, _, _ := rc.group.Do(key, func() (interface{}, error) {
+		leader = true
+		return rc.capture(c, store, baseKey, next), nil
+	})
+	if leader {
+		return
+	}
+
+	// the response of the leader is shared only if it is cacheable and of the same headers, otherwise the handlers run again
+	captured := result.(*capturedResponse)
+	if !captured.cacheable || captured.key != variedKey(baseKey, captured.vary, c.Request) {
+		next()
+		return
+	}
+	writeCachedResponse(c, captured.entry, "HIT")
+	if abort {
+		c.Abort()
+	}
+}
+
+// capture - run the handlers, write the response to the client and store it if it is cacheable
+// The response is stored by the base key and the request headers of its `Vary` header
+func (rc *responseCache) capture(c *gin.Context, store ResponseCacheStore, baseKey string, next func()) *capturedResponse {
+	writer := &responseCacheWriter{ResponseWriter: c.Writer, maxBodySize: rc.config.MaxBodySize}
+	writer.Header().Set("X-Cache", "MISS")
+	c.Writer = writer
+	next()
+	c.Writer = writer.ResponseWriter
+
+	result := &capturedResponse{}
+	ttl, cacheable := rc.isCacheable(writer)
+	if !cacheable {
+		return result
+	}
+
+	header := writer.Header().Clone()
+	header.Del("X-Cache")
+	result.vary = responseVary(header)
+	result.key = variedKey(baseKey, result.vary, c.Request)
+	result.entry = &cachedResponse{
+		Status:   writer.Status(),
+		Header:   header,
+		Body:     writer.body,
+		StoredAt: time.Now().Unix(),
+	}
+	result.ttl = ttl
+	result.cacheable = true
+
+	ctx := c.Request.Context()
+	data, err := json.Marshal(result.entry)
+	if err == nil {
+		err = store.Set(ctx, result.key, data, ttl)
+	}
+	if err == nil && len(result.vary) > 0 {
+		err = store.Set(ctx, responseCacheVaryKey(baseKey), strings.Join(result.vary, ","), ttl)
+	}
+	if err != nil {
+		logResponseCacheError("middlewares.ResponseCache", "Cannot store the response in the cache ...", err)
+	}
+	return result
+}
+
+// isCacheable - check the captured response and return its time to live
+func (rc *responseCache) isCacheable(w *responseCacheWriter) (time.Duration, bool) {
+	if w.uncacheable || w.overflow {
+		return 0, false
+	}
+
+	status := w.Status()
+	allowed := false
+	for _, item := range rc.config.StatusCodes {
+		if item == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return 0, false
+	}
+
+	header := w.Header()
+	if len(header.Values("Set-Cookie")) > 0 {
+		return 0, false
+	}
+	// `Vary: *` is a response of every request
+	for _, name := range responseVary(header) {
+		if name == "*" {
+			return 0, false
+		}
+	}
+
+	ttl := rc.ttl
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return 0, false
+	}
+	if _, ok := directives["private"]; ok {
+		return 0, false
+	}
+	if _, ok := directives["no-cache"]; ok {
+		return 0, false
+	}
+	if value, ok := directives["s-maxage"]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			ttl = time.Duration(seconds) * time.Second
+		}
+	} else if value, ok := directives["max-age"]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			ttl = time.Duration(seconds) * time.Second
+		}
+	}
+	return ttl, ttl > 0
+}
+
+// generateKey - build the cache key from the request and the current version of the tags
+// The key is scoped to the tenant of the request, e.g. "httpcache:acme:<hash>", so the tenants never share a response
+func (rc *responseCache) generateKey(ctx context.Context, store ResponseCacheStore, r *http.Request, routeName string) string {
+	hash := sha256.New()
+	// the virtual hosts of a server have their own responses for the same path
+	hash.Write([]byte(r.Method + "\n" + strings.ToLower(r.Host) + "\n" + r.URL.Path + "\n"))
+
+	query := r.URL.Query()
+	names := rc.config.VaryQuery
+	if len(names) == 0 {
+		names = make([]string, 0, len(query))
+		for name := range query {
+			names = append(names, name)
+		}
+	}
+	names = append([]string{}, names...)
+	sort.Strings(names)
+	for _, name := range names {
+		values := append([]string{}, query[name]...)
+		sort.Strings(values)
+		hash.Write([]byte("q:" + name + "=" + strings.Join(values, ",") + "\n"))
+	}
+
+	for _, name := range rc.config.VaryHeaders {
+		hash.Write([]byte("h:" + strings.ToLower(name) + "=" + strings.Join(r.Header.Values(name), ",") + "\n"))
+	}
+
+	tags := append([]string{}, rc.config.Tags...)
+	if routeName != "" {
+		tags = append(tags, responseCacheRouteTag(routeName))
+	}
+	for _, tag := range tags {
+		var version string
+		_ = store.Get(ctx, responseCacheTagKey(ctx, tag), &version)
+		hash.Write([]byte("t:" + tag + "=" + version + "\n"))
+	}
+
+	prefix := rc.config.KeyPrefix + ":"
+	if tenantPrefix := tenancy.KeyPrefix(ctx); tenantPrefix != "" {
+		prefix += tenantPrefix + ":"
+	}
+	return prefix + hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseVary - return the sorted names of the request headers in the `Vary` header of the response
+func responseVary(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" && !utils.ArrayContains(&names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// responseCacheVaryKey - return the key of the `Vary` header of the responses of the base key
+func responseCacheVaryKey(baseKey string) string {
+	return baseKey + ":vary"
+}
+
+// variedKey - return the key of the response by the values of the request headers, it is the base key if there is no header
+func variedKey(baseKey string, names []string, r *http.Request) string {
+	if len(names) == 0 {
+		return baseKey
+	}
+	hash := sha256.New()
+	for _, name := range names {
+		hash.Write([]byte(name + "=" + strings.Join(r.Header.Values(name), ",") + "\n"))
+	}
+	return baseKey + ":" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// parseCacheControl - parse the directives of the `Cache-Control` header
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), "\"")
+	}
+	return directives
+}
+
+// writeCachedResponse - write the stored response to the client
+func writeCachedResponse(c *gin.Context, entry *cachedResponse, state string) {
+	header := c.Writer.Header()
+	for name, values := range entry.Header {
+		header[name] = append([]string{}, values...)
+	}
+	header.Set("X-Cache", state)
+	if age := time.Now().Unix() - entry.StoredAt; age >= 0 {
+		header.Set("Age", strconv.FormatInt(age, 10))
+	}
+
+	c.Writer.WriteHeader(entry.Status)
+	if len(entry.Body) == 0 {
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	_, _ = c.Writer.Write(entry.Body)
+}
+
+// logResponseCacheError - log the error of the response cache
+func logResponseCacheError(section string, message string, err error) {
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(logTypes.NewLogObject(
+			logTypes.ERROR, section, ResponseCacheMaintenanceType,
+			time.Now(), message, err,
+		))
+	}
+}
+
+// MARK: responseCacheWriter
+
+// responseCacheWriter - write the response through and keep a copy of the body
+type responseCacheWriter struct {
+	gin.ResponseWriter
+	body        []byte
+	maxBodySize int
+	overflow    bool
+	uncacheable bool
+}
+
+// Write - write the data and keep a copy
+func (w *responseCacheWriter) Write(data []byte) (int, error) {
+	if !w.overflow {
+		if w.maxBodySize > 0 && len(w.body)+len(data) > w.maxBodySize {
+			w.overflow = true
+			w.body = nil
+		} else {
+			w.body = append(w.body, data...)
+		}
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString - write the string data and keep a copy
+func (w *responseCacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush - a flushed response is a stream and it is not cached
+func (w *responseCacheWriter) Flush() {
+	w.uncacheable = true
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap - return the underlying writer, used by http.ResponseController
+func (w *responseCacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack - the connection is taken over and it is not cached
+func (w *responseCacheWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.uncacheable = true
+	return w.ResponseWriter.Hijack()
+}
@@ -0,0 +1,247 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/cache"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+)
+
+// ResponseCacheStore - storage used by the response cache, satisfied by `cache.ICache` and rediskit clients
+type ResponseCacheStore interface {
+	Get(ctx context.Context, key string, val any) error
+	Set(ctx context.Context, key string, val any, expiration time.Duration) error
+}
+
+var (
+	responseCacheStoresLock sync.RWMutex
+	responseCacheStores     = make(map[string]ResponseCacheStore)
+	responseCacheStoreNames = make(map[string]struct{})
+)
+
+// RegisterResponseCacheStore - register a store to be referenced by name in the response cache config
+func RegisterResponseCacheStore(name string, store ResponseCacheStore) {
+	responseCacheStoresLock.Lock()
+	defer responseCacheStoresLock.Unlock()
+
+	responseCacheStores[name] = store
+	responseCacheStoreNames[name] = struct{}{}
+}
+
+// useResponseCacheStore - remember the store name so invalidation reaches it
+func useResponseCacheStore(name string) {
+	responseCacheStoresLock.Lock()
+	defer responseCacheStoresLock.Unlock()
+
+	responseCacheStoreNames[name] = struct{}{}
+}
+
+// getResponseCacheStore - return the registered store, "memory" or a connection of the cache manager
+func getResponseCacheStore(name string) (ResponseCacheStore, error) {
+	responseCacheStoresLock.RLock()
+	store, ok := responseCacheStores[name]
+	responseCacheStoresLock.RUnlock()
+	if ok {
+		return store, nil
+	}
+
+	if name == "memory" {
+		store = NewMemoryResponseCacheStore()
+	} else {
+		instance, err := cache.GetManager().GetCache(name)
+		if err != nil {
+			return nil, err
+		}
+		store = instance
+	}
+
+	responseCacheStoresLock.Lock()
+	defer responseCacheStoresLock.Unlock()
+	if existing, ok := responseCacheStores[name]; ok {
+		return existing, nil
+	}
+	responseCacheStores[name] = store
+	responseCacheStoreNames[name] = struct{}{}
+	return store, nil
+}
+
+// MARK: Invalidation
+
+// responseCacheTagKey - return the key of the tag version, it is scoped to the tenant of the context, e.g. "httpcache:tag:acme$items"
+func responseCacheTagKey(ctx context.Context, tag string) string {
+	if tenantPrefix := tenancy.KeyPrefix(ctx); tenantPrefix != "" {
+		return "httpcache:tag:" + tenantPrefix + "$" + tag
+	}
+	return "httpcache:tag:" + tag
+}
+
+// responseCacheRouteTag - return the tag of all responses of the route
+func responseCacheRouteTag(routeName string) string {
+	return "route:" + routeName
+}
+
+// InvalidateResponseCacheTags - invalidate all cached responses that carry one of the tags in every known store
+// Only the responses of the tenant of the context are invalidated, or the responses without a tenant if it has none
+func InvalidateResponseCacheTags(ctx context.Context, tags ...string) error {
+	responseCacheStoresLock.RLock()
+	names := make([]string, 0, len(responseCacheStoreNames))
+	for name := range responseCacheStoreNames {
+		names = append(names, name)
+	}
+	responseCacheStoresLock.RUnlock()
+
+	var result error
+	for _, name := range names {
+		store, err := getResponseCacheStore(name)
+		if err != nil {
+			result = errors.Join(result, err)
+			continue
+		}
+
+		for _, tag := range tags {
+			// a new version changes the key of every response with the tag, old entries expire by their TTL
+			if err := store.Set(ctx, responseCacheTagKey(ctx, tag), newResponseCacheVersion(), 0); err != nil {
+				result = errors.Join(result, err)
+			}
+		}
+	}
+	return result
+}
+
+// InvalidateResponseCacheRoutes - invalidate all cached responses of the routes
+func InvalidateResponseCacheRoutes(ctx context.Context, routeNames ...string) error {
+	tags := make([]string, 0, len(routeNames))
+	for _, item := range routeNames {
+		tags = append(tags, responseCacheRouteTag(item))
+	}
+	return InvalidateResponseCacheTags(ctx, tags...)
+}
+
+// newResponseCacheVersion - generate a random version token
+func newResponseCacheVersion() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MARK: MemoryResponseCacheStore
+
+// DefaultMemoryResponseCacheEntries - the maximum number of the entries of NewMemoryResponseCacheStore
+const DefaultMemoryResponseCacheEntries = 10000
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryResponseCacheStore - an in-process store, suitable for development and single instance deployments
+// The expired entries are evicted when the store is full, then the entry which expires first
+type MemoryResponseCacheStore struct {
+	lock       sync.RWMutex
+	entries    map[string]memoryCacheEntry
+	maxEntries int
+}
+
+// NewMemoryResponseCacheStore - create a new in-process store of DefaultMemoryResponseCacheEntries entries
+func NewMemoryResponseCacheStore() *MemoryResponseCacheStore {
+	return NewMemoryResponseCacheStoreWithLimit(DefaultMemoryResponseCacheEntries)
+}
+
+// NewMemoryResponseCacheStoreWithLimit - create a new in-process store of maxEntries entries, zero or less uses the default
+func NewMemoryResponseCacheStoreWithLimit(maxEntries int) *MemoryResponseCacheStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryResponseCacheEntries
+	}
+	return &MemoryResponseCacheStore{entries: make(map[string]memoryCacheEntry), maxEntries: maxEntries}
+}
+
+// Get - read the value into val which must be *[]byte, *string or a json target
+func (m *MemoryResponseCacheStore) Get(ctx context.Context, key string, val any) error {
+	m.lock.RLock()
+	entry, ok := m.entries[key]
+	m.lock.RUnlock()
+
+	if ok && entry.isExpired(time.Now()) {
+		m.lock.Lock()
+		if current, exists := m.entries[key]; exists && current.isExpired(time.Now()) {
+			delete(m.entries, key)
+		}
+		m.lock.Unlock()
+		ok = false
+	}
+	if !ok {
+		return fmt.Errorf("key `%v` does not exist", key)
+	}
+
+	switch v := val.(type) {
+	case *[]byte:
+		*v = append([]byte(nil), entry.value...)
+	case *string:
+		*v = string(entry.value)
+	default:
+		return json.Unmarshal(entry.value, val)
+	}
+	return nil
+}
+
+// Set - store the value, zero expiration means no expiry
+func (m *MemoryResponseCacheStore) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	var value []byte
+	switch v := val.(type) {
+	case []byte:
+		value = append([]byte(nil), v...)
+	case string:
+		value = []byte(v)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+
+	entry := memoryCacheEntry{value: value}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.entries[key]; !exists && len(m.entries) >= m.maxEntries {
+		m.evict()
+	}
+	m.entries[key] = entry
+	return nil
+}
+
+// evict - remove the expired entries, or the entry which expires first if none is expired
+// The entries without expiration, e.g. the versions of the tags, are removed only if all the entries have no expiration
+func (m *MemoryResponseCacheStore) evict() {
+	now := time.Now()
+	var victim string
+	var victimExpiresAt time.Time
+	for key, entry := range m.entries {
+		if entry.isExpired(now) {
+			delete(m.entries, key)
+			continue
+		}
+		if victim == "" || (!entry.expiresAt.IsZero() && (victimExpiresAt.IsZero() || entry.expiresAt.Before(victimExpiresAt))) {
+			victim, victimExpiresAt = key, entry.expiresAt
+		}
+	}
+	if len(m.entries) >= m.maxEntries {
+		delete(m.entries, victim)
+	}
+}
+
+// isExpired - check whether the entry is expired at the time
+func (e memoryCacheEntry) isExpired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
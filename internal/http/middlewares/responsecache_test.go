@@ -0,0 +1,208 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"github.com/gin-gonic/gin"
+)
+
+func newResponseCacheRouter(store string, calls *int32, cacheControl string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	RegisterResponseCacheStore(store, NewMemoryResponseCacheStore())
+
+	router := gin.New()
+	router.Use(ResponseCacheMiddleware(&types.ResponseCacheMiddlewareConfig{Store: store, TTL: 60}, func(c *gin.Context) string {
+		return "items"
+	}))
+	router.GET("/items", func(c *gin.Context) {
+		atomic.AddInt32(calls, 1)
+		if cacheControl != "" {
+			c.Header("Cache-Control", cacheControl)
+		}
+		c.String(http.StatusOK, "items")
+	})
+	return router
+}
+
+func TestResponseCacheMiddleware_HitAndInvalidate(t *testing.T) {
+	var calls int32
+	router := newResponseCacheRouter("test-hit", &calls, "")
+
+	for i, expected := range []string{"MISS", "HIT"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?page=1", nil))
+		if w.Header().Get("X-Cache") != expected || w.Body.String() != "items" {
+			t.Errorf("Request %d --> Expected: %v with body %q, but got %v with %q", i, expected, "items", w.Header().Get("X-Cache"), w.Body.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Handler calls --> Expected: %v, but got %v", 1, calls)
+	}
+
+	if err := InvalidateResponseCacheRoutes(context.Background(), "items"); err != nil {
+		t.Fatalf("Invalidate route --> Expected: %v, but got %v", nil, err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?page=1", nil))
+	if w.Header().Get("X-Cache") != "MISS" || calls != 2 {
+		t.Errorf("After invalidation --> Expected: %v and %v calls, but got %v and %v calls", "MISS", 2, w.Header().Get("X-Cache"), calls)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?page=2", nil))
+	if w.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("Different query --> Expected: %v, but got %v", "MISS", w.Header().Get("X-Cache"))
+	}
+}
+
+func TestResponseCacheMiddleware_CacheControl(t *testing.T) {
+	var calls int32
+	router := newResponseCacheRouter("test-no-store", &calls, "private, max-age=60")
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+	}
+	if calls != 2 {
+		t.Errorf("Private response --> Expected: %v calls, but got %v", 2, calls)
+	}
+
+	calls = 0
+	router = newResponseCacheRouter("test-bypass", &calls, "")
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/items", nil)
+		r.Header.Set("Cache-Control", "no-store")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if calls != 2 {
+		t.Errorf("Request no-store --> Expected: %v calls, but got %v", 2, calls)
+	}
+}
+
+func TestResponseCacheMiddleware_CredentialsAndVary(t *testing.T) {
+	var calls int32
+	router := newResponseCacheRouter("test-credentials", &calls, "")
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/items", nil)
+		r.Header.Set("Authorization", "Bearer token")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if calls != 2 {
+		t.Errorf("Request with credentials --> Expected: %v calls, but got %v", 2, calls)
+	}
+
+	gin.SetMode(gin.TestMode)
+	RegisterResponseCacheStore("test-vary", NewMemoryResponseCacheStore())
+	router = gin.New()
+	router.Use(ResponseCacheMiddleware(&types.ResponseCacheMiddlewareConfig{Store: "test-vary", TTL: 60}, nil))
+	router.GET("/items", func(c *gin.Context) {
+		c.Header("Vary", "Accept-Language")
+		c.String(http.StatusOK, c.GetHeader("Accept-Language"))
+	})
+
+	// the responses of the other languages are stored by their own keys
+	for i, item := range []struct{ language, state string }{{"en", "MISS"}, {"fa", "MISS"}, {"en", "HIT"}, {"fa", "HIT"}} {
+		r := httptest.NewRequest(http.MethodGet, "/items", nil)
+		r.Header.Set("Accept-Language", item.language)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Header().Get("X-Cache") != item.state || w.Body.String() != item.language {
+			t.Errorf("Request %d --> Expected: %v with body %q, but got %v with %q", i, item.state, item.language, w.Header().Get("X-Cache"), w.Body.String())
+		}
+	}
+}
+
+func TestResponseCacheMiddleware_Hosts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	RegisterResponseCacheStore("test-hosts", NewMemoryResponseCacheStore())
+	router := gin.New()
+	router.Use(ResponseCacheMiddleware(&types.ResponseCacheMiddlewareConfig{Store: "test-hosts", TTL: 60}, nil))
+	router.GET("/items", func(c *gin.Context) {
+		c.String(http.StatusOK, c.Request.Host)
+	})
+
+	// the responses of the hosts are stored by their own keys
+	for i, item := range []struct{ host, state string }{{"a.example.com", "MISS"}, {"b.example.com", "MISS"}, {"a.example.com", "HIT"}, {"b.example.com", "HIT"}} {
+		r := httptest.NewRequest(http.MethodGet, "/items", nil)
+		r.Host = item.host
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Header().Get("X-Cache") != item.state || w.Body.String() != item.host {
+			t.Errorf("Request %d --> Expected: %v with body %q, but got %v with %q", i, item.state, item.host, w.Header().Get("X-Cache"), w.Body.String())
+		}
+	}
+}
+
+func TestResponseCacheMiddleware_Tenants(t *testing.T) {
+	makeReadyConfigManager()
+	tenancy.GetManager().RegisterTenant(tenancy.Tenant{ID: "tenant-a"})
+	tenancy.GetManager().RegisterTenant(tenancy.Tenant{ID: "tenant-b"})
+
+	tenancyHandler, _ := TenancyMiddleware(nil)
+	gin.SetMode(gin.TestMode)
+	RegisterResponseCacheStore("test-tenants", NewMemoryResponseCacheStore())
+	router := gin.New()
+	router.Use(tenancyHandler, ResponseCacheMiddleware(&types.ResponseCacheMiddlewareConfig{Store: "test-tenants", TTL: 60}, func(c *gin.Context) string {
+		return "items"
+	}))
+	router.GET("/items", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(tenancy.TenantKey))
+	})
+
+	request := func(tenantID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/items", nil)
+		r.Header.Set("X-Tenant-ID", tenantID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	// the responses of the tenants are stored by their own keys
+	for i, item := range []struct{ tenant, state string }{{"tenant-a", "MISS"}, {"tenant-b", "MISS"}, {"tenant-a", "HIT"}, {"tenant-b", "HIT"}} {
+		w := request(item.tenant)
+		if w.Header().Get("X-Cache") != item.state || w.Body.String() != item.tenant {
+			t.Errorf("Request %d --> Expected: %v with body %q, but got %v with %q", i, item.state, item.tenant, w.Header().Get("X-Cache"), w.Body.String())
+		}
+	}
+
+	// the invalidation of a tenant keeps the responses of the other tenants
+	if err := InvalidateResponseCacheRoutes(tenancy.WithTenant(context.Background(), "tenant-a"), "items"); err != nil {
+		t.Fatalf("Invalidate route --> Expected: %v, but got %v", nil, err)
+	}
+	if state := request("tenant-a").Header().Get("X-Cache"); state != "MISS" {
+		t.Errorf("Invalidated tenant --> Expected: %v, but got %v", "MISS", state)
+	}
+	if state := request("tenant-b").Header().Get("X-Cache"); state != "HIT" {
+		t.Errorf("Other tenant --> Expected: %v, but got %v", "HIT", state)
+	}
+}
+
+func TestMemoryResponseCacheStore_Evict(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryResponseCacheStoreWithLimit(3)
+	_ = store.Set(ctx, "tag", "version", 0)
+	_ = store.Set(ctx, "expired", "value", time.Nanosecond)
+	_ = store.Set(ctx, "later", "value", time.Hour)
+	time.Sleep(time.Millisecond)
+
+	// the expired entry is evicted, then the one which expires first
+	_ = store.Set(ctx, "first", "value", time.Minute)
+	_ = store.Set(ctx, "second", "value", time.Hour)
+	if len(store.entries) != 3 {
+		t.Errorf("Number of entries --> Expected: %v, but got %v", 3, len(store.entries))
+	}
+	for key, expected := range map[string]bool{"tag": true, "expired": false, "later": true, "first": false, "second": true} {
+		var value string
+		if found := store.Get(ctx, key, &value) == nil; found != expected {
+			t.Errorf("Entry %v --> Expected: %v, but got %v", key, expected, found)
+		}
+	}
+}
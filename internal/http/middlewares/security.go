@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// securityHeader - a header and its value, empty value means the header is not sent
+type securityHeader struct {
+	name  string
+	value string
+}
+
+// headerValue - return the configured value, the default if it is empty and nothing if it is "-"
+func headerValue(value string, defaultValue string) string {
+	if value == "-" {
+		return ""
+	}
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// SecurityHeadersMiddleware creates a middleware that sets the security headers of the responses
+// If config is nil, HSTS (on TLS requests), X-Frame-Options, Referrer-Policy, X-Content-Type-Options
+// and Cross-Origin-Opener-Policy are set with their defaults
+func SecurityHeadersMiddleware(config *types.SecurityHeadersMiddlewareConfig) gin.HandlerFunc {
+	cfg := types.SecurityHeadersMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+
+	cspHeader := "Content-Security-Policy"
+	if cfg.CSPReportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+
+	var headers []securityHeader
+	for _, item := range []securityHeader{
+		{"X-Frame-Options", headerValue(cfg.FrameOptions, "DENY")},
+		{"Referrer-Policy", headerValue(cfg.ReferrerPolicy, "strict-origin-when-cross-origin")},
+		{"X-Content-Type-Options", headerValue(cfg.ContentTypeOptions, "nosniff")},
+		{"Cross-Origin-Opener-Policy", headerValue(cfg.CrossOriginOpenerPolicy, "same-origin")},
+		{cspHeader, headerValue(cfg.ContentSecurityPolicy, "")},
+		{"Permissions-Policy", headerValue(cfg.PermissionsPolicy, "")},
+	} {
+		if item.value != "" {
+			headers = append(headers, item)
+		}
+	}
+
+	hsts := ""
+	if cfg.HSTSMaxAge >= 0 {
+		maxAge := cfg.HSTSMaxAge
+		if maxAge == 0 {
+			maxAge = 31536000
+		}
+		hsts = "max-age=" + strconv.Itoa(maxAge)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		for _, item := range headers {
+			header.Set(item.name, item.value)
+		}
+
+		// browsers ignore HSTS on plain http, so it is sent only on https requests (directly or behind a proxy)
+		if hsts != "" && (c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		c.Next()
+	}
+}
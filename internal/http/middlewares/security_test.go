@@ -0,0 +1,165 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SecurityHeadersMiddleware(&types.SecurityHeadersMiddlewareConfig{
+		ContentSecurityPolicy: "default-src 'self'",
+		FrameOptions:          "-",
+	}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.TLS = &tls.ConnectionState{}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	expected := map[string]string{
+		"Strict-Transport-Security": "max-age=31536000",
+		"Content-Security-Policy":   "default-src 'self'",
+		"X-Frame-Options":           "",
+		"X-Content-Type-Options":    "nosniff",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+	}
+	for name, value := range expected {
+		if w.Header().Get(name) != value {
+			t.Errorf("Header %v --> Expected: %q, but got %q", name, value, w.Header().Get(name))
+		}
+	}
+}
+
+func TestCsrfMiddleware_DoubleSubmit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CsrfMiddleware(nil))
+	router.GET("/form", func(c *gin.Context) { c.String(http.StatusOK, CsrfToken(c)) })
+	router.POST("/form", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/form", nil))
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != w.Body.String() {
+		t.Fatalf("Token cookie --> Expected the cookie to match the token %q, but got %v", w.Body.String(), cookies)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/form", nil)
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Post without token --> Expected: %v, but got %v", http.StatusForbidden, w.Code)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("_csrf="+cookies[0].Value))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("Post with form token --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TimeoutMiddleware(&types.TimeoutMiddlewareConfig{
+		Timeout: 1000,
+		Routes:  map[string]int{"GET /slow": 20},
+	}))
+
+	cancelled := make(chan bool, 1)
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			cancelled <- true
+		case <-time.After(2 * time.Second):
+			cancelled <- false
+		}
+		c.String(http.StatusOK, "late")
+	})
+	router.GET("/fast", func(c *gin.Context) {
+		c.Header("X-Handler", "fast")
+		c.String(http.StatusCreated, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusServiceUnavailable || strings.Contains(w.Body.String(), "late") {
+		t.Errorf("Slow route --> Expected: %v without the late body, but got %v with %q", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+	if !<-cancelled {
+		t.Errorf("Handler context --> Expected to be cancelled")
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusCreated || w.Body.String() != "ok" || w.Header().Get("X-Handler") != "fast" {
+		t.Errorf("Fast route --> Expected: %v with %q, but got %v with %q", http.StatusCreated, "ok", w.Code, w.Body.String())
+	}
+}
+
+func TestTimeoutHandler_ParentContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", TimeoutHandler(time.Second, func(c *gin.Context) {
+		if _, ok := c.Request.Context().Deadline(); !ok {
+			t.Errorf("Handler context --> Expected a deadline")
+		}
+		c.Status(http.StatusNoContent)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Status --> Expected: %v, but got %v", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestLimitsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(LimitsMiddleware(&types.LimitsMiddlewareConfig{MaxBodySize: 4, MaxHeaderSize: 64}))
+	router.POST("/", func(c *gin.Context) {
+		if _, err := c.GetRawData(); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large")))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Large body --> Expected: %v, but got %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("ok"))
+	r.Header.Set("X-Large", strings.Repeat("a", 100))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Errorf("Large headers --> Expected: %v, but got %v", http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("ok")))
+	if w.Code != http.StatusOK {
+		t.Errorf("Small request --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
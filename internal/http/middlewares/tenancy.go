@@ -0,0 +1,144 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	HostTenancyStrategy   = "host"
+	HeaderTenancyStrategy = "header"
+	PathTenancyStrategy   = "path"
+	JWTTenancyStrategy    = "jwt"
+
+	defaultTenantHeader   = "X-Tenant-ID"
+	defaultTenantJWTClaim = "tenant"
+)
+
+// TenancyMiddleware creates a middleware that resolves the tenant of the request by the strategies of the config
+// The tenant id is put into the request context and the gin keys, so the database, the cache and the logs are scoped to it
+// If config is nil, the tenant is taken from the `X-Tenant-ID` header
+func TenancyMiddleware(config *types.TenancyMiddlewareConfig) (gin.HandlerFunc, error) {
+	cfg := types.TenancyMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if len(cfg.Strategies) == 0 {
+		cfg.Strategies = []string{HeaderTenancyStrategy}
+	}
+	if cfg.Header == "" {
+		cfg.Header = defaultTenantHeader
+	}
+	if cfg.JWTClaim == "" {
+		cfg.JWTClaim = defaultTenantJWTClaim
+	}
+	cfg.BaseDomain = strings.ToLower(strings.Trim(cfg.BaseDomain, "."))
+
+	for _, strategy := range cfg.Strategies {
+		switch strategy {
+		case HostTenancyStrategy, HeaderTenancyStrategy, PathTenancyStrategy:
+		case JWTTenancyStrategy:
+			if cfg.JWTSecret == "" {
+				return nil, errors.New("the jwt strategy needs the jwt_secret")
+			}
+		default:
+			return nil, fmt.Errorf("the tenancy strategy `%v` is not supported", strategy)
+		}
+	}
+
+	return func(c *gin.Context) {
+		tenantID := ""
+		for _, strategy := range cfg.Strategies {
+			if tenantID = resolveTenant(c, &cfg, strategy); tenantID != "" {
+				break
+			}
+		}
+
+		if tenantID == "" {
+			if cfg.Required {
+				abortTenancy(c, http.StatusBadRequest, "The tenant of the request is not specified")
+				return
+			}
+			c.Next()
+			return
+		}
+		if !cfg.AllowUnknown {
+			if _, err := tenancy.GetManager().GetTenant(tenantID); err != nil {
+				abortTenancy(c, http.StatusNotFound, err.Error())
+				return
+			}
+		}
+
+		c.Set(tenancy.TenantKey, tenantID)
+		c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), tenantID))
+		c.Next()
+	}, nil
+}
+
+// MARK: Private functions
+
+// resolveTenant - return the tenant id of the request by the strategy, or an empty string if it is not found
+func resolveTenant(c *gin.Context, cfg *types.TenancyMiddlewareConfig, strategy string) string {
+	switch strategy {
+	case HostTenancyStrategy:
+		if tenant, ok := tenancy.GetManager().GetTenantByHost(c.Request.Host); ok {
+			return tenant.ID
+		}
+		if cfg.BaseDomain != "" {
+			host := strings.ToLower(c.Request.Host)
+			if i := strings.LastIndex(host, ":"); i > strings.LastIndex(host, "]") {
+				host = host[:i]
+			}
+			if subdomain, ok := strings.CutSuffix(host, "."+cfg.BaseDomain); ok && subdomain != "" && !strings.Contains(subdomain, ".") {
+				return subdomain
+			}
+		}
+	case HeaderTenancyStrategy:
+		return strings.TrimSpace(c.GetHeader(cfg.Header))
+	case PathTenancyStrategy:
+		segments := strings.Split(strings.Trim(c.Request.URL.Path, "/"), "/")
+		if cfg.PathSegment >= 0 && cfg.PathSegment < len(segments) {
+			return segments[cfg.PathSegment]
+		}
+	case JWTTenancyStrategy:
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if ok {
+			return jwtClaim(strings.TrimSpace(token), cfg.JWTSecret, cfg.JWTClaim)
+		}
+	}
+	return ""
+}
+
+// jwtClaim - verify the token and return the claim, or an empty string if it is not valid
+func jwtClaim(token string, secret string, claim string) string {
+	claims, err := utils.VerifyJWT(token, []byte(secret))
+	if err != nil {
+		return ""
+	}
+	switch value := claims[claim].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	}
+	return ""
+}
+
+// abortTenancy - answer the request whose tenant is not resolved
+func abortTenancy(c *gin.Context, status int, description string) {
+	c.AbortWithStatusJSON(status, ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: description,
+	})
+}
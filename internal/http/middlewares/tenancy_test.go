@@ -0,0 +1,113 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"github.com/gin-gonic/gin"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../../..", "test", "Gonyx")
+}
+
+// signJWT - return a HS256 token of the payload
+func signJWT(secret string, payload string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	unsigned := encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(payload))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + encode(mac.Sum(nil))
+}
+
+func TestTenancyMiddleware_Strategies(t *testing.T) {
+	makeReadyConfigManager()
+	tenancy.GetManager().RegisterTenant(tenancy.Tenant{ID: "acme", Hosts: []string{"shop.acme.io"}})
+	tenancy.GetManager().RegisterTenant(tenancy.Tenant{ID: "globex"})
+
+	handler, err := TenancyMiddleware(&types.TenancyMiddlewareConfig{
+		Strategies:  []string{"host", "header", "path", "jwt"},
+		BaseDomain:  "example.com",
+		PathSegment: 1,
+		JWTSecret:   "secret",
+		Required:    true,
+	})
+	if err != nil {
+		t.Fatalf("Creating middleware --> Expected: %v, but got %v", nil, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(handler)
+	engine.GET("/*path", func(c *gin.Context) {
+		tenantID, _ := tenancy.FromContext(c.Request.Context())
+		c.String(http.StatusOK, c.GetString(tenancy.TenantKey)+"|"+tenantID)
+	})
+
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	tests := []struct {
+		name     string
+		host     string
+		path     string
+		headers  map[string]string
+		status   int
+		expected string
+	}{
+		{"host", "shop.acme.io:8080", "/", nil, http.StatusOK, "acme|acme"},
+		{"subdomain", "globex.example.com", "/", nil, http.StatusOK, "globex|globex"},
+		{"header", "localhost", "/", map[string]string{"X-Tenant-ID": "globex"}, http.StatusOK, "globex|globex"},
+		{"path", "localhost", "/t/acme/users", nil, http.StatusOK, "acme|acme"},
+		{"jwt", "localhost", "/", map[string]string{"Authorization": "Bearer " + signJWT("secret", `{"tenant":"globex"}`)}, http.StatusOK, "globex|globex"},
+		{"jwt wrong secret", "localhost", "/", map[string]string{"Authorization": "Bearer " + signJWT("other", `{"tenant":"globex"}`)}, http.StatusBadRequest, ""},
+		{"jwt expired", "localhost", "/", map[string]string{"Authorization": "Bearer " + signJWT("secret", `{"tenant":"globex","exp":`+expired+`}`)}, http.StatusBadRequest, ""},
+		{"unknown", "localhost", "/", map[string]string{"X-Tenant-ID": "initech"}, http.StatusNotFound, ""},
+		{"missing", "localhost", "/", nil, http.StatusBadRequest, ""},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, test.path, nil)
+		r.Host = test.host
+		for name, value := range test.headers {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+
+		if w.Code != test.status || (test.expected != "" && w.Body.String() != test.expected) {
+			t.Errorf("Strategy %v --> Expected: %v %v, but got %v %v", test.name, test.status, test.expected, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestTenancyMiddleware_Config(t *testing.T) {
+	if _, err := TenancyMiddleware(&types.TenancyMiddlewareConfig{Strategies: []string{"cookie"}}); err == nil {
+		t.Errorf("Unknown strategy --> Expected an error, but got %v", err)
+	}
+	if _, err := TenancyMiddleware(&types.TenancyMiddlewareConfig{Strategies: []string{"jwt"}}); err == nil {
+		t.Errorf("Jwt without secret --> Expected an error, but got %v", err)
+	}
+
+	// the tenant is optional by default
+	handler, err := TenancyMiddleware(nil)
+	if err != nil {
+		t.Fatalf("Creating middleware --> Expected: %v, but got %v", nil, err)
+	}
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(handler)
+	engine.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Optional tenant --> Expected: %v, but got %v", http.StatusNoContent, w.Code)
+	}
+}
@@ -0,0 +1,280 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware creates a middleware that cancels the request context after the timeout and answers 503
+// The timeout is looked up by "<METHOD> <route path>", then by "<route path>" and then the default is used
+// If config is nil, the middleware does nothing
+func TimeoutMiddleware(config *types.TimeoutMiddlewareConfig) gin.HandlerFunc {
+	cfg := types.TimeoutMiddlewareConfig{}
+	if config != nil {
+		cfg = *config
+	}
+
+	return func(c *gin.Context) {
+		for _, prefix := range cfg.ExcludedPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		timeout := cfg.Timeout
+		if value, ok := cfg.Routes[c.Request.Method+" "+c.FullPath()]; ok {
+			timeout = value
+		} else if value, ok := cfg.Routes[c.FullPath()]; ok {
+			timeout = value
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		serveWithTimeout(c, time.Duration(timeout)*time.Millisecond, c.Next)
+	}
+}
+
+// TimeoutHandler - wrap a single route handler with the timeout
+func TimeoutHandler(timeout time.Duration, f func(c *gin.Context)) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		serveWithTimeout(c, timeout, func() { f(c) })
+	}
+}
+
+// serveWithTimeout - run the handlers with a deadline, the response is buffered to be replaced by 503 on timeout
+// The middleware waits for the handlers to return, so they must respect the cancellation of the request context
+func serveWithTimeout(c *gin.Context, timeout time.Duration, next func()) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+
+	writer := &timeoutWriter{
+		ResponseWriter: c.Writer,
+		header:         c.Writer.Header().Clone(),
+		status:         http.StatusOK,
+	}
+	c.Writer = writer
+
+	done := make(chan struct{})
+	var recovered interface{}
+	go func() {
+		defer func() {
+			recovered = recover()
+			close(done)
+		}()
+		next()
+	}()
+
+	select {
+	case <-done:
+		writer.finish()
+	case <-ctx.Done():
+		writer.timeout()
+		<-done
+		c.Abort()
+	}
+	c.Writer = writer.ResponseWriter
+
+	if recovered != nil {
+		panic(recovered)
+	}
+}
+
+// MARK: timeoutWriter
+
+// timeoutWriter - buffer the response until the handlers return or the deadline is reached
+type timeoutWriter struct {
+	gin.ResponseWriter
+	lock      sync.Mutex
+	header    http.Header
+	status    int
+	body      []byte
+	written   bool
+	streaming bool
+	timedOut  bool
+}
+
+// Header - return the buffered headers
+func (w *timeoutWriter) Header() http.Header {
+	if w.streaming {
+		return w.ResponseWriter.Header()
+	}
+	return w.header
+}
+
+// WriteHeader - keep the status code until the response is finished
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if !w.written {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow - the headers are written when the response is finished
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.streaming {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.written = true
+}
+
+// Status - return the response status code
+func (w *timeoutWriter) Status() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+// Size - return the size of the body
+func (w *timeoutWriter) Size() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	if !w.written {
+		return -1
+	}
+	return len(w.body)
+}
+
+// Written - return whether anything is written
+func (w *timeoutWriter) Written() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.streaming || w.written
+}
+
+// Write - buffer the data, it is dropped after the deadline
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.streaming {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.written = true
+	w.body = append(w.body, data...)
+	return len(data), nil
+}
+
+// WriteString - buffer the string data
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush - a flushed response is a stream, the buffered response is written and the rest passes through
+func (w *timeoutWriter) Flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.timedOut {
+		return
+	}
+	if !w.streaming {
+		w.writeBuffered()
+		w.streaming = true
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap - return the underlying writer, used by http.ResponseController
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack - the connection is taken over, the timeout only cancels the context
+func (w *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	w.streaming = true
+	return w.ResponseWriter.Hijack()
+}
+
+// writeBuffered - copy the headers and write the buffered response
+func (w *timeoutWriter) writeBuffered() {
+	header := w.ResponseWriter.Header()
+	for name, values := range w.header {
+		header[name] = values
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.body) == 0 {
+		if w.written || w.status != http.StatusOK {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.body)
+	w.body = nil
+}
+
+// finish - write the response of the handlers
+func (w *timeoutWriter) finish() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.streaming {
+		w.writeBuffered()
+	}
+}
+
+// timeout - answer 503 unless the response is already streaming
+func (w *timeoutWriter) timeout() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.timedOut = true
+	if w.streaming {
+		return
+	}
+
+	body, _ := json.Marshal(ErrorHttpResponse{
+		Message:     "Service unavailable",
+		Status:      http.StatusServiceUnavailable,
+		Description: "The request is timed out",
+	})
+	header := w.ResponseWriter.Header()
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.ResponseWriter.Write(body)
+	// the client receives the response while the handlers are finishing
+	w.ResponseWriter.Flush()
+}
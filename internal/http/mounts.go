@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+// mountedHandler - an http.Handler which serves the requests of the prefix which are not matched by a route
+type mountedHandler struct {
+	prefix  string
+	handler http.Handler
+}
+
+// MARK: Public functions
+
+// MountHandler - serve the requests of the prefix which are not matched by a route by the handler, e.g. a gRPC-Gateway mux
+// The middlewares of the server run before the handler, the handler is mounted again on reload
+func (s *GinServer) MountHandler(prefix string, handler http.Handler) error {
+	if handler == nil {
+		return NewMountHandlerErr(prefix, "the handler is nil")
+	}
+	prefix = "/" + strings.Trim(prefix, "/")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, item := range s.mounts {
+		if item.prefix == prefix {
+			return NewMountHandlerErr(prefix, "the prefix is already mounted")
+		}
+	}
+	s.mounts = append(s.mounts, mountedHandler{prefix: prefix, handler: handler})
+	s.attachMounts()
+	return nil
+}
+
+// MARK: Private functions
+
+// attachMounts - serve the mounted handlers by the not-found handler of the engine
+func (s *GinServer) attachMounts() {
+	if len(s.mounts) == 0 {
+		return
+	}
+
+	mounts := append([]mountedHandler{}, s.mounts...)
+	s.baseRouter.NoRoute(func(c *gin.Context) {
+		var matched *mountedHandler
+		for i, item := range mounts {
+			if isUnderPrefix(c.Request.URL.Path, item.prefix) && (matched == nil || len(item.prefix) > len(matched.prefix)) {
+				matched = &mounts[i]
+			}
+		}
+		// the response of the engine is written if no handler is matched
+		if matched != nil {
+			// the status of the not-found handler is 404 until the handler writes its own
+			c.Status(http.StatusOK)
+			matched.handler.ServeHTTP(c.Writer, c.Request)
+		}
+	})
+}
+
+// isUnderPrefix - return whether the path is the prefix or one of its sub paths
+func isUnderPrefix(path string, prefix string) bool {
+	if prefix == "/" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
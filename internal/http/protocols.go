@@ -0,0 +1,192 @@
+package http
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/listener"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// MARK: HTTP/3
+
+// HTTP3Server - an HTTP/3 server running alongside the TLS listener, e.g. `http3.Server` of quic-go
+type HTTP3Server interface {
+	ListenAndServe() error
+	Close() error
+}
+
+// HTTP3ServerFactory - create an HTTP/3 server listening on the UDP address with the TLS config and the handler
+type HTTP3ServerFactory func(addr string, tlsConfig *tls.Config, handler http.Handler) (HTTP3Server, error)
+
+var (
+	http3FactoryLock sync.RWMutex
+	http3Factory     HTTP3ServerFactory
+)
+
+// RegisterHTTP3ServerFactory - register the HTTP/3 implementation used by servers with `protocols.http3.enabled`
+// The standard library has no QUIC transport, so the application provides it (e.g. by wrapping quic-go)
+func RegisterHTTP3ServerFactory(factory HTTP3ServerFactory) {
+	http3FactoryLock.Lock()
+	defer http3FactoryLock.Unlock()
+
+	http3Factory = factory
+}
+
+// getHTTP3ServerFactory - return the registered HTTP/3 implementation
+func getHTTP3ServerFactory() HTTP3ServerFactory {
+	http3FactoryLock.RLock()
+	defer http3FactoryLock.RUnlock()
+
+	return http3Factory
+}
+
+// MARK: Listener
+
+// serverListener - the servers created from the config of a GinServer
+type serverListener struct {
+	server      *http.Server
+	http3Server HTTP3Server
+	http3Down   atomic.Bool // the HTTP/3 server is stopped, so it is not advertised anymore
+	netListener net.Listener
+	useTLS      bool
+	socket      listener.SocketConfig
+}
+
+// newListener - create the http server, and the HTTP/3 server if it is enabled, from the config
+func newListener(config types.GinServerConfig, handler http.Handler) (*serverListener, error) {
+	result := &serverListener{
+		useTLS: config.TLS.CertFile != "" || config.TLS.KeyFile != "",
+		socket: config.Socket,
+	}
+	protocols := config.Protocols
+
+	h2s := &http2.Server{
+		MaxConcurrentStreams: protocols.HTTP2.MaxConcurrentStreams,
+		MaxReadFrameSize:     protocols.HTTP2.MaxReadFrameSize,
+		IdleTimeout:          time.Duration(protocols.HTTP2.IdleTimeout) * time.Millisecond,
+	}
+	if h2s.MaxConcurrentStreams == 0 {
+		h2s.MaxConcurrentStreams = 250
+	}
+
+	var tlsConfig *tls.Config
+	if result.useTLS {
+		certificate, err := tls.LoadX509KeyPair(config.TLS.CertFile, config.TLS.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+		if config.TLS.MinVersion == "1.3" {
+			tlsConfig.MinVersion = tls.VersionTLS13
+		}
+	}
+
+	if protocols.HTTP3.Enabled {
+		if tlsConfig == nil {
+			return nil, errors.New("http3 needs the tls certificate of the server")
+		}
+		factory := getHTTP3ServerFactory()
+		if factory == nil {
+			return nil, errors.New("http3 is enabled but no implementation is registered by RegisterHTTP3ServerFactory")
+		}
+
+		addr := protocols.HTTP3.Addr
+		if addr == "" {
+			addr = config.ListenAddress
+		}
+		http3Server, err := factory(addr, tlsConfig.Clone(), handler)
+		if err != nil {
+			return nil, err
+		}
+		result.http3Server = http3Server
+		handler = altSvcHandler(handler, addr, protocols.HTTP3.AltSvcMaxAge, &result.http3Down)
+	}
+
+	if protocols.H2C && !result.useTLS {
+		handler = h2c.NewHandler(handler, h2s)
+	}
+
+	result.server = &http.Server{
+		Addr:           config.ListenAddress,
+		Handler:        handler,
+		ReadTimeout:    config.Config.ReadTimeout,
+		WriteTimeout:   config.Config.WriteTimeout,
+		MaxHeaderBytes: config.Config.MaxHeaderBytes,
+		TLSConfig:      tlsConfig,
+	}
+
+	if protocols.DisableHTTP2 {
+		// a non-nil empty map disables the automatic HTTP/2 over TLS
+		result.server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+	} else if err := http2.ConfigureServer(result.server, h2s); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Listen - open the listener, the address can be a tcp address, "unix:///path.sock" or "systemd://<name or index>"
+func (l *serverListener) Listen() error {
+	netListener, err := listener.Listen("tcp", l.server.Addr, l.socket)
+	if err != nil {
+		return err
+	}
+	l.netListener = netListener
+	return nil
+}
+
+// Serve - serve the opened listener, and the HTTP/3 server in the background
+// The HTTP/3 endpoint is not advertised by Alt-Svc anymore after its server stops
+func (l *serverListener) Serve() error {
+	if l.http3Server != nil {
+		go func() {
+			err := l.http3Server.ListenAndServe()
+			l.http3Down.Store(true)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				if lg, _ := logger.GetManager().GetLogger(); lg != nil {
+					lg.Log(logTypes.NewLogObject(logTypes.ERROR, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Serving the HTTP/3 server failed ...", err))
+				}
+			}
+		}()
+	}
+
+	if l.useTLS {
+		// the certificate is already loaded in the TLS config
+		return l.server.ServeTLS(l.netListener, "", "")
+	}
+	return l.server.Serve(l.netListener)
+}
+
+// altSvcHandler - advertise the HTTP/3 endpoint to the clients until it is down
+func altSvcHandler(handler http.Handler, addr string, maxAge int, down *atomic.Bool) http.Handler {
+	if maxAge <= 0 {
+		maxAge = 86400
+	}
+
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		port = "443"
+	}
+	value := fmt.Sprintf("h3=\":%v\"; ma=%v", port, maxAge)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !down.Load() {
+			w.Header().Set("Alt-Svc", value)
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
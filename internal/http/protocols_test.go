@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"golang.org/x/net/http2"
+)
+
+func TestNewListener_H2C(t *testing.T) {
+	config := types.GinServerConfig{ListenAddress: "127.0.0.1:0"}
+	config.Protocols.H2C = true
+
+	serverListener, err := newListener(config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	if err != nil {
+		t.Fatalf("Creating listener --> Expected: %v, but got %v", nil, err)
+	}
+
+	server := httptest.NewServer(serverListener.server.Handler)
+	defer server.Close()
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("h2c request --> Expected: %v, but got %v", nil, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("X-Proto") != "HTTP/2.0" {
+		t.Errorf("Request protocol --> Expected: %v, but got %v", "HTTP/2.0", resp.Header.Get("X-Proto"))
+	}
+}
+
+func TestNewListener_HTTP3NeedsTLS(t *testing.T) {
+	config := types.GinServerConfig{ListenAddress: "127.0.0.1:0"}
+	config.Protocols.HTTP3.Enabled = true
+
+	if _, err := newListener(config, http.NotFoundHandler()); err == nil {
+		t.Errorf("HTTP/3 without TLS --> Expected an error, but got %v", err)
+	}
+}
+
+func TestAltSvcHandler(t *testing.T) {
+	var down atomic.Bool
+	handler := altSvcHandler(http.NotFoundHandler(), ":8443", 0, &down)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Header().Get("Alt-Svc") != `h3=":8443"; ma=86400` {
+		t.Errorf("Alt-Svc --> Expected: %v, but got %v", `h3=":8443"; ma=86400`, w.Header().Get("Alt-Svc"))
+	}
+}
+
+// failingHTTP3Server - an HTTP/3 server which cannot listen on its address
+type failingHTTP3Server struct{}
+
+func (failingHTTP3Server) ListenAndServe() error { return errors.New("address already in use") }
+func (failingHTTP3Server) Close() error          { return nil }
+
+func TestServerListener_HTTP3Failed(t *testing.T) {
+	makeReadyConfigManager()
+
+	serverListener := &serverListener{
+		server:      &http.Server{Handler: http.NotFoundHandler()},
+		http3Server: failingHTTP3Server{},
+	}
+	handler := altSvcHandler(http.NotFoundHandler(), ":8443", 0, &serverListener.http3Down)
+
+	netListener, _ := net.Listen("tcp", "127.0.0.1:0")
+	serverListener.netListener = netListener
+	go func() { _ = serverListener.Serve() }()
+	defer serverListener.server.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !serverListener.http3Down.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Header().Get("Alt-Svc") != "" {
+		t.Errorf("Alt-Svc of failed HTTP/3 --> Expected: %v, but got %v", "", w.Header().Get("Alt-Svc"))
+	}
+}
@@ -0,0 +1,48 @@
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// ConfigErr Error
+type ConfigErr struct {
+	Name   string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *ConfigErr) Error() string {
+	return fmt.Sprintf("The config of the proxy route '%v' is not valid: %v", err.Name, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewConfigErr - return a new instance of ConfigErr
+func NewConfigErr(name string, reason string) error {
+	return &ConfigErr{Name: name, Reason: reason}
+}
+
+// NoUpstreamErr Error - returned when every upstream of the pool is unhealthy or its circuit is open
+type NoUpstreamErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *NoUpstreamErr) Error() string {
+	return fmt.Sprintf("No upstream of the proxy route '%v' is available", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NoUpstreamErr) ErrorCode() errs.Code {
+	return errs.Unavailable
+}
+
+// NewNoUpstreamErr - return a new instance of NoUpstreamErr
+func NewNoUpstreamErr(name string) error {
+	return &NoUpstreamErr{Name: name}
+}
@@ -0,0 +1,532 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	RoundRobinBalancer = "round_robin"
+	LeastConnBalancer  = "least_conn"
+
+	// maxRetryBodySize - the largest request body which is kept in memory to be sent again on retry
+	maxRetryBodySize = 1 << 20
+)
+
+var (
+	ProxyMaintenanceType = logTypes.NewLogType("HTTP_PROXY_MAINTENANCE")
+)
+
+// Proxy - forward the requests of a route to a pool of upstreams
+type Proxy struct {
+	config        types.ProxyRouteConfig
+	upstreams     []*upstream
+	next          atomic.Uint64
+	pathRegex     *regexp.Regexp
+	retryStatuses []int
+	backoff       time.Duration
+	maxBackoff    time.Duration
+	openTimeout   time.Duration
+	transport     *http.Transport
+	reverseProxy  *httputil.ReverseProxy
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// MARK: Public functions
+
+// New - validate the config, create the proxy and start the health check of the upstreams
+func New(config types.ProxyRouteConfig) (*Proxy, error) {
+	p := &Proxy{
+		config:        config,
+		retryStatuses: config.Retry.Statuses,
+		backoff:       time.Duration(config.Retry.Backoff) * time.Millisecond,
+		maxBackoff:    time.Duration(config.Retry.MaxBackoff) * time.Millisecond,
+		openTimeout:   time.Duration(config.CircuitBreaker.OpenTimeout) * time.Millisecond,
+		stop:          make(chan struct{}),
+	}
+
+	if len(config.Upstreams) == 0 {
+		return nil, NewConfigErr(config.Name, "no upstream is defined")
+	}
+	for _, item := range config.Upstreams {
+		target, err := url.Parse(item)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, NewConfigErr(config.Name, fmt.Sprintf("the upstream '%v' is not an absolute url", item))
+		}
+		p.upstreams = append(p.upstreams, newUpstream(target))
+	}
+
+	switch config.Balancer {
+	case "":
+		p.config.Balancer = RoundRobinBalancer
+	case RoundRobinBalancer, LeastConnBalancer:
+	default:
+		return nil, NewConfigErr(config.Name, fmt.Sprintf("the balancer '%v' is not supported", config.Balancer))
+	}
+
+	if config.Rewrite.Regex != "" {
+		pathRegex, err := regexp.Compile(config.Rewrite.Regex)
+		if err != nil {
+			return nil, NewConfigErr(config.Name, fmt.Sprintf("the rewrite regex is not valid: %v", err))
+		}
+		p.pathRegex = pathRegex
+	}
+
+	if len(p.retryStatuses) == 0 {
+		p.retryStatuses = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+	if p.backoff <= 0 {
+		p.backoff = 100 * time.Millisecond
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = 2 * time.Second
+	}
+	if p.openTimeout <= 0 {
+		p.openTimeout = 30 * time.Second
+	}
+
+	p.transport = http.DefaultTransport.(*http.Transport).Clone()
+	p.reverseProxy = &httputil.ReverseProxy{
+		Rewrite:        p.rewrite,
+		Transport:      p,
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler:   p.handleError,
+	}
+
+	if config.HealthCheck.Path != "" {
+		go p.checkHealth()
+	}
+	return p, nil
+}
+
+// Handler - return the route handler which forwards the request
+func (p *Proxy) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p.ServeHTTP(responseWriter{c.Writer}, c.Request)
+	}
+}
+
+// responseWriter - hide the CloseNotify of the gin writer, which panics if the underlying writer does not have it
+// Flush and Hijack are still reached through Unwrap by http.ResponseController
+type responseWriter struct {
+	http.ResponseWriter
+}
+
+// Unwrap - return the gin writer
+func (w responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ServeHTTP - forward the request to an upstream of the pool
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.reverseProxy.ServeHTTP(w, r)
+}
+
+// Close - stop the health check and close the idle connections to the upstreams
+func (p *Proxy) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		p.transport.CloseIdleConnections()
+	})
+}
+
+// RoundTrip - send the request to the upstreams chosen by the balancer, retry the idempotent requests on failure
+func (p *Proxy) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if p.config.Retry.Attempts > 0 && isIdempotent(req.Method) &&
+		(req.Body == nil || req.Body == http.NoBody || req.GetBody != nil) {
+		attempts += p.config.Retry.Attempts
+	}
+
+	var tried []*upstream
+	var lastResponse *http.Response
+	var lastErr error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := p.wait(req.Context(), attempt); err != nil {
+				break
+			}
+		}
+
+		u := p.pick(tried)
+		if u == nil {
+			break
+		}
+		tried = append(tried, u)
+
+		if lastResponse != nil {
+			_, _ = io.Copy(io.Discard, lastResponse.Body)
+			_ = lastResponse.Body.Close()
+			lastResponse = nil
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := p.send(req, u)
+		if err != nil {
+			lastErr = err
+			if req.Context().Err() != nil {
+				// the client is gone
+				break
+			}
+			continue
+		}
+		if !p.isRetryStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		lastResponse = resp
+	}
+
+	if lastResponse != nil {
+		return lastResponse, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, NewNoUpstreamErr(p.config.Name)
+}
+
+// MARK: Private functions
+
+// rewrite - change the path and the headers of the request to the upstream
+func (p *Proxy) rewrite(pr *httputil.ProxyRequest) {
+	pr.SetXForwarded()
+
+	pr.Out.URL.Path = p.rewritePath(pr.In.URL.Path)
+	pr.Out.URL.RawPath = ""
+	if p.config.PreserveHost {
+		pr.Out.Host = pr.In.Host
+	} else {
+		pr.Out.Host = ""
+	}
+
+	for _, name := range p.config.RemoveRequestHeaders {
+		pr.Out.Header.Del(name)
+	}
+	for name, value := range p.config.RequestHeaders {
+		pr.Out.Header.Set(name, value)
+	}
+
+	// the body is kept to be sent again if the request is retried
+	body := pr.Out.Body
+	if p.config.Retry.Attempts > 0 && isIdempotent(pr.Out.Method) && body != nil && body != http.NoBody &&
+		pr.Out.ContentLength > 0 && pr.Out.ContentLength <= maxRetryBodySize {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			pr.Out.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), body))
+			return
+		}
+		pr.Out.Body = io.NopCloser(bytes.NewReader(data))
+		pr.Out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+}
+
+// rewritePath - apply the strip prefix, the add prefix and the regex of the config
+func (p *Proxy) rewritePath(path string) string {
+	rewrite := p.config.Rewrite
+	if rewrite.StripPrefix != "" {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(rewrite.StripPrefix, "/"))
+	}
+	if rewrite.AddPrefix != "" {
+		path = strings.TrimSuffix(rewrite.AddPrefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	}
+	if p.pathRegex != nil {
+		path = p.pathRegex.ReplaceAllString(path, rewrite.Replacement)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// modifyResponse - add the response headers of the config
+func (p *Proxy) modifyResponse(resp *http.Response) error {
+	for name, value := range p.config.ResponseHeaders {
+		resp.Header.Set(name, value)
+	}
+	return nil
+}
+
+// handleError - answer the request which is not forwarded
+func (p *Proxy) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadGateway
+	var noUpstreamErr *NoUpstreamErr
+	if errors.As(err, &noUpstreamErr) {
+		status = http.StatusServiceUnavailable
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+
+	if r.Context().Err() == nil {
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(logTypes.NewLogObject(logTypes.ERROR, "http.proxy.ServeHTTP", ProxyMaintenanceType, time.Now(), "Forwarding the request to the upstream failed ...", map[string]interface{}{
+				"route": p.config.Name,
+				"path":  r.URL.Path,
+				"error": err.Error(),
+			}))
+		}
+	}
+
+	body, _ := json.Marshal(middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: err.Error(),
+	})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// pick - choose an upstream by the balancer, the upstreams which are not tried yet are preferred
+func (p *Proxy) pick(tried []*upstream) *upstream {
+	if u := p.pickFrom(tried); u != nil {
+		return u
+	}
+	if len(tried) > 0 {
+		return p.pickFrom(nil)
+	}
+	return nil
+}
+
+// pickFrom - choose an available upstream except the excluded ones
+func (p *Proxy) pickFrom(excluded []*upstream) *upstream {
+	count := len(p.upstreams)
+	start := 0
+	if p.config.Balancer == RoundRobinBalancer {
+		start = int((p.next.Add(1) - 1) % uint64(count))
+	}
+
+	candidates := make([]*upstream, 0, count)
+	for i := 0; i < count; i++ {
+		u := p.upstreams[(start+i)%count]
+		if u.healthy.Load() && !containsUpstream(excluded, u) {
+			candidates = append(candidates, u)
+		}
+	}
+	if p.config.Balancer == LeastConnBalancer {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].active.Load() < candidates[j].active.Load()
+		})
+	}
+
+	for _, u := range candidates {
+		if u.acquire(p.config.CircuitBreaker.FailureThreshold) {
+			return u
+		}
+	}
+	return nil
+}
+
+// send - send the request to the upstream with the timeout and record the result
+func (p *Proxy) send(req *http.Request, u *upstream) (*http.Response, error) {
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	// the upgraded connections are long-lived, so the timeout is not applied to them
+	if p.config.Timeout > 0 && req.Header.Get("Upgrade") == "" {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.config.Timeout)*time.Millisecond)
+	}
+
+	out := req.WithContext(ctx)
+	target := *req.URL
+	target.Scheme = u.target.Scheme
+	target.Host = u.target.Host
+	target.Path = singleJoiningSlash(u.target.Path, req.URL.Path)
+	target.RawPath = ""
+	out.URL = &target
+
+	threshold := p.config.CircuitBreaker.FailureThreshold
+	u.active.Add(1)
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		cancel()
+		u.active.Add(-1)
+		if req.Context().Err() != nil {
+			u.release()
+		} else {
+			u.report(false, threshold, p.openTimeout)
+		}
+		return nil, err
+	}
+
+	u.report(!p.isRetryStatus(resp.StatusCode), threshold, p.openTimeout)
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		// the body of an upgraded connection must stay a io.ReadWriteCloser, there is no timeout to cancel
+		cancel()
+		u.active.Add(-1)
+		return resp, nil
+	}
+	resp.Body = &trackedBody{ReadCloser: resp.Body, finish: func() {
+		cancel()
+		u.active.Add(-1)
+	}}
+	return resp, nil
+}
+
+// wait - wait for the backoff of the retry
+func (p *Proxy) wait(ctx context.Context, attempt int) error {
+	backoff := p.backoff << (attempt - 1)
+	if backoff > p.maxBackoff || backoff <= 0 {
+		backoff = p.maxBackoff
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryStatus - check whether the upstream status is a failure to be retried
+func (p *Proxy) isRetryStatus(status int) bool {
+	for _, item := range p.retryStatuses {
+		if item == status {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHealth - check the health of the upstreams periodically until the proxy is closed
+func (p *Proxy) checkHealth() {
+	config := p.config.HealthCheck
+	interval := time.Duration(config.Interval) * time.Millisecond
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var wg sync.WaitGroup
+		for _, u := range p.upstreams {
+			wg.Add(1)
+			go func(u *upstream) {
+				defer wg.Done()
+				p.check(u)
+			}(u)
+		}
+		wg.Wait()
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check - send the health check request to the upstream and update its state
+func (p *Proxy) check(u *upstream) {
+	config := p.config.HealthCheck
+	timeout := time.Duration(config.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	healthyThreshold := config.HealthyThreshold
+	if healthyThreshold <= 0 {
+		healthyThreshold = 1
+	}
+	unhealthyThreshold := config.UnhealthyThreshold
+	if unhealthyThreshold <= 0 {
+		unhealthyThreshold = 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	healthy := false
+	target := *u.target
+	target.Path = singleJoiningSlash(u.target.Path, config.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err == nil {
+		var resp *http.Response
+		if resp, err = p.transport.RoundTrip(req); err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			if config.ExpectedStatus > 0 {
+				healthy = resp.StatusCode == config.ExpectedStatus
+			} else {
+				healthy = resp.StatusCode >= 200 && resp.StatusCode < 400
+			}
+		}
+	}
+
+	if u.recordCheck(healthy, healthyThreshold, unhealthyThreshold) {
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(logTypes.NewLogObject(logTypes.INFO, "http.proxy.check", ProxyMaintenanceType, time.Now(), "The health of the upstream is changed ...", map[string]interface{}{
+				"route":    p.config.Name,
+				"upstream": u.target.String(),
+				"healthy":  healthy,
+			}))
+		}
+	}
+}
+
+// isIdempotent - check whether the request can be sent again
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// containsUpstream - check whether the upstream is in the list
+func containsUpstream(list []*upstream, u *upstream) bool {
+	for _, item := range list {
+		if item == u {
+			return true
+		}
+	}
+	return false
+}
+
+// singleJoiningSlash - join the base path of the upstream and the request path
+func singleJoiningSlash(a string, b string) string {
+	switch {
+	case a == "" || a == "/":
+		if !strings.HasPrefix(b, "/") {
+			return "/" + b
+		}
+		return b
+	case b == "":
+		return a
+	}
+	return strings.TrimSuffix(a, "/") + "/" + strings.TrimPrefix(b, "/")
+}
@@ -0,0 +1,198 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../../..", "test", "Gonyx")
+}
+
+func newUpstreamServer(name string, status *atomic.Int32, hits *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("X-Upstream", name)
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("X-Injected", r.Header.Get("X-Injected"))
+		w.Header().Set("X-Host", r.Host)
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write(body)
+	}))
+}
+
+func serve(p *Proxy, method string, path string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestProxy_RewriteAndHeaders(t *testing.T) {
+	makeReadyConfigManager()
+
+	var status, hits atomic.Int32
+	status.Store(http.StatusOK)
+	server := newUpstreamServer("a", &status, &hits)
+	defer server.Close()
+
+	p, err := New(types.ProxyRouteConfig{
+		Name:            "users",
+		Upstreams:       []string{server.URL + "/base"},
+		Rewrite:         types.ProxyRewriteConfig{StripPrefix: "/api", AddPrefix: "/internal", Regex: "^/internal/users/(.*)$", Replacement: "/internal/accounts/$1"},
+		RequestHeaders:  map[string]string{"X-Injected": "yes"},
+		ResponseHeaders: map[string]string{"X-Proxy": "gonyx"},
+		PreserveHost:    true,
+	})
+	if err != nil {
+		t.Fatalf("Creating proxy --> Expected: %v, but got %v", nil, err)
+	}
+	defer p.Close()
+
+	w := serve(p, http.MethodPost, "/api/users/1", "body")
+	if w.Code != http.StatusOK || w.Body.String() != "body" {
+		t.Errorf("Proxied response --> Expected: %v %v, but got %v %v", http.StatusOK, "body", w.Code, w.Body.String())
+	}
+	if path := w.Header().Get("X-Path"); path != "/base/internal/accounts/1" {
+		t.Errorf("Upstream path --> Expected: %v, but got %v", "/base/internal/accounts/1", path)
+	}
+	if w.Header().Get("X-Injected") != "yes" || w.Header().Get("X-Proxy") != "gonyx" {
+		t.Errorf("Injected headers --> Expected: %v, but got %v", "yes gonyx", w.Header())
+	}
+	if host := w.Header().Get("X-Host"); host != "example.com" {
+		t.Errorf("Preserved host --> Expected: %v, but got %v", "example.com", host)
+	}
+}
+
+func TestProxy_BalancerAndRetry(t *testing.T) {
+	makeReadyConfigManager()
+
+	var statusA, hitsA, statusB, hitsB atomic.Int32
+	statusA.Store(http.StatusOK)
+	statusB.Store(http.StatusOK)
+	serverA := newUpstreamServer("a", &statusA, &hitsA)
+	defer serverA.Close()
+	serverB := newUpstreamServer("b", &statusB, &hitsB)
+	defer serverB.Close()
+
+	p, err := New(types.ProxyRouteConfig{
+		Name:      "pool",
+		Upstreams: []string{serverA.URL, serverB.URL},
+		Retry:     types.ProxyRetryConfig{Attempts: 1, Backoff: 1},
+	})
+	if err != nil {
+		t.Fatalf("Creating proxy --> Expected: %v, but got %v", nil, err)
+	}
+	defer p.Close()
+
+	for i := 0; i < 4; i++ {
+		serve(p, http.MethodGet, "/", "")
+	}
+	if hitsA.Load() != 2 || hitsB.Load() != 2 {
+		t.Errorf("Round robin --> Expected: %v, but got %v and %v", "2 and 2", hitsA.Load(), hitsB.Load())
+	}
+
+	statusA.Store(http.StatusBadGateway)
+	for i := 0; i < 2; i++ {
+		w := serve(p, http.MethodPut, "/", "payload")
+		if w.Code != http.StatusOK || w.Header().Get("X-Upstream") != "b" || w.Body.String() != "payload" {
+			t.Errorf("Retried request --> Expected: %v from b, but got %v from %v", http.StatusOK, w.Code, w.Header().Get("X-Upstream"))
+		}
+	}
+
+	hitsA.Store(0)
+	hitsB.Store(0)
+	if w := serve(p, http.MethodPost, "/", "payload"); hitsA.Load()+hitsB.Load() != 1 {
+		t.Errorf("Not idempotent request --> Expected: %v try, but got %v with status %v", 1, hitsA.Load()+hitsB.Load(), w.Code)
+	}
+}
+
+func TestProxy_CircuitBreaker(t *testing.T) {
+	makeReadyConfigManager()
+
+	var status, hits atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	server := newUpstreamServer("a", &status, &hits)
+	defer server.Close()
+
+	p, err := New(types.ProxyRouteConfig{
+		Name:           "breaker",
+		Upstreams:      []string{server.URL},
+		CircuitBreaker: types.ProxyCircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: 50},
+	})
+	if err != nil {
+		t.Fatalf("Creating proxy --> Expected: %v, but got %v", nil, err)
+	}
+	defer p.Close()
+
+	serve(p, http.MethodGet, "/", "")
+	serve(p, http.MethodGet, "/", "")
+	if w := serve(p, http.MethodGet, "/", ""); w.Code != http.StatusServiceUnavailable || hits.Load() != 2 {
+		t.Errorf("Open circuit --> Expected: %v without a request, but got %v after %v requests", http.StatusServiceUnavailable, w.Code, hits.Load())
+	}
+
+	status.Store(http.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	if w := serve(p, http.MethodGet, "/", ""); w.Code != http.StatusOK {
+		t.Errorf("Half-open circuit --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestProxy_HealthCheck(t *testing.T) {
+	makeReadyConfigManager()
+
+	var status, hits atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	server := newUpstreamServer("a", &status, &hits)
+	defer server.Close()
+
+	p, err := New(types.ProxyRouteConfig{
+		Name:        "health",
+		Upstreams:   []string{server.URL},
+		HealthCheck: types.ProxyHealthCheckConfig{Path: "/health", Interval: 10, UnhealthyThreshold: 1},
+	})
+	if err != nil {
+		t.Fatalf("Creating proxy --> Expected: %v, but got %v", nil, err)
+	}
+	defer p.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for p.upstreams[0].healthy.Load() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if w := serve(p, http.MethodGet, "/", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Unhealthy upstream --> Expected: %v, but got %v", http.StatusServiceUnavailable, w.Code)
+	}
+
+	status.Store(http.StatusOK)
+	deadline = time.Now().Add(time.Second)
+	for !p.upstreams[0].healthy.Load() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if w := serve(p, http.MethodGet, "/", ""); w.Code != http.StatusOK {
+		t.Errorf("Recovered upstream --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	testCases := []types.ProxyRouteConfig{
+		{Name: "empty"},
+		{Name: "relative", Upstreams: []string{"10.0.0.1:8080"}},
+		{Name: "balancer", Upstreams: []string{"http://10.0.0.1"}, Balancer: "random"},
+		{Name: "regex", Upstreams: []string{"http://10.0.0.1"}, Rewrite: types.ProxyRewriteConfig{Regex: "("}},
+	}
+
+	for _, config := range testCases {
+		if _, err := New(config); err == nil {
+			t.Errorf("Creating proxy %v --> Expected an error, but got %v", config.Name, err)
+		}
+	}
+}
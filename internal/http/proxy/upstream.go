@@ -0,0 +1,120 @@
+package proxy
+
+import (
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// upstream - a server of the pool with its health and circuit state
+type upstream struct {
+	target  *url.URL
+	healthy atomic.Bool
+	active  atomic.Int64 // the requests in flight, used by the least_conn balancer
+
+	lock      sync.Mutex
+	failures  int       // the consecutive failed requests
+	openUntil time.Time // the circuit is open until this time
+	trial     bool      // a trial request is in flight while the circuit is half-open
+
+	// the consecutive results of the health check, only used by the health check goroutine
+	checkSuccesses int
+	checkFailures  int
+}
+
+// newUpstream - create a healthy upstream
+func newUpstream(target *url.URL) *upstream {
+	u := &upstream{target: target}
+	u.healthy.Store(true)
+	return u
+}
+
+// acquire - check whether the upstream can receive a request
+// If the circuit is open and its timeout is passed, only one trial request is let through
+func (u *upstream) acquire(threshold int) bool {
+	if !u.healthy.Load() {
+		return false
+	}
+	if threshold <= 0 {
+		return true
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.failures < threshold {
+		return true
+	}
+	if u.trial || time.Now().Before(u.openUntil) {
+		return false
+	}
+	u.trial = true
+	return true
+}
+
+// report - record the result of a request, the circuit is opened after the consecutive failures
+func (u *upstream) report(success bool, threshold int, openTimeout time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.trial = false
+	if success {
+		u.failures = 0
+		return
+	}
+
+	u.failures++
+	if u.failures >= threshold {
+		u.openUntil = time.Now().Add(openTimeout)
+	}
+}
+
+// release - finish a request without a result, e.g. when the client is gone
+func (u *upstream) release() {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.trial = false
+}
+
+// recordCheck - record the result of a health check and return true if the health state is changed
+func (u *upstream) recordCheck(healthy bool, healthyThreshold int, unhealthyThreshold int) bool {
+	if healthy {
+		u.checkFailures = 0
+		u.checkSuccesses++
+		if !u.healthy.Load() && u.checkSuccesses >= healthyThreshold {
+			u.healthy.Store(true)
+			return true
+		}
+		return false
+	}
+
+	u.checkSuccesses = 0
+	u.checkFailures++
+	if u.healthy.Load() && u.checkFailures >= unhealthyThreshold {
+		u.healthy.Store(false)
+		return true
+	}
+	return false
+}
+
+// MARK: trackedBody
+
+// trackedBody - the body of an upstream response, it finishes the request when it is closed
+type trackedBody struct {
+	io.ReadCloser
+	once   sync.Once
+	finish func()
+}
+
+// Close - close the body and finish the request
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.finish)
+	return err
+}
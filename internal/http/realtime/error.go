@@ -0,0 +1,114 @@
+package realtime
+
+import "fmt"
+
+// HandshakeErr Error
+type HandshakeErr struct {
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *HandshakeErr) Error() string {
+	return fmt.Sprintf("WebSocket handshake failed: %v", err.Reason)
+}
+
+// NewHandshakeErr - return a new instance of HandshakeErr
+func NewHandshakeErr(reason string) error {
+	return &HandshakeErr{Reason: reason}
+}
+
+// CloseErr Error - returned by read/write when the peer closed the connection
+type CloseErr struct {
+	Code   int
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *CloseErr) Error() string {
+	return fmt.Sprintf("WebSocket closed with code %v: %v", err.Code, err.Reason)
+}
+
+// NewCloseErr - return a new instance of CloseErr
+func NewCloseErr(code int, reason string) error {
+	return &CloseErr{Code: code, Reason: reason}
+}
+
+// ProtocolErr Error
+type ProtocolErr struct {
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *ProtocolErr) Error() string {
+	return fmt.Sprintf("WebSocket protocol error: %v", err.Reason)
+}
+
+// NewProtocolErr - return a new instance of ProtocolErr
+func NewProtocolErr(reason string) error {
+	return &ProtocolErr{Reason: reason}
+}
+
+// ReadLimitErr Error
+type ReadLimitErr struct {
+	Limit int64
+}
+
+// Error method - satisfying error interface
+func (err *ReadLimitErr) Error() string {
+	return fmt.Sprintf("WebSocket message exceeds the read limit of %v bytes", err.Limit)
+}
+
+// NewReadLimitErr - return a new instance of ReadLimitErr
+func NewReadLimitErr(limit int64) error {
+	return &ReadLimitErr{Limit: limit}
+}
+
+// ClosedConnectionErr Error
+type ClosedConnectionErr struct {
+	ID string
+}
+
+// Error method - satisfying error interface
+func (err *ClosedConnectionErr) Error() string {
+	return fmt.Sprintf("Connection `%v` is already closed", err.ID)
+}
+
+// NewClosedConnectionErr - return a new instance of ClosedConnectionErr
+func NewClosedConnectionErr(id string) error {
+	return &ClosedConnectionErr{ID: id}
+}
+
+// StreamingNotSupportedErr Error
+type StreamingNotSupportedErr struct {
+}
+
+// Error method - satisfying error interface
+func (err *StreamingNotSupportedErr) Error() string {
+	return fmt.Sprintf("The response writer does not support streaming")
+}
+
+// NewStreamingNotSupportedErr - return a new instance of StreamingNotSupportedErr
+func NewStreamingNotSupportedErr() error {
+	return &StreamingNotSupportedErr{}
+}
+
+// BrokerErr Error
+type BrokerErr struct {
+	Hub string
+	Err error
+}
+
+// Error method - satisfying error interface
+func (err *BrokerErr) Error() string {
+	return fmt.Sprintf("Hub `%v` broker encounterred an error: %v", err.Hub, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *BrokerErr) Unwrap() error {
+	return err.Err
+}
+
+// NewBrokerErr - return a new instance of BrokerErr
+func NewBrokerErr(hub string, err error) error {
+	return &BrokerErr{Hub: hub, Err: err}
+}
@@ -0,0 +1,310 @@
+package realtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+)
+
+// MARK: Variables
+
+var (
+	HubMaintenanceType = types.NewLogType("HTTP_HUB_MAINTENANCE")
+)
+
+// DefaultHubQueueSize - the number of the messages which wait for a client before it is disconnected as a slow consumer
+const DefaultHubQueueSize = 256
+
+// MARK: Definitions
+
+// Message - a message broadcast through the hub
+type Message struct {
+	Event  string `json:"event,omitempty"`
+	Data   []byte `json:"data"`
+	Binary bool   `json:"binary,omitempty"`
+}
+
+// Client - a live connection that can join hub rooms, satisfied by *Conn and *SSEStream
+type Client interface {
+	ID() string
+	Send(msg Message) error
+	Done() <-chan struct{}
+}
+
+// Broker - a pub/sub backend used to share hub broadcasts between multiple instances
+type Broker interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error)
+}
+
+// disconnecter - a client which can be disconnected while a write to it is blocked
+type disconnecter interface {
+	disconnect()
+}
+
+// sender - the queue of the messages of a client, they are sent by its own goroutine so a slow client blocks no other
+type sender struct {
+	queue chan Message
+	stop  chan struct{}
+}
+
+// envelope - the payload that is published to the broker
+type envelope struct {
+	Origin  string  `json:"origin"`
+	Room    string  `json:"room"`
+	Message Message `json:"message"`
+}
+
+// Hub - group clients in rooms and broadcast messages to them
+type Hub struct {
+	name         string
+	instanceId   string
+	lock         sync.RWMutex
+	rooms        map[string]map[string]Client
+	memberships  map[string]map[string]struct{}
+	senders      map[string]*sender
+	queueSize    int
+	broker       Broker
+	subscription io.Closer
+}
+
+// NewHub - create a new hub with the name
+func NewHub(name string) *Hub {
+	return &Hub{
+		name:        name,
+		instanceId:  generateID(),
+		rooms:       make(map[string]map[string]Client),
+		memberships: make(map[string]map[string]struct{}),
+		senders:     make(map[string]*sender),
+		queueSize:   DefaultHubQueueSize,
+	}
+}
+
+// Name - return the name of the hub
+func (h *Hub) Name() string {
+	return h.name
+}
+
+// channel - return the broker channel name of the hub
+func (h *Hub) channel() string {
+	return "gonyx:hub:" + h.name
+}
+
+// UseBroker - fan out broadcasts through the broker so all instances deliver them
+func (h *Hub) UseBroker(ctx context.Context, broker Broker) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.subscription != nil {
+		_ = h.subscription.Close()
+		h.subscription = nil
+	}
+
+	subscription, err := broker.Subscribe(ctx, h.channel(), h.onBrokerMessage)
+	if err != nil {
+		return NewBrokerErr(h.name, err)
+	}
+
+	h.broker = broker
+	h.subscription = subscription
+	return nil
+}
+
+// Join - add the client to the room, the client leaves all rooms automatically when it is closed
+func (h *Hub) Join(room string, client Client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	members, ok := h.rooms[room]
+	if !ok {
+		members = make(map[string]Client)
+		h.rooms[room] = members
+	}
+	members[client.ID()] = client
+
+	rooms, ok := h.memberships[client.ID()]
+	if !ok {
+		rooms = make(map[string]struct{})
+		h.memberships[client.ID()] = rooms
+		item := &sender{queue: make(chan Message, h.queueSize), stop: make(chan struct{})}
+		h.senders[client.ID()] = item
+		go h.send(client, item)
+		go func() {
+			<-client.Done()
+			h.LeaveAll(client)
+		}()
+	}
+	rooms[room] = struct{}{}
+}
+
+// Leave - remove the client from the room
+func (h *Hub) Leave(room string, client Client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.leave(room, client.ID())
+}
+
+// LeaveAll - remove the client from all rooms
+func (h *Hub) LeaveAll(client Client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for room := range h.memberships[client.ID()] {
+		h.leave(room, client.ID())
+	}
+}
+
+// leave - remove the client from the room, the lock must be held
+func (h *Hub) leave(room string, clientId string) {
+	if members, ok := h.rooms[room]; ok {
+		delete(members, clientId)
+		if len(members) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+	if rooms, ok := h.memberships[clientId]; ok {
+		delete(rooms, room)
+		if len(rooms) == 0 {
+			delete(h.memberships, clientId)
+		}
+	}
+	if _, ok := h.memberships[clientId]; !ok {
+		if item, ok := h.senders[clientId]; ok {
+			close(item.stop)
+			delete(h.senders, clientId)
+		}
+	}
+}
+
+// Rooms - return the name of rooms with at least one member
+func (h *Hub) Rooms() []string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	result := make([]string, 0, len(h.rooms))
+	for room := range h.rooms {
+		result = append(result, room)
+	}
+	return result
+}
+
+// Members - return the number of local members of the room
+func (h *Hub) Members(room string) int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return len(h.rooms[room])
+}
+
+// Broadcast - deliver the message to all members of the room, on all instances if a broker is used
+func (h *Hub) Broadcast(ctx context.Context, room string, msg Message) error {
+	h.deliver(room, msg)
+
+	h.lock.RLock()
+	broker := h.broker
+	h.lock.RUnlock()
+
+	if broker != nil {
+		payload, err := json.Marshal(envelope{Origin: h.instanceId, Room: room, Message: msg})
+		if err != nil {
+			return NewBrokerErr(h.name, err)
+		}
+		if err := broker.Publish(ctx, h.channel(), payload); err != nil {
+			return NewBrokerErr(h.name, err)
+		}
+	}
+	return nil
+}
+
+// Close - stop receiving broadcasts from the broker
+func (h *Hub) Close() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.broker = nil
+	if h.subscription != nil {
+		err := h.subscription.Close()
+		h.subscription = nil
+		return err
+	}
+	return nil
+}
+
+// onBrokerMessage - deliver the messages published by other instances
+func (h *Hub) onBrokerMessage(payload []byte) {
+	var item envelope
+	if err := json.Unmarshal(payload, &item); err != nil {
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(types.NewLogObject(types.ERROR, "http.Hub.onBrokerMessage", HubMaintenanceType, time.Now(), "Cannot decode the broker message ...", err))
+		}
+		return
+	}
+
+	if item.Origin == h.instanceId {
+		return
+	}
+	h.deliver(item.Room, item.Message)
+}
+
+// deliver - queue the message for the local members of the room
+// A member whose queue is full is a slow consumer, it leaves the hub and it is disconnected, so it blocks no other member
+func (h *Hub) deliver(room string, msg Message) {
+	var slow []Client
+
+	h.lock.RLock()
+	for clientId, client := range h.rooms[room] {
+		item, ok := h.senders[clientId]
+		if !ok {
+			continue
+		}
+		select {
+		case item.queue <- msg:
+		default:
+			slow = append(slow, client)
+		}
+	}
+	h.lock.RUnlock()
+
+	for _, client := range slow {
+		h.LeaveAll(client)
+		if c, ok := client.(disconnecter); ok {
+			go c.disconnect()
+		}
+
+		l, _ := logger.GetManager().GetLogger()
+		if l != nil {
+			l.Log(types.NewLogObject(types.WARNING, "http.Hub.deliver", HubMaintenanceType, time.Now(), "The slow client is disconnected from the hub ...", map[string]interface{}{"hub": h.name, "client": client.ID()}))
+		}
+	}
+}
+
+// send - send the queued messages to the client until it leaves the hub, a client that fails leaves the hub
+func (h *Hub) send(client Client, item *sender) {
+	for {
+		select {
+		case <-item.stop:
+			return
+		case msg := <-item.queue:
+			if err := client.Send(msg); err != nil {
+				h.LeaveAll(client)
+				return
+			}
+		}
+	}
+}
+
+// generateID - generate a random identifier
+func generateID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,186 @@
+package realtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"golang.org/x/net/websocket"
+)
+
+type fakeClient struct {
+	id       string
+	lock     sync.Mutex
+	messages []Message
+	done     chan struct{}
+}
+
+func newFakeClient(id string) *fakeClient {
+	return &fakeClient{id: id, done: make(chan struct{})}
+}
+
+func (c *fakeClient) ID() string { return c.id }
+
+func (c *fakeClient) Done() <-chan struct{} { return c.done }
+
+func (c *fakeClient) Send(msg Message) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+func (c *fakeClient) count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.messages)
+}
+
+// waitFor - wait until the condition is true, the messages are delivered to the clients in the background
+func waitFor(condition func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return condition()
+}
+
+// memoryBroker - an in-memory broker shared by hubs to simulate multiple instances
+type memoryBroker struct {
+	lock     sync.Mutex
+	handlers map[string][]func(payload []byte)
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+func (b *memoryBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.lock.Lock()
+	handlers := append([]func(payload []byte){}, b.handlers[channel]...)
+	b.lock.Unlock()
+
+	for _, h := range handlers {
+		h(payload)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (io.Closer, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.handlers == nil {
+		b.handlers = make(map[string][]func(payload []byte))
+	}
+	b.handlers[channel] = append(b.handlers[channel], handler)
+	return nopCloser{}, nil
+}
+
+func TestHub_BroadcastToRoom(t *testing.T) {
+	hub := NewHub("chat")
+	c1 := newFakeClient("c1")
+	c2 := newFakeClient("c2")
+
+	hub.Join("room1", c1)
+	hub.Join("room2", c2)
+
+	_ = hub.Broadcast(context.Background(), "room1", Message{Data: []byte("hi")})
+
+	if !waitFor(func() bool { return c1.count() == 1 }) {
+		t.Errorf("Member messages --> Expected: %v, but got %v", 1, c1.count())
+	}
+	if c2.count() != 0 {
+		t.Errorf("Non-member messages --> Expected: %v, but got %v", 0, c2.count())
+	}
+
+	hub.LeaveAll(c1)
+	if hub.Members("room1") != 0 {
+		t.Errorf("Members after leave --> Expected: %v, but got %v", 0, hub.Members("room1"))
+	}
+}
+
+func TestHub_BrokerFanOut(t *testing.T) {
+	broker := &memoryBroker{}
+	hub1 := NewHub("chat")
+	hub2 := NewHub("chat")
+	_ = hub1.UseBroker(context.Background(), broker)
+	_ = hub2.UseBroker(context.Background(), broker)
+
+	c1 := newFakeClient("c1")
+	c2 := newFakeClient("c2")
+	hub1.Join("room", c1)
+	hub2.Join("room", c2)
+
+	err := hub1.Broadcast(context.Background(), "room", Message{Data: []byte("hi")})
+	if err != nil {
+		t.Fatalf("Broadcast --> Expected: %v, but got %v", nil, err)
+	}
+
+	if !waitFor(func() bool { return c1.count() == 1 }) {
+		t.Errorf("Local member messages --> Expected: %v, but got %v", 1, c1.count())
+	}
+	if !waitFor(func() bool { return c2.count() == 1 }) {
+		t.Errorf("Remote member messages --> Expected: %v, but got %v", 1, c2.count())
+	}
+}
+
+func TestHub_SlowConsumer(t *testing.T) {
+	_ = config.CreateManager("../../..", "test", "Gonyx")
+
+	hub := NewHub("chat")
+	hub.queueSize = 4
+
+	joined := make(chan *Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, WebSocketOptions{})
+		if err != nil {
+			return
+		}
+		hub.Join("room", conn)
+		joined <- conn
+		<-conn.Done()
+	}))
+	defer server.Close()
+
+	// the client never reads, so the writes to it are blocked once the socket buffers are full
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial --> Expected: %v, but got %v", nil, err)
+	}
+	defer ws.Close()
+	slow := <-joined
+
+	fast := newFakeClient("fast")
+	hub.Join("room", fast)
+
+	data := make([]byte, 256*1024)
+	for i := 1; ; i++ {
+		if i > 1000 {
+			t.Fatalf("Slow client --> Expected to be disconnected, but it is still a member")
+		}
+		_ = hub.Broadcast(context.Background(), "room", Message{Data: data, Binary: true})
+		if !waitFor(func() bool { return fast.count() == i }) {
+			t.Fatalf("Fast client messages --> Expected: %v, but got %v", i, fast.count())
+		}
+
+		select {
+		case <-slow.Done():
+		default:
+			continue
+		}
+		break
+	}
+
+	if hub.Members("room") != 1 {
+		t.Errorf("Members after the slow client --> Expected: %v, but got %v", 1, hub.Members("room"))
+	}
+}
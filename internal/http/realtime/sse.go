@@ -0,0 +1,211 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+)
+
+// MARK: Options
+
+// SSEOptions - runtime options of a server-sent events route
+type SSEOptions struct {
+	HeartbeatInterval time.Duration
+	Retry             time.Duration
+	WriteTimeout      time.Duration
+}
+
+// NewSSEOptions - create server-sent events options from the server config
+func NewSSEOptions(config types.SSEConfig) SSEOptions {
+	return SSEOptions{
+		HeartbeatInterval: time.Duration(config.HeartbeatInterval) * time.Millisecond,
+		Retry:             time.Duration(config.Retry) * time.Millisecond,
+		WriteTimeout:      time.Duration(config.WriteTimeout) * time.Millisecond,
+	}
+}
+
+// MARK: SSEStream
+
+// SSEStream - a server-sent events stream to a single client
+type SSEStream struct {
+	id          string
+	writer      http.ResponseWriter
+	controller  *http.ResponseController
+	request     *http.Request
+	lastEventID string
+	options     SSEOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeLock sync.Mutex
+	values    sync.Map
+}
+
+// NewSSEStream - write the event stream headers and return the stream
+func NewSSEStream(w http.ResponseWriter, r *http.Request, options SSEOptions) (*SSEStream, error) {
+	if _, ok := w.(http.Flusher); !ok {
+		return nil, NewStreamingNotSupportedErr()
+	}
+
+	controller := http.NewResponseController(w)
+	// streams are long-lived, so the server write timeout must not be applied
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	s := &SSEStream{
+		id:          generateID(),
+		writer:      w,
+		controller:  controller,
+		request:     r,
+		lastEventID: r.Header.Get("Last-Event-ID"),
+		options:     options,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	if options.Retry > 0 {
+		if err := s.write(fmt.Sprintf("retry: %d\n\n", options.Retry.Milliseconds())); err != nil {
+			s.Close()
+			return nil, err
+		}
+	} else if err := s.write(":ok\n\n"); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	if options.HeartbeatInterval > 0 {
+		go s.heartbeatLoop()
+	}
+
+	return s, nil
+}
+
+// ID - return unique identifier of the stream
+func (s *SSEStream) ID() string {
+	return s.id
+}
+
+// Context - return the context of the stream which is cancelled when the stream is closed
+func (s *SSEStream) Context() context.Context {
+	return s.ctx
+}
+
+// Done - return a channel that is closed when the stream is closed
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Request - return the http request of the stream
+func (s *SSEStream) Request() *http.Request {
+	return s.request
+}
+
+// LastEventID - return the `Last-Event-ID` sent by the client on reconnect
+func (s *SSEStream) LastEventID() string {
+	return s.lastEventID
+}
+
+// Set - store a value on the stream
+func (s *SSEStream) Set(key string, value any) {
+	s.values.Store(key, value)
+}
+
+// Get - return the value stored on the stream
+func (s *SSEStream) Get(key string) (any, bool) {
+	return s.values.Load(key)
+}
+
+// SendEvent - write an event with optional name and id to the client
+func (s *SSEStream) SendEvent(event string, id string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + sanitizeField(id) + "\n")
+	}
+	if event != "" {
+		b.WriteString("event: " + sanitizeField(event) + "\n")
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// Send - deliver a hub message to the stream
+func (s *SSEStream) Send(msg Message) error {
+	return s.SendEvent(msg.Event, "", msg.Data)
+}
+
+// Close - end the stream, it waits for the in-flight write so no write happens after the handler returns
+func (s *SSEStream) Close() {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.cancel()
+}
+
+// shutdownLive - end the stream because the server is going away
+func (s *SSEStream) shutdownLive() {
+	s.Close()
+}
+
+// disconnect - end the stream of a slow consumer, the deadline fails the blocked write so the stream can be closed
+func (s *SSEStream) disconnect() {
+	_ = s.controller.SetWriteDeadline(time.Now())
+	s.Close()
+}
+
+// heartbeatLoop - write comment lines periodically to keep the stream alive behind proxies
+func (s *SSEStream) heartbeatLoop() {
+	ticker := time.NewTicker(s.options.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.write(":heartbeat\n\n"); err != nil {
+				s.Close()
+				return
+			}
+		}
+	}
+}
+
+// write - write the raw chunk and flush it
+func (s *SSEStream) write(chunk string) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if s.ctx.Err() != nil {
+		return NewClosedConnectionErr(s.id)
+	}
+
+	if s.options.WriteTimeout > 0 {
+		_ = s.controller.SetWriteDeadline(time.Now().Add(s.options.WriteTimeout))
+	}
+	if _, err := s.writer.Write([]byte(chunk)); err != nil {
+		return err
+	}
+	return s.controller.Flush()
+}
+
+// sanitizeField - remove new lines from the single line fields
+func sanitizeField(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,59 @@
+package realtime
+
+import "sync"
+
+// liveConnection - a connection that must be closed when the server stops
+type liveConnection interface {
+	ID() string
+	shutdownLive()
+}
+
+// Tracker - keep track of live websocket and server-sent events connections of a server
+type Tracker struct {
+	lock        sync.Mutex
+	connections map[string]liveConnection
+}
+
+// NewTracker - create a new connection tracker
+func NewTracker() *Tracker {
+	return &Tracker{connections: make(map[string]liveConnection)}
+}
+
+// Add - track the connection
+func (t *Tracker) Add(c liveConnection) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.connections[c.ID()] = c
+}
+
+// Remove - stop tracking the connection
+func (t *Tracker) Remove(c liveConnection) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.connections, c.ID())
+}
+
+// Count - return number of live connections
+func (t *Tracker) Count() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.connections)
+}
+
+// CloseAll - close all live connections
+func (t *Tracker) CloseAll() {
+	t.lock.Lock()
+	items := make([]liveConnection, 0, len(t.connections))
+	for _, c := range t.connections {
+		items = append(items, c)
+	}
+	t.connections = make(map[string]liveConnection)
+	t.lock.Unlock()
+
+	for _, c := range items {
+		c.shutdownLive()
+	}
+}
@@ -0,0 +1,575 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+)
+
+// MARK: Constants
+
+// MessageType - type of the websocket data message
+type MessageType int
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// Close codes defined in RFC 6455, section 7.4.1
+const (
+	CloseNormalClosure   = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+
+	maxControlPayload = 125
+	websocketGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// DefaultReadLimit - the maximum size of a message when the read limit is not configured
+const DefaultReadLimit = 1 << 20
+
+// MARK: Options
+
+// WebSocketOptions - runtime options of a websocket route
+type WebSocketOptions struct {
+	ReadLimit      int64 // the maximum size of a message in bytes, DefaultReadLimit is used if it is not positive
+	ReadBufferSize int
+	WriteTimeout   time.Duration
+	PingInterval   time.Duration
+	PongTimeout    time.Duration
+	AllowedOrigins []string
+	Subprotocols   []string
+}
+
+// NewWebSocketOptions - create websocket options from the server config
+func NewWebSocketOptions(config types.WebSocketConfig) WebSocketOptions {
+	return WebSocketOptions{
+		ReadLimit:      config.ReadLimit,
+		ReadBufferSize: config.ReadBufferSize,
+		WriteTimeout:   time.Duration(config.WriteTimeout) * time.Millisecond,
+		PingInterval:   time.Duration(config.PingInterval) * time.Millisecond,
+		PongTimeout:    time.Duration(config.PongTimeout) * time.Millisecond,
+		AllowedOrigins: config.AllowedOrigins,
+		Subprotocols:   config.Subprotocols,
+	}
+}
+
+// MARK: Conn
+
+// Conn - a server side websocket connection
+type Conn struct {
+	id          string
+	conn        net.Conn
+	reader      *bufio.Reader
+	request     *http.Request
+	subprotocol string
+	options     WebSocketOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeLock sync.Mutex
+	closeOnce sync.Once
+	values    sync.Map
+}
+
+// Upgrade - upgrade the http request to a websocket connection, on failure the error response is written to the client
+func Upgrade(w http.ResponseWriter, r *http.Request, options WebSocketOptions) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		return nil, rejectHandshake(w, http.StatusMethodNotAllowed, "request method must be GET")
+	}
+	if !headerContainsToken(r.Header, "Connection", "upgrade") {
+		return nil, rejectHandshake(w, http.StatusBadRequest, "`Connection` header must contain `upgrade`")
+	}
+	if !headerContainsToken(r.Header, "Upgrade", "websocket") {
+		return nil, rejectHandshake(w, http.StatusBadRequest, "`Upgrade` header must be `websocket`")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, rejectHandshake(w, http.StatusUpgradeRequired, "unsupported websocket version")
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, rejectHandshake(w, http.StatusBadRequest, "invalid `Sec-WebSocket-Key` header")
+	}
+
+	if !checkOrigin(r, options.AllowedOrigins) {
+		return nil, rejectHandshake(w, http.StatusForbidden, "origin is not allowed")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, rejectHandshake(w, http.StatusInternalServerError, "response writer does not support hijacking")
+	}
+
+	subprotocol := selectSubprotocol(r, options.Subprotocols)
+
+	netConn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, NewHandshakeErr(err.Error())
+	}
+
+	// clear the deadlines which are set by the http server for the request
+	_ = netConn.SetDeadline(time.Time{})
+
+	var response strings.Builder
+	response.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	response.WriteString("Sec-WebSocket-Accept: " + computeAcceptKey(key) + "\r\n")
+	if subprotocol != "" {
+		response.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+	response.WriteString("\r\n")
+
+	if options.WriteTimeout > 0 {
+		_ = netConn.SetWriteDeadline(time.Now().Add(options.WriteTimeout))
+	}
+	if _, err := netConn.Write([]byte(response.String())); err != nil {
+		_ = netConn.Close()
+		return nil, NewHandshakeErr(err.Error())
+	}
+	_ = netConn.SetWriteDeadline(time.Time{})
+
+	reader := brw.Reader
+	if options.ReadBufferSize > 0 && reader.Size() < options.ReadBufferSize {
+		reader = bufio.NewReaderSize(io.MultiReader(io.LimitReader(brw.Reader, int64(brw.Reader.Buffered())), netConn), options.ReadBufferSize)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	c := &Conn{
+		id:          generateID(),
+		conn:        netConn,
+		reader:      reader,
+		request:     r,
+		subprotocol: subprotocol,
+		options:     options,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	c.extendReadDeadline()
+	if options.PingInterval > 0 {
+		go c.pingLoop()
+	}
+
+	return c, nil
+}
+
+// ID - return unique identifier of the connection
+func (c *Conn) ID() string {
+	return c.id
+}
+
+// Context - return the context of the connection which is cancelled when the connection is closed
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Done - return a channel that is closed when the connection is closed
+func (c *Conn) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// Request - return the http request that was upgraded
+func (c *Conn) Request() *http.Request {
+	return c.request
+}
+
+// Subprotocol - return the negotiated subprotocol
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// RemoteAddr - return the remote network address
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Set - store a value on the connection
+func (c *Conn) Set(key string, value any) {
+	c.values.Store(key, value)
+}
+
+// Get - return the value stored on the connection
+func (c *Conn) Get(key string) (any, bool) {
+	return c.values.Load(key)
+}
+
+// ReadMessage - read the next data message, control frames are handled while reading
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	var messageType MessageType
+	var payload []byte
+
+	for {
+		fin, opcode, data, err := c.readFrame()
+		if err != nil {
+			return 0, nil, c.failRead(err)
+		}
+		c.extendReadDeadline()
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, data); err != nil {
+				return 0, nil, c.failRead(err)
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			code, reason := parseClosePayload(data)
+			replyCode := code
+			if replyCode == CloseNoStatus {
+				replyCode = CloseNormalClosure
+			}
+			_ = c.writeFrame(opClose, closePayload(replyCode, ""))
+			c.shutdown()
+			return 0, nil, NewCloseErr(code, reason)
+		case opText, opBinary:
+			if messageType != 0 {
+				return 0, nil, c.failRead(NewProtocolErr("new data frame before the end of fragmented message"))
+			}
+			messageType = MessageType(opcode)
+		case opContinuation:
+			if messageType == 0 {
+				return 0, nil, c.failRead(NewProtocolErr("continuation frame without a started message"))
+			}
+		default:
+			return 0, nil, c.failRead(NewProtocolErr("unknown opcode"))
+		}
+
+		if int64(len(payload)+len(data)) > c.readLimit() {
+			return 0, nil, c.failRead(NewReadLimitErr(c.readLimit()))
+		}
+		payload = append(payload, data...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(payload) {
+				return 0, nil, c.failRead(NewProtocolErr("invalid utf-8 in text message"))
+			}
+			return messageType, payload, nil
+		}
+	}
+}
+
+// ReadJSON - read the next message and unmarshal it into v
+func (c *Conn) ReadJSON(v any) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// WriteMessage - write a data message to the peer
+func (c *Conn) WriteMessage(messageType MessageType, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return NewProtocolErr("invalid message type")
+	}
+	return c.writeFrame(byte(messageType), data)
+}
+
+// WriteJSON - marshal v and write it as a text message
+func (c *Conn) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, data)
+}
+
+// Send - deliver a hub message to the connection
+func (c *Conn) Send(msg Message) error {
+	if msg.Binary {
+		return c.WriteMessage(BinaryMessage, msg.Data)
+	}
+	return c.WriteMessage(TextMessage, msg.Data)
+}
+
+// Ping - send a ping control frame to the peer
+func (c *Conn) Ping(data []byte) error {
+	if len(data) > maxControlPayload {
+		return NewProtocolErr("control frame payload is too large")
+	}
+	return c.writeFrame(opPing, data)
+}
+
+// Close - close the connection with the normal closure status
+func (c *Conn) Close() error {
+	return c.CloseWithStatus(CloseNormalClosure, "")
+}
+
+// CloseWithStatus - send the close frame with the status code and close the connection
+func (c *Conn) CloseWithStatus(code int, reason string) error {
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+
+	var err error
+	if c.ctx.Err() == nil {
+		err = c.writeFrame(opClose, closePayload(code, reason))
+	}
+	c.shutdown()
+	return err
+}
+
+// shutdownLive - close the connection because the server is going away
+func (c *Conn) shutdownLive() {
+	_ = c.CloseWithStatus(CloseGoingAway, "server is shutting down")
+}
+
+// disconnect - close the connection of a slow consumer, the blocked write fails since the network connection is closed
+func (c *Conn) disconnect() {
+	c.shutdown()
+}
+
+// shutdown - close the network connection and cancel the context
+func (c *Conn) shutdown() {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		_ = c.conn.Close()
+	})
+}
+
+// failRead - close the connection based on the read error and return the error
+func (c *Conn) failRead(err error) error {
+	switch err.(type) {
+	case *ProtocolErr:
+		_ = c.CloseWithStatus(CloseProtocolError, "")
+	case *ReadLimitErr:
+		_ = c.CloseWithStatus(CloseMessageTooBig, "")
+	default:
+		c.shutdown()
+	}
+	return err
+}
+
+// extendReadDeadline - move the read deadline forward if pong timeout is configured
+func (c *Conn) extendReadDeadline() {
+	if c.options.PongTimeout > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.options.PingInterval + c.options.PongTimeout))
+	}
+}
+
+// pingLoop - send ping frames periodically until the connection is closed
+func (c *Conn) pingLoop() {
+	ticker := time.NewTicker(c.options.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeFrame(opPing, nil); err != nil {
+				c.shutdown()
+				return
+			}
+		}
+	}
+}
+
+// readLimit - return the maximum size of a message
+func (c *Conn) readLimit() int64 {
+	if c.options.ReadLimit > 0 {
+		return c.options.ReadLimit
+	}
+	return DefaultReadLimit
+}
+
+// readFrame - read a single frame from the connection
+func (c *Conn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, NewProtocolErr("reserved bits must be zero")
+	}
+	opcode := header[0] & 0x0F
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, NewProtocolErr("client frames must be masked")
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if opcode >= opClose {
+		if !fin {
+			return false, 0, nil, NewProtocolErr("control frames must not be fragmented")
+		}
+		if length > maxControlPayload {
+			return false, 0, nil, NewProtocolErr("control frame payload is too large")
+		}
+	} else if length&(1<<63) != 0 || length > uint64(c.readLimit()) {
+		// the length is checked before the payload is allocated, the most significant bit must be zero
+		return false, 0, nil, NewReadLimitErr(c.readLimit())
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, data); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range data {
+		data[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, data, nil
+}
+
+// writeFrame - write a single unmasked frame to the connection
+func (c *Conn) writeFrame(opcode byte, data []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.ctx.Err() != nil {
+		return NewClosedConnectionErr(c.id)
+	}
+
+	frame := make([]byte, 0, len(data)+10)
+	frame = append(frame, 0x80|opcode)
+	switch {
+	case len(data) <= 125:
+		frame = append(frame, byte(len(data)))
+	case len(data) <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(data)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(data)))
+	}
+	frame = append(frame, data...)
+
+	if c.options.WriteTimeout > 0 {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.options.WriteTimeout))
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// MARK: Helpers
+
+// rejectHandshake - write the error response and return the handshake error
+func rejectHandshake(w http.ResponseWriter, status int, reason string) error {
+	http.Error(w, http.StatusText(status), status)
+	return NewHandshakeErr(reason)
+}
+
+// headerContainsToken - check whether the comma separated header contains the token
+func headerContainsToken(header http.Header, name string, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkOrigin - same origin is always allowed, others must be in the allowed list ("*" allows all)
+func checkOrigin(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, item := range allowedOrigins {
+		if item == "*" || strings.EqualFold(item, origin) {
+			return true
+		}
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// selectSubprotocol - select the first client subprotocol supported by the server
+func selectSubprotocol(r *http.Request, supported []string) string {
+	for _, value := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			for _, s := range supported {
+				if s == item {
+					return item
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// computeAcceptKey - compute the `Sec-WebSocket-Accept` header value
+func computeAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// closePayload - build the payload of the close frame
+func closePayload(code int, reason string) []byte {
+	if code == CloseNoStatus {
+		return nil
+	}
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	return append(payload, reason...)
+}
+
+// parseClosePayload - parse the status code and reason of the close frame
+func parseClosePayload(data []byte) (int, string) {
+	if len(data) < 2 {
+		return CloseNoStatus, ""
+	}
+	return int(binary.BigEndian.Uint16(data[:2])), string(data[2:])
+}
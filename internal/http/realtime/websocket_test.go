@@ -0,0 +1,195 @@
+package realtime
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func newEchoServer(t *testing.T, options WebSocketOptions) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, options)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestUpgrade_Echo(t *testing.T) {
+	server := newEchoServer(t, WebSocketOptions{})
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := websocket.Dial(url, "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial websocket --> Expected: %v, but got %v", nil, err)
+	}
+	defer client.Close()
+
+	if err := websocket.Message.Send(client, "hello"); err != nil {
+		t.Fatalf("Send message --> Expected: %v, but got %v", nil, err)
+	}
+
+	var reply string
+	_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := websocket.Message.Receive(client, &reply); err != nil {
+		t.Fatalf("Receive message --> Expected: %v, but got %v", nil, err)
+	}
+	if reply != "hello" {
+		t.Errorf("Echo message --> Expected: %v, but got %v", "hello", reply)
+	}
+}
+
+func TestUpgrade_ReadLimit(t *testing.T) {
+	server := newEchoServer(t, WebSocketOptions{ReadLimit: 4})
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := websocket.Dial(url, "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial websocket --> Expected: %v, but got %v", nil, err)
+	}
+	defer client.Close()
+
+	_ = websocket.Message.Send(client, "too long message")
+
+	var reply string
+	_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := websocket.Message.Receive(client, &reply); err == nil {
+		t.Errorf("Receive after read limit --> Expected an error, but got message %v", reply)
+	}
+}
+
+func TestUpgrade_RejectForeignOrigin(t *testing.T) {
+	server := newEchoServer(t, WebSocketOptions{AllowedOrigins: []string{"https://allowed.example"}})
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	if _, err := websocket.Dial(url, "", "https://evil.example"); err == nil {
+		t.Errorf("Dial with foreign origin --> Expected an error, but got %v", nil)
+	}
+
+	client, err := websocket.Dial(url, "", "https://allowed.example")
+	if err != nil {
+		t.Fatalf("Dial with allowed origin --> Expected: %v, but got %v", nil, err)
+	}
+	_ = client.Close()
+}
+
+func TestUpgrade_NotWebSocketRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+
+	_, err := Upgrade(w, r, WebSocketOptions{})
+	if _, ok := err.(*HandshakeErr); !ok {
+		t.Errorf("Upgrade plain request --> Expected: %T, but got %v", &HandshakeErr{}, err)
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Upgrade plain request status --> Expected: %v, but got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestTracker_CloseAll(t *testing.T) {
+	tracker := NewTracker()
+	closed := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := NewSSEStream(w, r, SSEOptions{})
+		if err != nil {
+			return
+		}
+		tracker.Add(stream)
+		defer tracker.Remove(stream)
+
+		_ = stream.SendEvent("greeting", "1", []byte("hello\nworld"))
+		<-stream.Done()
+		close(closed)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Open stream --> Expected: %v, but got %v", nil, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("Stream content type --> Expected: %v, but got %v", "text/event-stream", resp.Header.Get("Content-Type"))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 6 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Read stream --> Expected: %v, but got %v", nil, err)
+		}
+		lines = append(lines, strings.TrimRight(line, "\n"))
+	}
+	expected := []string{":ok", "", "id: 1", "event: greeting", "data: hello", "data: world"}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Stream line %d --> Expected: %q, but got %q", i, expected[i], lines[i])
+		}
+	}
+
+	tracker.CloseAll()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Errorf("Close all streams --> Expected the handler to return")
+	}
+}
+
+func TestUpgrade_OversizedFrameLength(t *testing.T) {
+	server := newEchoServer(t, WebSocketOptions{})
+	defer server.Close()
+
+	for _, length := range []uint64{1 << 63, DefaultReadLimit + 1} {
+		conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+		if err != nil {
+			t.Fatalf("Dial --> Expected: %v, but got %v", nil, err)
+		}
+		_, _ = conn.Write([]byte("GET / HTTP/1.1\r\nHost: " + strings.TrimPrefix(server.URL, "http://") +
+			"\r\nConnection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"))
+		reader := bufio.NewReader(conn)
+		response, err := http.ReadResponse(reader, nil)
+		if err != nil || response.StatusCode != http.StatusSwitchingProtocols {
+			t.Fatalf("Handshake --> Expected: %v, but got %v %v", http.StatusSwitchingProtocols, response, err)
+		}
+
+		// only the header of the frame is sent, the server must not wait for or allocate the payload
+		frame := []byte{0x82, 0x80 | 127, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(frame[2:], length)
+		_, _ = conn.Write(append(frame, 1, 2, 3, 4))
+
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		header := make([]byte, 4)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			t.Fatalf("Close frame of length %v --> Expected: %v, but got %v", length, nil, err)
+		}
+		if header[0]&0x0F != opClose || binary.BigEndian.Uint16(header[2:]) != CloseMessageTooBig {
+			t.Errorf("Close frame of length %v --> Expected: %v, but got %v", length, CloseMessageTooBig, header)
+		}
+		_ = conn.Close()
+	}
+}
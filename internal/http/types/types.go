@@ -2,6 +2,8 @@ package types
 
 import (
 	"time"
+
+	"github.com/Blocktunium/gonyx/internal/listener"
 )
 
 // SwaggerConfig - defines the config for Swagger documentation.
@@ -70,22 +72,435 @@ type CorsMiddlewareConfig struct {
 	OptionsResponseStatusCode float64 `json:"options_response_status_code"`
 }
 
+// CompressMiddlewareConfig - defines the config for response compression middleware.
+type CompressMiddlewareConfig struct {
+	// Level is the compression level, zero means the default level of each encoding
+	Level int `json:"level"`
+
+	// MinLength is the minimum response size in bytes to be compressed
+	MinLength int `json:"min_length"`
+
+	// Encodings is the list of supported encodings in order of server preference (e.g. "zstd", "br", "gzip")
+	Encodings []string `json:"encodings"`
+
+	// ContentTypes is the list of compressible content types, a trailing "*" matches a prefix (e.g. "text/*")
+	ContentTypes []string `json:"content_types"`
+
+	// ExcludedPaths is the list of path prefixes that are never compressed
+	ExcludedPaths []string `json:"excluded_paths"`
+}
+
+// EtagMiddlewareConfig - defines the config for ETag and conditional request middleware.
+type EtagMiddlewareConfig struct {
+	// Weak generates weak validators (W/"...") instead of strong ones
+	Weak bool `json:"weak"`
+
+	// MaxBodySize is the maximum body size in bytes which is buffered to generate the validator (default: 1048576)
+	// A larger body is streamed without a validator
+	MaxBodySize int `json:"max_body_size"`
+}
+
+// ResponseCacheMiddlewareConfig - defines the config for response caching middleware.
+type ResponseCacheMiddlewareConfig struct {
+	// Store is the name of the cache connection (cache manager or registered store, "memory" for in-process)
+	Store string `json:"store"`
+
+	// TTL is the default time to live of the cached responses in seconds
+	TTL int `json:"ttl"`
+
+	// KeyPrefix is prepended to all cache keys
+	KeyPrefix string `json:"key_prefix"`
+
+	// VaryHeaders is the list of request headers that are part of the cache key
+	VaryHeaders []string `json:"vary_headers"`
+
+	// VaryQuery is the list of query parameters that are part of the cache key, empty means all of them
+	VaryQuery []string `json:"vary_query"`
+
+	// CacheCredentials caches the responses of the requests with the `Authorization` or `Cookie` header too,
+	// they are shared between the users unless the headers are in VaryHeaders
+	CacheCredentials bool `json:"cache_credentials"`
+
+	// Tags is the list of tags attached to the cached responses, used for invalidation
+	Tags []string `json:"tags"`
+
+	// StatusCodes is the list of cacheable status codes, empty means only 200
+	StatusCodes []int `json:"status_codes"`
+
+	// MaxBodySize is the maximum body size in bytes to be cached, zero means no limit
+	MaxBodySize int `json:"max_body_size"`
+
+	// Paths is the list of path prefixes to be cached, empty means all paths
+	Paths []string `json:"paths"`
+
+	// ExcludedPaths is the list of path prefixes that are never cached
+	ExcludedPaths []string `json:"excluded_paths"`
+}
+
+// SecurityHeadersMiddlewareConfig - defines the config for security headers middleware.
+// An empty value uses the default and "-" disables the header.
+type SecurityHeadersMiddlewareConfig struct {
+	// HSTSMaxAge is the max-age of Strict-Transport-Security in seconds, negative disables it (default: 1 year)
+	HSTSMaxAge int `json:"hsts_max_age"`
+
+	// HSTSIncludeSubdomains adds `includeSubDomains` to Strict-Transport-Security
+	HSTSIncludeSubdomains bool `json:"hsts_include_subdomains"`
+
+	// HSTSPreload adds `preload` to Strict-Transport-Security
+	HSTSPreload bool `json:"hsts_preload"`
+
+	// ContentSecurityPolicy is the value of Content-Security-Policy (default: not sent)
+	ContentSecurityPolicy string `json:"content_security_policy"`
+
+	// CSPReportOnly sends the policy as Content-Security-Policy-Report-Only
+	CSPReportOnly bool `json:"csp_report_only"`
+
+	// FrameOptions is the value of X-Frame-Options (default: DENY)
+	FrameOptions string `json:"frame_options"`
+
+	// ReferrerPolicy is the value of Referrer-Policy (default: strict-origin-when-cross-origin)
+	ReferrerPolicy string `json:"referrer_policy"`
+
+	// ContentTypeOptions is the value of X-Content-Type-Options (default: nosniff)
+	ContentTypeOptions string `json:"content_type_options"`
+
+	// CrossOriginOpenerPolicy is the value of Cross-Origin-Opener-Policy (default: same-origin)
+	CrossOriginOpenerPolicy string `json:"cross_origin_opener_policy"`
+
+	// PermissionsPolicy is the value of Permissions-Policy (default: not sent)
+	PermissionsPolicy string `json:"permissions_policy"`
+}
+
+// CsrfMiddlewareConfig - defines the config for CSRF middleware using double-submit cookies.
+type CsrfMiddlewareConfig struct {
+	// CookieName is the name of the token cookie (default: _csrf)
+	CookieName string `json:"cookie_name"`
+
+	// HeaderName is the request header carrying the token (default: X-CSRF-Token)
+	HeaderName string `json:"header_name"`
+
+	// FormField is the form field carrying the token (default: _csrf)
+	FormField string `json:"form_field"`
+
+	// CookiePath is the path of the token cookie (default: /)
+	CookiePath string `json:"cookie_path"`
+
+	// CookieDomain is the domain of the token cookie
+	CookieDomain string `json:"cookie_domain"`
+
+	// CookieSecure sets the Secure attribute of the token cookie
+	CookieSecure bool `json:"cookie_secure"`
+
+	// SameSite is the SameSite attribute of the token cookie: "lax", "strict" or "none" (default: lax)
+	SameSite string `json:"same_site"`
+
+	// MaxAge is the lifetime of the token cookie in seconds (default: 12 hours)
+	MaxAge int `json:"max_age"`
+
+	// ExcludedPaths is the list of path prefixes that are not protected, e.g. API routes using tokens
+	ExcludedPaths []string `json:"excluded_paths"`
+}
+
+// TimeoutMiddlewareConfig - defines the config for request timeout middleware.
+type TimeoutMiddlewareConfig struct {
+	// Timeout is the default timeout of the requests in milliseconds, zero means no timeout
+	Timeout int `json:"timeout"`
+
+	// Routes overrides the timeout in milliseconds by the route path (e.g. "/v1/reports/:id") or "<METHOD> <path>"
+	Routes map[string]int `json:"routes"`
+
+	// ExcludedPaths is the list of path prefixes without timeout, e.g. websocket and streaming routes
+	ExcludedPaths []string `json:"excluded_paths"`
+}
+
+// LimitsMiddlewareConfig - defines the config for request size limits middleware.
+type LimitsMiddlewareConfig struct {
+	// MaxBodySize is the maximum size of the request body in bytes (default: `conf.body_limit`)
+	MaxBodySize int64 `json:"max_body_size"`
+
+	// MaxHeaderSize is the maximum total size of the request headers in bytes, zero means no limit
+	MaxHeaderSize int `json:"max_header_size"`
+
+	// MaxURLLength is the maximum length of the request URI, zero means no limit
+	MaxURLLength int `json:"max_url_length"`
+}
+
+// I18nMiddlewareConfig - defines the config for the locale negotiation middleware.
+// The locale is taken from the query param, then the cookie and then the `Accept-Language` header.
+type I18nMiddlewareConfig struct {
+	QueryParam string `json:"query_param"` // e.g. "lang", empty means it is not used
+	Cookie     string `json:"cookie"`      // e.g. "lang", empty means it is not used
+}
+
+// TenancyMiddlewareConfig - defines the config for the tenant resolution middleware.
+// The strategies are tried in order until one of them resolves the tenant.
+type TenancyMiddlewareConfig struct {
+	// Strategies are "host", "header", "path" and "jwt" (default: ["header"])
+	Strategies []string `json:"strategies"`
+
+	// BaseDomain resolves the first label of a subdomain as the tenant if no tenant has the host, e.g. "example.com"
+	BaseDomain string `json:"base_domain"`
+
+	Header      string `json:"header"`       // default: "X-Tenant-ID"
+	PathSegment int    `json:"path_segment"` // the index of the path segment, e.g. 1 for "/t/acme/users"
+
+	// JWTSecret verifies the HS256, HS384 or HS512 signature of the bearer token, it is required by the jwt strategy
+	JWTSecret string `json:"jwt_secret"`
+	JWTClaim  string `json:"jwt_claim"` // default: "tenant"
+
+	// Required answers 400 to the requests without a tenant
+	Required bool `json:"required"`
+
+	// AllowUnknown accepts the tenants which are not defined in the tenancy config
+	AllowUnknown bool `json:"allow_unknown"`
+}
+
+// TLSConfig - defines the certificate of the https listener.
+type TLSConfig struct {
+	CertFile   string `json:"cert_file"`
+	KeyFile    string `json:"key_file"`
+	MinVersion string `json:"min_version"` // "1.2" or "1.3" (default: 1.2)
+}
+
+// HTTP2Config - defines the HTTP/2 tuning of the listener.
+type HTTP2Config struct {
+	// MaxConcurrentStreams is the number of concurrent streams per connection (default: 250)
+	MaxConcurrentStreams uint32 `json:"max_concurrent_streams"`
+
+	// MaxReadFrameSize is the largest frame in bytes that is read, between 16KB and 16MB
+	MaxReadFrameSize uint32 `json:"max_read_frame_size"`
+
+	// IdleTimeout closes the idle connections after the duration in milliseconds
+	IdleTimeout int `json:"idle_timeout"`
+}
+
+// HTTP3Config - defines the HTTP/3 (QUIC) listener running alongside the TLS listener.
+// gonyx ships no QUIC transport, the application registers one by `RegisterHTTP3ServerFactory` of pkg/http, e.g. a quic-go adapter.
+type HTTP3Config struct {
+	// Enabled starts the HTTP/3 listener, the server fails to start if no HTTP/3 implementation is registered
+	Enabled bool `json:"enabled"`
+
+	// Addr is the UDP address of the listener (default: the address of the server)
+	Addr string `json:"addr"`
+
+	// AltSvcMaxAge is the max age of the Alt-Svc advertisement in seconds (default: 24 hours)
+	AltSvcMaxAge int `json:"alt_svc_max_age"`
+}
+
+// ProtocolsConfig - defines the protocols served by the listener.
+type ProtocolsConfig struct {
+	// H2C serves HTTP/2 over cleartext, used for internal service traffic without TLS
+	H2C bool `json:"h2c"`
+
+	// DisableHTTP2 serves only HTTP/1.1 over TLS
+	DisableHTTP2 bool `json:"disable_http2"`
+
+	HTTP2 HTTP2Config `json:"http2"`
+	HTTP3 HTTP3Config `json:"http3"`
+}
+
+// VersionLifecycleConfig - defines the deprecation metadata of an api version.
+// The dates are in RFC 3339 format (e.g. "2025-01-01" or "2025-01-01T00:00:00Z").
+type VersionLifecycleConfig struct {
+	// Deprecation is the date since the version is deprecated, it is sent as the `Deprecation` header
+	Deprecation string `json:"deprecation"`
+
+	// Sunset is the date the version stops working, it is sent as the `Sunset` header
+	Sunset string `json:"sunset"`
+
+	// Link is the documentation of the deprecation or the migration guide, it is sent as a `Link` header
+	Link string `json:"link"`
+}
+
+// VersioningConfig - defines how the api version of a request is selected.
+type VersioningConfig struct {
+	// Strategy is one of "path" (e.g. "/v2/users"), "header" or "media_type" (default: path)
+	// A version in the path is always accepted, the other strategies select it for the paths without a version
+	Strategy string `json:"strategy"`
+
+	// Header is the request header of the header strategy (default: Accept-Version)
+	Header string `json:"header"`
+
+	// Vendor is the vendor of the media type strategy, e.g. "gonyx" for "application/vnd.gonyx.v2+json"
+	// If it is empty, any vendor is accepted
+	Vendor string `json:"vendor"`
+
+	// Default is the version of the requests which do not select one, if it is empty they are not changed
+	Default string `json:"default"`
+
+	// Lifecycle is the deprecation metadata by version
+	Lifecycle map[string]VersionLifecycleConfig `json:"lifecycle"`
+}
+
+// ProxyRewriteConfig - defines how the request path is changed before it is sent to the upstream.
+type ProxyRewriteConfig struct {
+	// StripPrefix is removed from the beginning of the path, e.g. "/api"
+	StripPrefix string `json:"strip_prefix"`
+
+	// AddPrefix is added to the beginning of the path after the prefix is stripped
+	AddPrefix string `json:"add_prefix"`
+
+	// Regex and Replacement replace the path at last, e.g. "^/users/(.*)$" and "/accounts/$1"
+	Regex       string `json:"regex"`
+	Replacement string `json:"replacement"`
+}
+
+// ProxyHealthCheckConfig - defines the active health check of the upstreams.
+// All durations are in milliseconds, the health check is disabled if the path is empty.
+type ProxyHealthCheckConfig struct {
+	Path     string `json:"path"`
+	Interval int    `json:"interval"` // default: 10 seconds
+	Timeout  int    `json:"timeout"`  // default: 2 seconds
+
+	// ExpectedStatus is the status of a healthy upstream, zero accepts any 2xx and 3xx status
+	ExpectedStatus int `json:"expected_status"`
+
+	// HealthyThreshold and UnhealthyThreshold are the consecutive results to change the state (default: 1 and 2)
+	HealthyThreshold   int `json:"healthy_threshold"`
+	UnhealthyThreshold int `json:"unhealthy_threshold"`
+}
+
+// ProxyRetryConfig - defines the retries of the idempotent requests on another upstream.
+// All durations are in milliseconds.
+type ProxyRetryConfig struct {
+	// Attempts is the number of retries after the first try, zero disables the retries
+	Attempts int `json:"attempts"`
+
+	// Backoff is the wait before the first retry, it is doubled on each retry up to MaxBackoff (default: 100 and 2000)
+	Backoff    int `json:"backoff"`
+	MaxBackoff int `json:"max_backoff"`
+
+	// Statuses are the upstream statuses which are retried (default: 502, 503 and 504)
+	Statuses []int `json:"statuses"`
+}
+
+// ProxyCircuitBreakerConfig - defines when an upstream stops receiving the requests after failures.
+type ProxyCircuitBreakerConfig struct {
+	// FailureThreshold is the consecutive failures which open the circuit, zero disables the circuit breaker
+	FailureThreshold int `json:"failure_threshold"`
+
+	// OpenTimeout is the duration in milliseconds before a trial request is sent again (default: 30 seconds)
+	OpenTimeout int `json:"open_timeout"`
+}
+
+// ProxyRouteConfig - defines a route which forwards the requests to a pool of upstreams.
+type ProxyRouteConfig struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`    // the route path, e.g. "/api/users/*path"
+	Methods  []string `json:"methods"` // default: the request methods of the server
+	Versions []string `json:"versions"`
+	Groups   []string `json:"groups"`
+
+	// Upstreams are the base urls of the pool, e.g. "http://10.0.0.1:8080"
+	Upstreams []string `json:"upstreams"`
+
+	// Balancer is "round_robin" or "least_conn" (default: round_robin)
+	Balancer string `json:"balancer"`
+
+	// Timeout is the duration in milliseconds of each try until the response headers, zero means no timeout
+	Timeout int `json:"timeout"`
+
+	// PreserveHost sends the Host header of the client instead of the host of the upstream
+	PreserveHost bool `json:"preserve_host"`
+
+	Rewrite              ProxyRewriteConfig        `json:"rewrite"`
+	RequestHeaders       map[string]string         `json:"request_headers"`
+	RemoveRequestHeaders []string                  `json:"remove_request_headers"`
+	ResponseHeaders      map[string]string         `json:"response_headers"`
+	HealthCheck          ProxyHealthCheckConfig    `json:"health_check"`
+	Retry                ProxyRetryConfig          `json:"retry"`
+	CircuitBreaker       ProxyCircuitBreakerConfig `json:"circuit_breaker"`
+}
+
+// StaticConfig - defines the static files of a server, they are served when `support_static` is enabled.
+type StaticConfig struct {
+	Prefix string `json:"prefix"` // default: "/static"
+	Root   string `json:"root"`   // the directory of the files, it is the directory in the embedded files if they are set
+
+	// Fingerprint serves the files by the urls with the hash of their content too, these urls are cached for a year
+	Fingerprint bool `json:"fingerprint"`
+
+	// MaxAge is the cache lifetime in seconds of the urls without the hash, zero means they are revalidated
+	MaxAge int `json:"max_age"`
+}
+
+// TemplatesConfig - defines the html templates of a server.
+// Every page under the root is parsed with all layouts and partials, so a page can override the blocks of a layout.
+type TemplatesConfig struct {
+	Enabled bool   `json:"enabled"`
+	Root    string `json:"root"` // the directory of the templates, it is the directory in the embedded files if they are set
+
+	// Layouts and Partials are the directories under the root which are shared by the pages (default: "layouts" and "partials")
+	Layouts  string `json:"layouts"`
+	Partials string `json:"partials"`
+
+	// Extension is the extension of the template files (default: ".html")
+	Extension string `json:"extension"`
+
+	// Reload parses the templates again when they are changed on the disk, it is always enabled in the dev env
+	Reload bool `json:"reload"`
+}
+
+// DownloadsConfig - defines the route which serves the objects of a storage backend by signed urls.
+type DownloadsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Prefix  string `json:"prefix"`  // default: "/files"
+	Backend string `json:"backend"` // the name of the storage backend, empty means the default backend
+
+	// Secret signs the urls, it is required when the downloads are enabled
+	Secret string `json:"secret"`
+
+	// TTL is the default lifetime of the urls in seconds (default: 3600)
+	TTL int `json:"ttl"`
+}
+
+// WebSocketConfig - defines the default options of the websocket routes on a server.
+// All durations are in milliseconds and zero disables the related feature.
+// ReadLimit is the maximum size of a message in bytes, zero uses the default of 1 MiB.
+type WebSocketConfig struct {
+	ReadLimit      int64    `json:"read_limit"`
+	ReadBufferSize int      `json:"read_buffer_size"`
+	WriteTimeout   int      `json:"write_timeout"`
+	PingInterval   int      `json:"ping_interval"`
+	PongTimeout    int      `json:"pong_timeout"`
+	AllowedOrigins []string `json:"allowed_origins"`
+	Subprotocols   []string `json:"subprotocols"`
+}
+
+// SSEConfig - defines the default options of the server-sent events routes on a server.
+// All durations are in milliseconds and zero disables the related feature.
+type SSEConfig struct {
+	HeartbeatInterval int `json:"heartbeat_interval"`
+	Retry             int `json:"retry"`
+	WriteTimeout      int `json:"write_timeout"`
+}
+
 type GinServerConfig struct {
-	ListenAddress string   `json:"addr"`
-	Name          string   `json:"name"`
-	Versions      []string `json:"versions"`
-	SupportStatic bool     `json:"support_static"`
+	ListenAddress string           `json:"addr"`
+	Name          string           `json:"name"`
+	Versions      []string         `json:"versions"`
+	Versioning    VersioningConfig `json:"versioning"`
+	SupportStatic bool             `json:"support_static"`
 	Config        struct {
-		ReadTimeout    time.Duration `json:"read_timeout"`
-		WriteTimeout   time.Duration `json:"write_timeout"`
-		RequestMethods []string      `json:"request_methods"`
+		ReadTimeout          time.Duration `json:"read_timeout"`
+		WriteTimeout         time.Duration `json:"write_timeout"`
+		RequestMethods       []string      `json:"request_methods"`
+		Etag                 bool          `json:"etag"`
+		CompressedFileSuffix string        `json:"compressed_file_suffix"`
+		BodyLimit            int64         `json:"body_limit"`
+		MaxHeaderBytes       int           `json:"max_header_bytes"`
 	} `json:"conf"`
 	Middlewares struct {
 		Order []string `json:"order"`
 	} `json:"middlewares"`
-	Static struct {
-		Prefix string `json:"prefix"`
-		Root   string `json:"root"`
-	} `json:"static"`
-	Swagger SwaggerConfig `json:"swagger"`
+	Static    StaticConfig          `json:"static"`
+	Templates TemplatesConfig       `json:"templates"`
+	Swagger   SwaggerConfig         `json:"swagger"`
+	WebSocket WebSocketConfig       `json:"websocket"`
+	SSE       SSEConfig             `json:"sse"`
+	TLS       TLSConfig             `json:"tls"`
+	Protocols ProtocolsConfig       `json:"protocols"`
+	Socket    listener.SocketConfig `json:"socket"`
+	Proxies   []ProxyRouteConfig    `json:"proxies"`
+	Downloads DownloadsConfig       `json:"downloads"`
 }
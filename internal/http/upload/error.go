@@ -0,0 +1,83 @@
+package upload
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// FileTooLargeErr Error
+type FileTooLargeErr struct {
+	FileName string
+	Limit    int64
+}
+
+// Error method - satisfying error interface
+func (err *FileTooLargeErr) Error() string {
+	return fmt.Sprintf("The upload `%v` is larger than %v bytes", err.FileName, err.Limit)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *FileTooLargeErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewFileTooLargeErr - return a new instance of FileTooLargeErr
+func NewFileTooLargeErr(fileName string, limit int64) error {
+	return &FileTooLargeErr{FileName: fileName, Limit: limit}
+}
+
+// FileTypeNotAllowedErr Error
+type FileTypeNotAllowedErr struct {
+	FileName    string
+	ContentType string
+}
+
+// Error method - satisfying error interface
+func (err *FileTypeNotAllowedErr) Error() string {
+	return fmt.Sprintf("The type `%v` of the upload `%v` is not allowed", err.ContentType, err.FileName)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *FileTypeNotAllowedErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewFileTypeNotAllowedErr - return a new instance of FileTypeNotAllowedErr
+func NewFileTypeNotAllowedErr(fileName string, contentType string) error {
+	return &FileTypeNotAllowedErr{FileName: fileName, ContentType: contentType}
+}
+
+// InvalidUploadErr Error - the request is not a valid upload
+type InvalidUploadErr struct {
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *InvalidUploadErr) Error() string {
+	return fmt.Sprintf("The upload request is not valid: %v", err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *InvalidUploadErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewInvalidUploadErr - return a new instance of InvalidUploadErr
+func NewInvalidUploadErr(reason string) error {
+	return &InvalidUploadErr{Reason: reason}
+}
+
+// StatusOf - return the http status which describes the upload error
+func StatusOf(err error) int {
+	switch err.(type) {
+	case *FileTooLargeErr:
+		return http.StatusRequestEntityTooLarge
+	case *FileTypeNotAllowedErr:
+		return http.StatusUnsupportedMediaType
+	case *InvalidUploadErr:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,264 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	defaultMaxFieldSize = 1 << 20
+	sniffSize           = 512
+)
+
+// MultipartOptions - options of receiving the files of a multipart request
+type MultipartOptions struct {
+	Backend storage.Backend
+
+	// KeyPrefix is added to the generated keys, e.g. "avatars/"
+	KeyPrefix string
+
+	// KeyFunc returns the key of a file, the default is the prefix, a random id and the extension of the file
+	KeyFunc func(fieldName string, fileName string) string
+
+	// MaxFileSize and MaxTotalSize are the limits in bytes of each file and of all files, zero means no limit
+	MaxFileSize  int64
+	MaxTotalSize int64
+
+	// MaxFiles is the number of accepted files, zero means no limit
+	MaxFiles int
+
+	// MaxFieldSize is the limit in bytes of each form field which is not a file (default: 1MB)
+	MaxFieldSize int64
+
+	// AllowedTypes are the media types detected from the content, e.g. "image/png" or "image/*"
+	AllowedTypes []string
+
+	// AllowedExtensions are the extensions of the file names, e.g. ".png"
+	AllowedExtensions []string
+}
+
+// UploadedFile - a file which is stored in the backend
+type UploadedFile struct {
+	FieldName string
+	FileName  string
+	storage.ObjectInfo
+}
+
+// MultipartResult - the files and the form fields of a multipart request
+type MultipartResult struct {
+	Files  []UploadedFile
+	Fields map[string][]string
+}
+
+// MARK: Public functions
+
+// ReceiveMultipart - stream the files of the multipart request to the backend while the limits are checked
+// The files are not buffered in memory or on the disk, if a file is rejected the stored files of the request are deleted
+func ReceiveMultipart(c *gin.Context, options MultipartOptions) (*MultipartResult, error) {
+	if options.Backend == nil {
+		return nil, errors.New("the storage backend of the upload is nil")
+	}
+	if options.MaxFieldSize <= 0 {
+		options.MaxFieldSize = defaultMaxFieldSize
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, NewInvalidUploadErr(err.Error())
+	}
+
+	ctx := c.Request.Context()
+	result := &MultipartResult{Fields: make(map[string][]string)}
+	stored := false
+	defer func() {
+		if !stored {
+			for _, item := range result.Files {
+				_ = options.Backend.Delete(context.Background(), item.Key)
+			}
+		}
+	}()
+
+	var total int64
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, NewInvalidUploadErr(err.Error())
+		}
+
+		if part.FileName() == "" {
+			data, err := io.ReadAll(io.LimitReader(part, options.MaxFieldSize+1))
+			_ = part.Close()
+			if err != nil {
+				return nil, NewInvalidUploadErr(err.Error())
+			}
+			if int64(len(data)) > options.MaxFieldSize {
+				return nil, NewInvalidUploadErr(fmt.Sprintf("the field `%v` is larger than %v bytes", part.FormName(), options.MaxFieldSize))
+			}
+			result.Fields[part.FormName()] = append(result.Fields[part.FormName()], string(data))
+			continue
+		}
+
+		if options.MaxFiles > 0 && len(result.Files) >= options.MaxFiles {
+			_ = part.Close()
+			return nil, NewInvalidUploadErr(fmt.Sprintf("more than %v files are uploaded", options.MaxFiles))
+		}
+
+		limit := options.MaxFileSize
+		if options.MaxTotalSize > 0 && (limit <= 0 || options.MaxTotalSize-total < limit) {
+			limit = options.MaxTotalSize - total
+		}
+
+		file, err := store(ctx, part, part.FormName(), part.FileName(), limit, options)
+		_ = part.Close()
+		if err != nil {
+			return nil, err
+		}
+		total += file.Size
+		result.Files = append(result.Files, file)
+	}
+
+	stored = true
+	return result, nil
+}
+
+// AbortWithError - answer the request with the status of the upload error
+func AbortWithError(c *gin.Context, err error) {
+	status := StatusOf(err)
+	c.AbortWithStatusJSON(status, middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: err.Error(),
+	})
+}
+
+// MARK: Private functions
+
+// store - check the type of the content and stream it to the backend with its checksum
+func store(ctx context.Context, r io.Reader, fieldName string, fileName string, limit int64, options MultipartOptions) (UploadedFile, error) {
+	extension := strings.ToLower(path.Ext(fileName))
+	if len(options.AllowedExtensions) > 0 && !containsFold(options.AllowedExtensions, extension) {
+		return UploadedFile{}, NewFileTypeNotAllowedErr(fileName, extension)
+	}
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return UploadedFile{}, NewInvalidUploadErr(err.Error())
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !isTypeAllowed(contentType, options.AllowedTypes) {
+		return UploadedFile{}, NewFileTypeNotAllowedErr(fileName, contentType)
+	}
+	if strings.HasPrefix(contentType, "application/octet-stream") {
+		// the content is not recognized, so the extension tells the type
+		if byExtension := mime.TypeByExtension(extension); byExtension != "" {
+			contentType = byExtension
+		}
+	}
+
+	key := options.KeyPrefix + randomID() + extension
+	if options.KeyFunc != nil {
+		key = options.KeyFunc(fieldName, fileName)
+	}
+
+	var body io.Reader = io.MultiReader(bytes.NewReader(head), r)
+	if limit > 0 {
+		body = &limitedReader{r: body, remaining: limit, limit: limit, fileName: fileName}
+	}
+	hash := sha256.New()
+
+	info, err := options.Backend.Put(ctx, key, io.TeeReader(body, hash), storage.PutOptions{
+		ContentType: contentType,
+		Metadata:    map[string]string{"filename": fileName},
+	})
+	if err != nil {
+		var tooLargeErr *FileTooLargeErr
+		if errors.As(err, &tooLargeErr) {
+			return UploadedFile{}, tooLargeErr
+		}
+		return UploadedFile{}, err
+	}
+
+	info.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return UploadedFile{FieldName: fieldName, FileName: fileName, ObjectInfo: info}, nil
+}
+
+// isTypeAllowed - check the media type against the allowed types, "image/*" allows all images
+func isTypeAllowed(contentType string, allowedTypes []string) bool {
+	if len(allowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, item := range allowedTypes {
+		if item == mediaType || item == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(item, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(item, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold - check whether the list contains the value case-insensitively
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// randomID - return a random hex id
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// limitedReader - fail with FileTooLargeErr when the content is larger than the limit
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+	fileName  string
+}
+
+// Read - read up to one byte more than the remaining size to detect the exceeded limit
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		return 0, NewFileTooLargeErr(l.fileName, l.limit)
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,399 @@
+package upload
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	TusVersion        = "1.0.0"
+	tusExtensions     = "creation,termination"
+	offsetContentType = "application/offset+octet-stream"
+)
+
+// uploadIDPattern - the ids generated by randomID, other values are rejected before touching the staging directory
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// ResumableOptions - options of the resumable uploads, following the tus 1.0 protocol (core, creation and termination)
+type ResumableOptions struct {
+	Backend storage.Backend
+
+	// StagingDir keeps the chunks until the upload is complete (default: "gonyx-uploads" in the temp directory)
+	StagingDir string
+
+	// KeyPrefix is added to the generated keys, e.g. "videos/"
+	KeyPrefix string
+
+	// KeyFunc returns the key of the completed upload by the file name of the `Upload-Metadata` header
+	KeyFunc func(fileName string) string
+
+	// MaxSize is the limit in bytes of an upload, zero means no limit
+	MaxSize int64
+
+	// AllowedTypes are the media types detected from the content when the upload is complete, e.g. "video/*"
+	AllowedTypes []string
+
+	// OnComplete is called after the upload is stored in the backend
+	OnComplete func(c *gin.Context, file UploadedFile) error
+}
+
+// resumableState - the progress of an upload, kept beside its chunks
+type resumableState struct {
+	ID        string            `json:"id"`
+	Length    int64             `json:"length"`
+	Offset    int64             `json:"offset"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Key       string            `json:"key,omitempty"` // set when the upload is stored in the backend
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Resumable - the handlers of the resumable uploads
+type Resumable struct {
+	options ResumableOptions
+	lock    sync.Mutex
+	busy    map[string]struct{} // the uploads which are receiving a chunk
+}
+
+// MARK: Public functions
+
+// NewResumable - create the handlers and the staging directory
+func NewResumable(options ResumableOptions) (*Resumable, error) {
+	if options.Backend == nil {
+		return nil, errors.New("the storage backend of the upload is nil")
+	}
+	if options.StagingDir == "" {
+		options.StagingDir = filepath.Join(os.TempDir(), "gonyx-uploads")
+	}
+	if err := os.MkdirAll(options.StagingDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Resumable{options: options, busy: make(map[string]struct{})}, nil
+}
+
+// Options - describe the supported protocol and extensions
+func (u *Resumable) Options(c *gin.Context) {
+	c.Header("Tus-Resumable", TusVersion)
+	c.Header("Tus-Version", TusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	if u.options.MaxSize > 0 {
+		c.Header("Tus-Max-Size", strconv.FormatInt(u.options.MaxSize, 10))
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Create - start an upload of `Upload-Length` bytes, the url of the upload is returned in the Location header
+func (u *Resumable) Create(c *gin.Context) {
+	if !u.checkVersion(c) {
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		abort(c, http.StatusBadRequest, "The Upload-Length header is not valid")
+		return
+	}
+	if u.options.MaxSize > 0 && length > u.options.MaxSize {
+		abort(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("The upload is larger than %v bytes", u.options.MaxSize))
+		return
+	}
+
+	state := &resumableState{
+		ID:        randomID(),
+		Length:    length,
+		Metadata:  parseMetadata(c.GetHeader("Upload-Metadata")),
+		CreatedAt: time.Now(),
+	}
+	if err := os.WriteFile(u.dataPath(state.ID), nil, 0o644); err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := u.saveState(state); err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if length == 0 {
+		if !u.complete(c, state) {
+			return
+		}
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+state.ID)
+	c.Header("Upload-Offset", strconv.FormatInt(state.Offset, 10))
+	c.Status(http.StatusCreated)
+}
+
+// Head - return the offset of the upload to resume it
+func (u *Resumable) Head(c *gin.Context) {
+	c.Header("Tus-Resumable", TusVersion)
+	c.Header("Cache-Control", "no-store")
+
+	state, ok := u.loadState(c)
+	if !ok {
+		return
+	}
+	c.Header("Upload-Offset", strconv.FormatInt(state.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(state.Length, 10))
+	c.Status(http.StatusOK)
+}
+
+// Patch - append the chunk at `Upload-Offset`, the upload is stored in the backend after its last chunk
+func (u *Resumable) Patch(c *gin.Context) {
+	if !u.checkVersion(c) {
+		return
+	}
+	if c.ContentType() != offsetContentType {
+		abort(c, http.StatusUnsupportedMediaType, "The Content-Type must be "+offsetContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		abort(c, http.StatusBadRequest, "The Upload-Offset header is not valid")
+		return
+	}
+
+	id := c.Param("id")
+	if !u.acquire(id) {
+		abort(c, http.StatusLocked, "Another chunk of the upload is being received")
+		return
+	}
+	defer u.release(id)
+
+	state, ok := u.loadState(c)
+	if !ok {
+		return
+	}
+	if offset != state.Offset || state.Key != "" {
+		abort(c, http.StatusConflict, fmt.Sprintf("The offset of the upload is %v", state.Offset))
+		return
+	}
+
+	file, err := os.OpenFile(u.dataPath(id), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	written, copyErr := io.Copy(file, io.LimitReader(c.Request.Body, state.Length-state.Offset))
+	closeErr := file.Close()
+
+	// the received part is kept even if the connection is broken, so the client resumes from there
+	state.Offset += written
+	if err := u.saveState(state); err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if copyErr != nil || closeErr != nil {
+		abort(c, http.StatusInternalServerError, fmt.Sprintf("The chunk is received partially up to %v", state.Offset))
+		return
+	}
+
+	if state.Offset == state.Length {
+		if !u.complete(c, state) {
+			return
+		}
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(state.Offset, 10))
+	c.Status(http.StatusNoContent)
+}
+
+// Delete - terminate the upload and remove its chunks
+func (u *Resumable) Delete(c *gin.Context) {
+	if !u.checkVersion(c) {
+		return
+	}
+
+	id := c.Param("id")
+	if !u.acquire(id) {
+		abort(c, http.StatusLocked, "A chunk of the upload is being received")
+		return
+	}
+	defer u.release(id)
+
+	if _, ok := u.loadState(c); !ok {
+		return
+	}
+	_ = os.Remove(u.dataPath(id))
+	_ = os.Remove(u.statePath(id))
+	c.Status(http.StatusNoContent)
+}
+
+// MARK: Private functions
+
+// complete - store the chunks in the backend and call the completion callback
+func (u *Resumable) complete(c *gin.Context, state *resumableState) bool {
+	file, err := os.Open(u.dataPath(state.ID))
+	if err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	defer file.Close()
+
+	fileName := state.Metadata["filename"]
+	head := make([]byte, sniffSize)
+	n, _ := io.ReadFull(file, head)
+	contentType := http.DetectContentType(head[:n])
+	if !isTypeAllowed(contentType, u.options.AllowedTypes) {
+		_ = os.Remove(u.dataPath(state.ID))
+		_ = os.Remove(u.statePath(state.ID))
+		err := NewFileTypeNotAllowedErr(fileName, contentType)
+		abort(c, StatusOf(err), err.Error())
+		return false
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	key := u.options.KeyPrefix + state.ID + strings.ToLower(path.Ext(fileName))
+	if u.options.KeyFunc != nil {
+		key = u.options.KeyFunc(fileName)
+	}
+
+	hash := sha256.New()
+	info, err := u.options.Backend.Put(c.Request.Context(), key, io.TeeReader(file, hash), storage.PutOptions{
+		ContentType: contentType,
+		Metadata:    map[string]string{"filename": fileName},
+	})
+	if err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	info.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	state.Key = key
+	_ = u.saveState(state)
+	_ = os.Remove(u.dataPath(state.ID))
+
+	if u.options.OnComplete != nil {
+		if err := u.options.OnComplete(c, UploadedFile{FileName: fileName, ObjectInfo: info}); err != nil {
+			abort(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+	}
+	return true
+}
+
+// checkVersion - reject the clients of another protocol version
+func (u *Resumable) checkVersion(c *gin.Context) bool {
+	c.Header("Tus-Resumable", TusVersion)
+	if c.GetHeader("Tus-Resumable") != TusVersion {
+		c.Header("Tus-Version", TusVersion)
+		abort(c, http.StatusPreconditionFailed, "The Tus-Resumable version is not supported")
+		return false
+	}
+	return true
+}
+
+// loadState - read the state of the upload of the request, the response is written if it is not found
+func (u *Resumable) loadState(c *gin.Context) (*resumableState, bool) {
+	id := c.Param("id")
+	if !uploadIDPattern.MatchString(id) {
+		abort(c, http.StatusNotFound, "The upload is not found")
+		return nil, false
+	}
+
+	data, err := os.ReadFile(u.statePath(id))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			abort(c, http.StatusNotFound, "The upload is not found")
+		} else {
+			abort(c, http.StatusInternalServerError, err.Error())
+		}
+		return nil, false
+	}
+
+	var state resumableState
+	if err := json.Unmarshal(data, &state); err != nil {
+		abort(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return &state, true
+}
+
+// saveState - write the state of the upload
+func (u *Resumable) saveState(state *resumableState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(u.statePath(state.ID), data, 0o644)
+}
+
+// acquire - mark the upload as busy, false if it is already busy
+func (u *Resumable) acquire(id string) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if _, ok := u.busy[id]; ok {
+		return false
+	}
+	u.busy[id] = struct{}{}
+	return true
+}
+
+// release - mark the upload as free
+func (u *Resumable) release(id string) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	delete(u.busy, id)
+}
+
+// dataPath - return the path of the received chunks
+func (u *Resumable) dataPath(id string) string {
+	return filepath.Join(u.options.StagingDir, id+".part")
+}
+
+// statePath - return the path of the state
+func (u *Resumable) statePath(id string) string {
+	return filepath.Join(u.options.StagingDir, id+".json")
+}
+
+// parseMetadata - parse `Upload-Metadata`, comma separated keys with base64 encoded values
+func parseMetadata(header string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range strings.Split(header, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 {
+			continue
+		}
+		value := ""
+		if len(parts) > 1 {
+			if decoded, err := base64.StdEncoding.DecodeString(parts[1]); err == nil {
+				value = string(decoded)
+			}
+		}
+		result[parts[0]] = value
+	}
+	return result
+}
+
+// abort - answer the request with the error
+func abort(c *gin.Context, status int, description string) {
+	c.AbortWithStatusJSON(status, middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: description,
+	})
+}
@@ -0,0 +1,213 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// pngHeader - the signature of a png file, enough for the content type detection
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string][]byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
+	}
+	for name, content := range files {
+		part, err := writer.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatalf("Creating form file --> Expected: %v, but got %v", nil, err)
+		}
+		_, _ = part.Write(content)
+	}
+	_ = writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func receive(t *testing.T, r *http.Request, options MultipartOptions) (*MultipartResult, error) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = r
+	return ReceiveMultipart(c, options)
+}
+
+func TestReceiveMultipart(t *testing.T) {
+	backend, err := storage.NewLocalBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Creating local backend --> Expected: %v, but got %v", nil, err)
+	}
+
+	image := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 100)...)
+	hash := sha256.Sum256(image)
+
+	options := MultipartOptions{Backend: backend, KeyPrefix: "avatars/", AllowedTypes: []string{"image/*"}, MaxFileSize: 1024}
+	result, err := receive(t, newMultipartRequest(t, map[string]string{"title": "me"}, map[string][]byte{"me.png": image}), options)
+	if err != nil {
+		t.Fatalf("Receiving files --> Expected: %v, but got %v", nil, err)
+	}
+	if len(result.Files) != 1 || result.Fields["title"][0] != "me" {
+		t.Fatalf("Received files and fields --> Expected: %v %v, but got %v %v", 1, "me", len(result.Files), result.Fields)
+	}
+	file := result.Files[0]
+	if !strings.HasPrefix(file.Key, "avatars/") || !strings.HasSuffix(file.Key, ".png") || file.ContentType != "image/png" ||
+		file.Size != int64(len(image)) || file.Checksum != hex.EncodeToString(hash[:]) {
+		t.Errorf("Uploaded file --> Expected: %v %v %v, but got %v %v %v", "avatars/*.png", "image/png", len(image), file.Key, file.ContentType, file.Size)
+	}
+	if info, err := backend.Stat(context.Background(), file.Key); err != nil || info.Metadata["filename"] != "me.png" {
+		t.Errorf("Stored file --> Expected: %v, but got %v %v", "me.png", info.Metadata, err)
+	}
+
+	// the stored file of the request is removed when the next one is too large
+	var keys []string
+	options.KeyFunc = func(fieldName string, fileName string) string {
+		keys = append(keys, fileName)
+		return "avatars/" + fileName
+	}
+	options.MaxTotalSize = 150
+	_, err = receive(t, newMultipartRequest(t, nil, map[string][]byte{"a.png": image, "b.png": image}), options)
+	if StatusOf(err) != http.StatusRequestEntityTooLarge {
+		t.Errorf("Total size limit --> Expected: %v, but got %v", http.StatusRequestEntityTooLarge, err)
+	}
+	for _, key := range keys {
+		if _, err := backend.Stat(context.Background(), "avatars/"+key); err == nil {
+			t.Errorf("Cleanup of %v --> Expected an error, but got %v", key, err)
+		}
+	}
+
+	_, err = receive(t, newMultipartRequest(t, nil, map[string][]byte{"doc.png": []byte("plain text")}), options)
+	if StatusOf(err) != http.StatusUnsupportedMediaType {
+		t.Errorf("Content type check --> Expected: %v, but got %v", http.StatusUnsupportedMediaType, err)
+	}
+
+	options.AllowedExtensions = []string{".jpg"}
+	_, err = receive(t, newMultipartRequest(t, nil, map[string][]byte{"me.png": image}), options)
+	if StatusOf(err) != http.StatusUnsupportedMediaType {
+		t.Errorf("Extension check --> Expected: %v, but got %v", http.StatusUnsupportedMediaType, err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	if _, err := receive(t, r, options); StatusOf(err) != http.StatusBadRequest {
+		t.Errorf("Not a multipart request --> Expected: %v, but got %v", http.StatusBadRequest, err)
+	}
+}
+
+func TestResumable(t *testing.T) {
+	backend, err := storage.NewLocalBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("Creating local backend --> Expected: %v, but got %v", nil, err)
+	}
+
+	var completed []UploadedFile
+	resumable, err := NewResumable(ResumableOptions{
+		Backend:    backend,
+		StagingDir: t.TempDir(),
+		KeyPrefix:  "videos/",
+		MaxSize:    1024,
+		OnComplete: func(c *gin.Context, file UploadedFile) error {
+			completed = append(completed, file)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating resumable uploads --> Expected: %v, but got %v", nil, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.OPTIONS("/uploads", resumable.Options)
+	engine.POST("/uploads", resumable.Create)
+	engine.HEAD("/uploads/:id", resumable.Head)
+	engine.PATCH("/uploads/:id", resumable.Patch)
+	engine.DELETE("/uploads/:id", resumable.Delete)
+
+	serve := func(method string, target string, body string, header map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Tus-Resumable", TusVersion)
+		for name, value := range header {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+		return w
+	}
+	chunk := func(location string, offset int, body string) *httptest.ResponseRecorder {
+		return serve(http.MethodPatch, location, body, map[string]string{
+			"Content-Type":  offsetContentType,
+			"Upload-Offset": strconv.Itoa(offset),
+		})
+	}
+
+	if w := serve(http.MethodOptions, "/uploads", "", nil); w.Header().Get("Tus-Max-Size") != "1024" {
+		t.Errorf("Options --> Expected: %v, but got %v", "1024", w.Header().Get("Tus-Max-Size"))
+	}
+	if w := serve(http.MethodPost, "/uploads", "", map[string]string{"Tus-Resumable": "0.2.2", "Upload-Length": "10"}); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Unsupported version --> Expected: %v, but got %v", http.StatusPreconditionFailed, w.Code)
+	}
+	if w := serve(http.MethodPost, "/uploads", "", map[string]string{"Upload-Length": "2048"}); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Upload larger than max size --> Expected: %v, but got %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	content := "resumable upload content"
+	metadata := "filename " + base64.StdEncoding.EncodeToString([]byte("clip.txt"))
+	w := serve(http.MethodPost, "/uploads", "", map[string]string{"Upload-Length": strconv.Itoa(len(content)), "Upload-Metadata": metadata})
+	location := w.Header().Get("Location")
+	if w.Code != http.StatusCreated || !strings.HasPrefix(location, "/uploads/") {
+		t.Fatalf("Create --> Expected: %v /uploads/<id>, but got %v %v", http.StatusCreated, w.Code, location)
+	}
+
+	if w := chunk(location, 0, content[:10]); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != "10" {
+		t.Errorf("First chunk --> Expected: %v 10, but got %v %v", http.StatusNoContent, w.Code, w.Header().Get("Upload-Offset"))
+	}
+	if w := serve(http.MethodHead, location, "", nil); w.Header().Get("Upload-Offset") != "10" || w.Header().Get("Upload-Length") != strconv.Itoa(len(content)) {
+		t.Errorf("Head --> Expected: %v %v, but got %v %v", 10, len(content), w.Header().Get("Upload-Offset"), w.Header().Get("Upload-Length"))
+	}
+	if w := chunk(location, 5, content[5:]); w.Code != http.StatusConflict {
+		t.Errorf("Chunk with wrong offset --> Expected: %v, but got %v", http.StatusConflict, w.Code)
+	}
+	if w := serve(http.MethodPatch, location, content[10:], map[string]string{"Upload-Offset": "10"}); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Chunk without content type --> Expected: %v, but got %v", http.StatusUnsupportedMediaType, w.Code)
+	}
+	if w := chunk(location, 10, content[10:]); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != strconv.Itoa(len(content)) {
+		t.Errorf("Last chunk --> Expected: %v %v, but got %v %v", http.StatusNoContent, len(content), w.Code, w.Header().Get("Upload-Offset"))
+	}
+
+	if len(completed) != 1 || completed[0].FileName != "clip.txt" || !strings.HasPrefix(completed[0].Key, "videos/") {
+		t.Fatalf("Completed upload --> Expected: %v %v, but got %v", 1, "clip.txt", completed)
+	}
+	reader, _, err := backend.Get(context.Background(), completed[0].Key)
+	if err != nil {
+		t.Fatalf("Stored upload --> Expected: %v, but got %v", nil, err)
+	}
+	data, _ := io.ReadAll(reader)
+	_ = reader.Close()
+	if string(data) != content {
+		t.Errorf("Stored content --> Expected: %v, but got %v", content, string(data))
+	}
+
+	if w := serve(http.MethodDelete, location, "", nil); w.Code != http.StatusNoContent {
+		t.Errorf("Delete --> Expected: %v, but got %v", http.StatusNoContent, w.Code)
+	}
+	if w := serve(http.MethodHead, location, "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Head after delete --> Expected: %v, but got %v", http.StatusNotFound, w.Code)
+	}
+	if w := serve(http.MethodHead, "/uploads/..%2f..%2fetc", "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Head with invalid id --> Expected: %v, but got %v", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	VersioningPathStrategy      = "path"
+	VersioningHeaderStrategy    = "header"
+	VersioningMediaTypeStrategy = "media_type"
+
+	defaultVersionHeader = "Accept-Version"
+	vendorMediaPrefix    = "application/vnd."
+)
+
+var (
+	// versionSegmentPattern - a path segment which looks like a version, e.g. "v1" or "v2.1"
+	versionSegmentPattern = regexp.MustCompile(`^v\d+(\.\d+)*$`)
+
+	// swaggerParamPattern - a path parameter in swagger format, e.g. "{id}"
+	swaggerParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+)
+
+// apiVersionKey - the key of the selected version in the request context
+type apiVersionKey struct{}
+
+// VersionedRoute - a route of a server with the api version it belongs to
+type VersionedRoute struct {
+	Server      string
+	Method      string
+	Path        string
+	Handler     string
+	RouteName   string
+	Version     string    // empty for the routes outside the version groups
+	Deprecation time.Time // zero if the version is not deprecated
+	Sunset      time.Time // zero if the version has no sunset date
+}
+
+// versionLifecycle - the parsed deprecation metadata of a version and the response headers made from it
+type versionLifecycle struct {
+	deprecation time.Time
+	sunset      time.Time
+	headers     http.Header
+}
+
+// MARK: Public functions
+
+// ApiVersion - return the api version selected for the request, empty if the route is not versioned
+func ApiVersion(c *gin.Context) string {
+	if version, ok := c.Request.Context().Value(apiVersionKey{}).(string); ok {
+		return version
+	}
+	return ""
+}
+
+// MARK: versionResolver
+
+// versionResolver - select the api version of the requests by the versioning strategy of a server
+// The routes of a version are registered in its path group, so the selected version is added to the path
+type versionResolver struct {
+	strategy       string
+	header         string
+	vendor         string
+	defaultVersion string
+	versions       []string
+	lifecycle      map[string]versionLifecycle
+
+	lock        sync.RWMutex
+	unversioned map[string][]string // the route paths outside the version groups by method
+	versioned   map[string][]string // the route paths of the version groups by method
+}
+
+// newVersionResolver - validate the versioning config and create the resolver
+func newVersionResolver(versions []string, config types.VersioningConfig) (*versionResolver, error) {
+	v := &versionResolver{
+		strategy:       config.Strategy,
+		header:         config.Header,
+		vendor:         config.Vendor,
+		defaultVersion: config.Default,
+		versions:       versions,
+		lifecycle:      make(map[string]versionLifecycle),
+		unversioned:    make(map[string][]string),
+		versioned:      make(map[string][]string),
+	}
+
+	switch v.strategy {
+	case "":
+		v.strategy = VersioningPathStrategy
+	case VersioningPathStrategy, VersioningMediaTypeStrategy:
+	case VersioningHeaderStrategy:
+		if v.header == "" {
+			v.header = defaultVersionHeader
+		}
+	default:
+		return nil, NewVersioningConfigErr(fmt.Sprintf("the strategy '%v' is not supported", config.Strategy))
+	}
+
+	if v.defaultVersion != "" && !v.isVersion(v.defaultVersion) {
+		return nil, NewVersioningConfigErr(fmt.Sprintf("the default version '%v' is not in the versions", v.defaultVersion))
+	}
+
+	for version, item := range config.Lifecycle {
+		if !v.isVersion(version) {
+			return nil, NewVersioningConfigErr(fmt.Sprintf("the lifecycle of '%v' is defined but it is not in the versions", version))
+		}
+
+		lifecycle := versionLifecycle{headers: make(http.Header)}
+		if item.Deprecation != "" {
+			date, err := parseLifecycleDate(item.Deprecation)
+			if err != nil {
+				return nil, NewVersioningConfigErr(fmt.Sprintf("the deprecation date of '%v' is not valid: %v", version, err))
+			}
+			lifecycle.deprecation = date
+			// RFC 9745: the date as a structured field, seconds since the epoch
+			lifecycle.headers.Set("Deprecation", "@"+strconv.FormatInt(date.Unix(), 10))
+		}
+		if item.Sunset != "" {
+			date, err := parseLifecycleDate(item.Sunset)
+			if err != nil {
+				return nil, NewVersioningConfigErr(fmt.Sprintf("the sunset date of '%v' is not valid: %v", version, err))
+			}
+			lifecycle.sunset = date
+			// RFC 8594: the date in the http date format
+			lifecycle.headers.Set("Sunset", date.UTC().Format(http.TimeFormat))
+		}
+		if item.Link != "" {
+			lifecycle.headers.Set("Link", fmt.Sprintf("<%v>; rel=\"deprecation\"; type=\"text/html\"", item.Link))
+		}
+		v.lifecycle[version] = lifecycle
+	}
+
+	return v, nil
+}
+
+// addRoute - remember the routes, the ones outside the version groups are served without selecting a version
+func (v *versionResolver) addRoute(method string, fullPath string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.pathVersion(fullPath) != "" {
+		v.versioned[method] = append(v.versioned[method], fullPath)
+		return
+	}
+	v.unversioned[method] = append(v.unversioned[method], fullPath)
+}
+
+// resolve - select the version of the request and write the version headers of the response
+// The request is returned with the version in the path and in the context, or nil if the response is already written
+func (v *versionResolver) resolve(w http.ResponseWriter, r *http.Request) *http.Request {
+	version := v.pathVersion(r.URL.Path)
+	rewrite := false
+
+	if version == "" {
+		if v.strategy == VersioningPathStrategy && v.defaultVersion == "" {
+			return r
+		}
+		if v.hasRoute(v.unversioned, r.Method, r.URL.Path) {
+			return r
+		}
+
+		switch v.strategy {
+		case VersioningHeaderStrategy:
+			w.Header().Add("Vary", v.header)
+			if value := strings.TrimSpace(r.Header.Get(v.header)); value != "" {
+				if version = v.normalize(value); version == "" {
+					writeVersionError(w, http.StatusBadRequest, fmt.Sprintf("The api version '%v' is not supported", value))
+					return nil
+				}
+			}
+		case VersioningMediaTypeStrategy:
+			w.Header().Add("Vary", "Accept")
+			if value := v.mediaTypeVersion(r.Header.Get("Accept")); value != "" {
+				if version = v.normalize(value); version == "" {
+					writeVersionError(w, http.StatusNotAcceptable, fmt.Sprintf("The api version '%v' is not supported", value))
+					return nil
+				}
+			}
+		}
+
+		if version == "" {
+			version = v.defaultVersion
+		}
+		// the paths which are not routes of the version, e.g. the mounted handlers and the favicon, are served as they are
+		if version == "" || !v.hasRoute(v.versioned, r.Method, joinPaths("/"+version, r.URL.Path)) {
+			return r
+		}
+		rewrite = true
+	}
+
+	if lifecycle, ok := v.lifecycle[version]; ok {
+		header := w.Header()
+		for name, values := range lifecycle.headers {
+			header[name] = append(header[name], values...)
+		}
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, version))
+	if rewrite {
+		// the request is a shallow copy, so the url is copied before it is changed
+		u := *r.URL
+		u.Path = joinPaths("/"+version, u.Path)
+		if u.RawPath != "" {
+			u.RawPath = joinPaths("/"+version, u.RawPath)
+		}
+		r.URL = &u
+	}
+	return r
+}
+
+// pathVersion - return the version of the first path segment, empty if it is not a version of the server
+func (v *versionResolver) pathVersion(path string) string {
+	segment := strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(segment, '/'); i >= 0 {
+		segment = segment[:i]
+	}
+	if v.isVersion(segment) {
+		return segment
+	}
+	return ""
+}
+
+// isVersion - check whether the version is served by the server
+func (v *versionResolver) isVersion(version string) bool {
+	for _, item := range v.versions {
+		if item == version {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize - return the version of the server requested by the value, "2" is accepted for "v2"
+func (v *versionResolver) normalize(value string) string {
+	if v.isVersion(value) {
+		return value
+	}
+	if v.isVersion("v" + value) {
+		return "v" + value
+	}
+	return ""
+}
+
+// mediaTypeVersion - return the version of the first vendor media type in the Accept header
+// e.g. "v2" of "application/vnd.gonyx.v2+json"
+func (v *versionResolver) mediaTypeVersion(accept string) string {
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil || !strings.HasPrefix(mediaType, vendorMediaPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(mediaType, vendorMediaPrefix)
+		if i := strings.IndexByte(name, '+'); i >= 0 {
+			name = name[:i]
+		}
+
+		i := strings.LastIndexByte(name, '.')
+		if i <= 0 {
+			continue
+		}
+		if v.vendor != "" && name[:i] != v.vendor {
+			continue
+		}
+		return name[i+1:]
+	}
+	return ""
+}
+
+// hasRoute - check whether a route of the routes, versioned or unversioned, matches the request
+func (v *versionResolver) hasRoute(routes map[string][]string, method string, path string) bool {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	for _, pattern := range routes[method] {
+		if matchRoutePath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// routes - return the routes of the engine with their versions
+func (v *versionResolver) routes(serverName string, engine *gin.Engine, names *routeNameTable) []VersionedRoute {
+	var result []VersionedRoute
+	for _, item := range engine.Routes() {
+		route := VersionedRoute{
+			Server:    serverName,
+			Method:    item.Method,
+			Path:      item.Path,
+			Handler:   item.Handler,
+			RouteName: names.get(item.Method + " " + item.Path),
+			Version:   v.pathVersion(item.Path),
+		}
+		if lifecycle, ok := v.lifecycle[route.Version]; ok {
+			route.Deprecation = lifecycle.deprecation
+			route.Sunset = lifecycle.sunset
+		}
+		result = append(result, route)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Version != result[j].Version {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// MARK: Private functions
+
+// matchRoutePath - check whether the path matches the route path with ":param" and "*wildcard" segments
+func matchRoutePath(pattern string, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
+
+// parseLifecycleDate - parse a date of the lifecycle config, with or without the time
+func parseLifecycleDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// writeVersionError - answer the request which selects a version that is not supported
+func writeVersionError(w http.ResponseWriter, status int, description string) {
+	body, _ := json.Marshal(middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(status),
+		Status:      status,
+		Description: description,
+	})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+func newVersionedTestServer(t *testing.T, versioning types.VersioningConfig) *GinServer {
+	makeReadyConfigManager()
+
+	serverConfig := types.GinServerConfig{Name: "versioning", ListenAddress: "127.0.0.1:0", Versions: []string{"v1", "v2"}, Versioning: versioning}
+	serverConfig.Config.RequestMethods = []string{"ALL"}
+
+	server, err := NewGinServer("http", serverConfig, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Creating Http Server --> Expected: %v, but got %v", nil, err)
+	}
+
+	handler := func(c *gin.Context) { c.String(http.StatusOK, ApiVersion(c)) }
+	_ = server.AddRoute(http.MethodGet, "/users/:id", handler, "users", []string{"all"}, nil)
+	_ = server.AddRoute(http.MethodGet, "/health", func(c *gin.Context) { c.String(http.StatusOK, "ok") }, "health", nil, nil)
+	return server
+}
+
+func TestVersioning_Strategies(t *testing.T) {
+	lifecycle := map[string]types.VersionLifecycleConfig{
+		"v1": {Deprecation: "2025-01-01", Sunset: "2026-01-01T00:00:00Z", Link: "https://example.com/v2"},
+	}
+
+	testCases := []struct {
+		name       string
+		versioning types.VersioningConfig
+		path       string
+		header     map[string]string
+		status     int
+		body       string
+	}{
+		{"path", types.VersioningConfig{}, "/v2/users/1", nil, http.StatusOK, "v2"},
+		{"path without default", types.VersioningConfig{}, "/users/1", nil, http.StatusNotFound, ""},
+		{"path with default", types.VersioningConfig{Default: "v1"}, "/users/1", nil, http.StatusOK, "v1"},
+		{"header", types.VersioningConfig{Strategy: "header"}, "/users/1", map[string]string{"Accept-Version": "v2"}, http.StatusOK, "v2"},
+		{"header without v", types.VersioningConfig{Strategy: "header", Header: "X-Api-Version"}, "/users/1", map[string]string{"X-Api-Version": "1"}, http.StatusOK, "v1"},
+		{"header default", types.VersioningConfig{Strategy: "header", Default: "v2"}, "/users/1", nil, http.StatusOK, "v2"},
+		{"header not supported", types.VersioningConfig{Strategy: "header"}, "/users/1", map[string]string{"Accept-Version": "v9"}, http.StatusBadRequest, ""},
+		{"header and path", types.VersioningConfig{Strategy: "header"}, "/v1/users/1", map[string]string{"Accept-Version": "v2"}, http.StatusOK, "v1"},
+		{"media type", types.VersioningConfig{Strategy: "media_type", Vendor: "gonyx"}, "/users/1", map[string]string{"Accept": "text/html, application/vnd.gonyx.v2+json"}, http.StatusOK, "v2"},
+		{"media type of other vendor", types.VersioningConfig{Strategy: "media_type", Vendor: "gonyx", Default: "v1"}, "/users/1", map[string]string{"Accept": "application/vnd.other.v2+json"}, http.StatusOK, "v1"},
+		{"media type not supported", types.VersioningConfig{Strategy: "media_type"}, "/users/1", map[string]string{"Accept": "application/vnd.gonyx.v3+json"}, http.StatusNotAcceptable, ""},
+		{"unversioned route", types.VersioningConfig{Strategy: "header", Default: "v2"}, "/health", nil, http.StatusOK, "ok"},
+	}
+
+	for _, tc := range testCases {
+		tc.versioning.Lifecycle = lifecycle
+		server := newVersionedTestServer(t, tc.versioning)
+
+		r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		for name, value := range tc.header {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		server.serveHTTP(w, r)
+
+		if w.Code != tc.status {
+			t.Errorf("%v: Status --> Expected: %v, but got %v", tc.name, tc.status, w.Code)
+			continue
+		}
+		if tc.body != "" && w.Body.String() != tc.body {
+			t.Errorf("%v: Selected version --> Expected: %v, but got %v", tc.name, tc.body, w.Body.String())
+		}
+
+		deprecation := w.Header().Get("Deprecation")
+		if tc.body == "v1" && (deprecation != "@1735689600" || w.Header().Get("Sunset") != "Thu, 01 Jan 2026 00:00:00 GMT") {
+			t.Errorf("%v: Deprecation headers --> Expected: %v, but got %v", tc.name, "@1735689600", deprecation)
+		}
+		if tc.body == "v2" && deprecation != "" {
+			t.Errorf("%v: Deprecation header --> Expected: %v, but got %v", tc.name, "", deprecation)
+		}
+	}
+}
+
+func TestVersioning_DefaultKeepsMountedPaths(t *testing.T) {
+	server := newVersionedTestServer(t, types.VersioningConfig{Default: "v2"})
+	mounted := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(r.URL.Path)) })
+	if err := server.MountHandler("/gw", mounted); err != nil {
+		t.Fatalf("Mount handler --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the default version is only added to the paths of the versioned routes
+	for path, expected := range map[string]string{"/gw/hello": "/gw/hello", "/users/1": "v2"} {
+		w := httptest.NewRecorder()
+		server.serveHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || w.Body.String() != expected {
+			t.Errorf("Request of %v --> Expected: %v %v, but got %v %v", path, http.StatusOK, expected, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestVersioning_InvalidConfig(t *testing.T) {
+	makeReadyConfigManager()
+
+	testCases := []types.VersioningConfig{
+		{Strategy: "query"},
+		{Default: "v3"},
+		{Lifecycle: map[string]types.VersionLifecycleConfig{"v3": {Deprecation: "2025-01-01"}}},
+		{Lifecycle: map[string]types.VersionLifecycleConfig{"v1": {Sunset: "next year"}}},
+	}
+
+	for _, versioning := range testCases {
+		serverConfig := types.GinServerConfig{Name: "versioning", Versions: []string{"v1", "v2"}, Versioning: versioning}
+		if _, err := NewGinServer("http", serverConfig, map[string]interface{}{}); err == nil {
+			t.Errorf("Creating server with %+v --> Expected an error, but got %v", versioning, err)
+		}
+	}
+}
+
+func TestGinServer_GetVersionedRoutes(t *testing.T) {
+	server := newVersionedTestServer(t, types.VersioningConfig{
+		Lifecycle: map[string]types.VersionLifecycleConfig{"v1": {Sunset: "2026-01-01"}},
+	})
+
+	routes := server.GetVersionedRoutes()
+	if len(routes) != 3 {
+		t.Fatalf("Number of routes --> Expected: %v, but got %v", 3, len(routes))
+	}
+
+	expected := []struct{ path, version string }{{"/health", ""}, {"/v1/users/:id", "v1"}, {"/v2/users/:id", "v2"}}
+	for i, item := range expected {
+		if routes[i].Path != item.path || routes[i].Version != item.version {
+			t.Errorf("Route %v --> Expected: %v %v, but got %v %v", i, item.path, item.version, routes[i].Path, routes[i].Version)
+		}
+	}
+	if routes[1].RouteName != "users" || routes[1].Sunset.IsZero() || !routes[2].Sunset.IsZero() {
+		t.Errorf("Route metadata --> Expected: %v with sunset only on v1, but got %+v", "users", routes[1:])
+	}
+}
+
+func TestGinServer_IsPathSupportedByServer(t *testing.T) {
+	server := newVersionedTestServer(t, types.VersioningConfig{Strategy: "header"})
+	_ = server.AddRoute(http.MethodGet, "/videos", func(c *gin.Context) {}, "videos", nil, nil)
+
+	testCases := map[string]bool{
+		"/v1/users/{id}": true,
+		"/v3/users/{id}": false,
+		"/users/{id}":    true,
+		"/videos":        true,
+		"/orders":        false,
+	}
+	for path, expected := range testCases {
+		if result := server.isPathSupportedByServer(path); result != expected {
+			t.Errorf("Swagger path %v --> Expected: %v, but got %v", path, expected, result)
+		}
+	}
+}
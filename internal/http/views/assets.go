@@ -0,0 +1,251 @@
+package views
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/watcher"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	defaultStaticPrefix = "/static"
+	fingerprintLength   = 8
+	immutableMaxAge     = 365 * 24 * 60 * 60
+)
+
+// Assets - the static files of a server, they are served by the urls with the hash of their content if it is enabled
+type Assets struct {
+	config       types.StaticConfig
+	lock         sync.RWMutex
+	fsys         fs.FS
+	embedded     bool              // the files are not on the disk, so they are not watched
+	fingerprints map[string]string // the fingerprinted name of each file, e.g. "css/app.css" -> "css/app.1a2b3c4d.css"
+	originals    map[string]string // the file of each fingerprinted name
+	stopWatch    func()
+}
+
+// MARK: Public functions
+
+// NewAssets - create the static files of the config, they are read from fsys if it is not nil
+func NewAssets(config types.StaticConfig, fsys fs.FS) (*Assets, error) {
+	config.Prefix = "/" + strings.Trim(config.Prefix, "/")
+	if config.Prefix == "/" {
+		config.Prefix = defaultStaticPrefix
+	}
+
+	a := &Assets{config: config}
+	if err := a.SetFS(fsys); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SetFS - read the files from fsys instead of the disk, e.g. an embed.FS of a production build
+func (a *Assets) SetFS(fsys fs.FS) error {
+	root, err := rootFS(fsys, a.config.Root)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	a.fsys = root
+	a.embedded = fsys != nil
+	a.lock.Unlock()
+
+	if a.embedded {
+		a.unwatch()
+	}
+	return a.Load()
+}
+
+// Load - calculate the fingerprints of the files
+func (a *Assets) Load() error {
+	if !a.config.Fingerprint {
+		return nil
+	}
+
+	a.lock.RLock()
+	fsys := a.fsys
+	a.lock.RUnlock()
+
+	fingerprints := make(map[string]string)
+	originals := make(map[string]string)
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return skipMissingRoot(name, err)
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		_, err = io.Copy(hash, file)
+		_ = file.Close()
+		if err != nil {
+			return err
+		}
+
+		extension := path.Ext(name)
+		fingerprinted := strings.TrimSuffix(name, extension) + "." + hex.EncodeToString(hash.Sum(nil))[:fingerprintLength] + extension
+		fingerprints[name] = fingerprinted
+		originals[fingerprinted] = name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	a.fingerprints = fingerprints
+	a.originals = originals
+	a.lock.Unlock()
+	return nil
+}
+
+// Prefix - return the url prefix of the files
+func (a *Assets) Prefix() string {
+	return a.config.Prefix
+}
+
+// URL - return the url of the file, it is the fingerprinted url if the fingerprint is enabled
+func (a *Assets) URL(name string) string {
+	if a == nil {
+		return name
+	}
+	name = strings.TrimPrefix(name, "/")
+
+	a.lock.RLock()
+	fingerprinted, ok := a.fingerprints[name]
+	a.lock.RUnlock()
+
+	if ok {
+		name = fingerprinted
+	}
+	return a.config.Prefix + "/" + name
+}
+
+// Handler - serve the file of the `filepath` param, the fingerprinted urls are cached for a year
+func (a *Assets) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := strings.TrimPrefix(c.Param("filepath"), "/")
+
+		a.lock.RLock()
+		fsys := a.fsys
+		original, fingerprinted := a.originals[name]
+		a.lock.RUnlock()
+
+		cacheControl := "no-cache"
+		if fingerprinted {
+			name = original
+			cacheControl = "public, max-age=" + strconv.Itoa(immutableMaxAge) + ", immutable"
+		} else if a.config.MaxAge > 0 {
+			cacheControl = "public, max-age=" + strconv.Itoa(a.config.MaxAge)
+		}
+
+		if !fs.ValidPath(name) {
+			abortNotFound(c)
+			return
+		}
+		file, err := fsys.Open(name)
+		if err != nil {
+			abortNotFound(c)
+			return
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			abortNotFound(c)
+			return
+		}
+
+		content, ok := file.(io.ReadSeeker)
+		if !ok {
+			data, err := io.ReadAll(file)
+			if err != nil {
+				abortNotFound(c)
+				return
+			}
+			content = bytes.NewReader(data)
+		}
+
+		c.Header("Cache-Control", cacheControl)
+		http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), content)
+	}
+}
+
+// Watch - calculate the fingerprints again when the files are changed on the disk
+func (a *Assets) Watch() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.embedded || a.stopWatch != nil || !a.config.Fingerprint {
+		return nil
+	}
+	root := a.config.Root
+	if root == "" {
+		root = "."
+	}
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	stop, err := watcher.GetManager().Watch([]string{root}, func(changedPath string) {
+		if err := a.Load(); err != nil {
+			logError("views.Assets.Watch", "Reloading the static files failed ...", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	a.stopWatch = stop
+	return nil
+}
+
+// Close - stop watching the files
+func (a *Assets) Close() {
+	if a != nil {
+		a.unwatch()
+	}
+}
+
+// MARK: Private functions
+
+// unwatch - stop the watcher if it is running
+func (a *Assets) unwatch() {
+	a.lock.Lock()
+	stop := a.stopWatch
+	a.stopWatch = nil
+	a.lock.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// abortNotFound - answer the request of a missing file
+func abortNotFound(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusNotFound, middlewares.ErrorHttpResponse{
+		Message:     http.StatusText(http.StatusNotFound),
+		Status:      http.StatusNotFound,
+		Description: "The file is not found",
+	})
+}
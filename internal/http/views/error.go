@@ -0,0 +1,53 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// TemplateNotFoundErr Error
+type TemplateNotFoundErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *TemplateNotFoundErr) Error() string {
+	return fmt.Sprintf("There is no html template by the name: %v", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *TemplateNotFoundErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewTemplateNotFoundErr - return a new instance of TemplateNotFoundErr
+func NewTemplateNotFoundErr(name string) error {
+	return &TemplateNotFoundErr{Name: name}
+}
+
+// ParseTemplateErr Error
+type ParseTemplateErr struct {
+	Name string
+	Err  error
+}
+
+// Error method - satisfying error interface
+func (err *ParseTemplateErr) Error() string {
+	return fmt.Sprintf("Parsing the html template `%v` failed: %v", err.Name, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *ParseTemplateErr) Unwrap() error {
+	return err.Err
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ParseTemplateErr) ErrorCode() errs.Code {
+	return errs.Internal
+}
+
+// NewParseTemplateErr - return a new instance of ParseTemplateErr
+func NewParseTemplateErr(name string, err error) error {
+	return &ParseTemplateErr{Name: name, Err: err}
+}
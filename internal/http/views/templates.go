@@ -0,0 +1,272 @@
+package views
+
+import (
+	"errors"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	logTypes "github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/watcher"
+	"github.com/gin-gonic/gin/render"
+)
+
+// MARK: Variables
+
+var (
+	ViewsMaintenanceType = logTypes.NewLogType("HTTP_VIEWS_MAINTENANCE")
+)
+
+const (
+	defaultLayoutsDir  = "layouts"
+	defaultPartialsDir = "partials"
+	defaultExtension   = ".html"
+)
+
+// Renderer - the html templates of a server, it satisfies the HTMLRender of gin
+// The templates are named by their path under the root, e.g. "users/show.html"
+type Renderer struct {
+	config    types.TemplatesConfig
+	lock      sync.RWMutex
+	fsys      fs.FS
+	embedded  bool // the files are not on the disk, so they are not watched
+	funcs     template.FuncMap
+	sets      map[string]*template.Template // the templates of each page with the layouts and the partials
+	stopWatch func()
+}
+
+// MARK: Public functions
+
+// NewRenderer - parse the templates of the config, they are read from fsys if it is not nil
+func NewRenderer(config types.TemplatesConfig, fsys fs.FS, funcs template.FuncMap) (*Renderer, error) {
+	if config.Layouts == "" {
+		config.Layouts = defaultLayoutsDir
+	}
+	if config.Partials == "" {
+		config.Partials = defaultPartialsDir
+	}
+	if config.Extension == "" {
+		config.Extension = defaultExtension
+	}
+
+	r := &Renderer{config: config, funcs: template.FuncMap{}}
+	for name, f := range funcs {
+		r.funcs[name] = f
+	}
+	if err := r.SetFS(fsys); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetFS - read the templates from fsys instead of the disk and parse them again, e.g. an embed.FS of a production build
+func (r *Renderer) SetFS(fsys fs.FS) error {
+	root, err := rootFS(fsys, r.config.Root)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	r.fsys = root
+	r.embedded = fsys != nil
+	r.lock.Unlock()
+
+	if r.embedded {
+		r.unwatch()
+	}
+	return r.Load()
+}
+
+// AddFuncs - add the functions to the templates and parse them again
+func (r *Renderer) AddFuncs(funcs template.FuncMap) error {
+	r.lock.Lock()
+	for name, f := range funcs {
+		r.funcs[name] = f
+	}
+	r.lock.Unlock()
+	return r.Load()
+}
+
+// Load - parse the templates, the previous ones are kept if a template is not valid
+func (r *Renderer) Load() error {
+	r.lock.RLock()
+	fsys, funcs, config := r.fsys, r.funcs, r.config
+	r.lock.RUnlock()
+
+	var shared, pages []string
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return skipMissingRoot(name, err)
+		}
+		if entry.IsDir() || path.Ext(name) != config.Extension {
+			return nil
+		}
+		if isUnder(name, config.Layouts) || isUnder(name, config.Partials) {
+			shared = append(shared, name)
+		} else {
+			pages = append(pages, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(shared)
+
+	base := template.New("").Funcs(funcs)
+	for _, name := range shared {
+		if err := parseFile(base.New(name), fsys, name); err != nil {
+			return err
+		}
+	}
+
+	sets := make(map[string]*template.Template, len(pages))
+	for _, name := range pages {
+		set, err := base.Clone()
+		if err != nil {
+			return NewParseTemplateErr(name, err)
+		}
+		if err := parseFile(set.New(name), fsys, name); err != nil {
+			return err
+		}
+		sets[name] = set
+	}
+
+	r.lock.Lock()
+	r.sets = sets
+	r.lock.Unlock()
+	return nil
+}
+
+// Instance - return the render of the page, it satisfies the HTMLRender of gin
+func (r *Renderer) Instance(name string, data any) render.Render {
+	r.lock.RLock()
+	set, ok := r.sets[name]
+	r.lock.RUnlock()
+
+	if !ok {
+		return errorRender{err: NewTemplateNotFoundErr(name)}
+	}
+	return render.HTML{Template: set, Name: name, Data: data}
+}
+
+// Watch - parse the templates again when they are changed on the disk
+func (r *Renderer) Watch() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.embedded || r.stopWatch != nil {
+		return nil
+	}
+	root := r.config.Root
+	if root == "" {
+		root = "."
+	}
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	stop, err := watcher.GetManager().Watch([]string{root}, func(changedPath string) {
+		if err := r.Load(); err != nil {
+			logError("views.Renderer.Watch", "Reloading the html templates failed ...", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	r.stopWatch = stop
+	return nil
+}
+
+// Close - stop watching the templates
+func (r *Renderer) Close() {
+	if r != nil {
+		r.unwatch()
+	}
+}
+
+// MARK: Private functions
+
+// unwatch - stop the watcher if it is running
+func (r *Renderer) unwatch() {
+	r.lock.Lock()
+	stop := r.stopWatch
+	r.stopWatch = nil
+	r.lock.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// parseFile - parse the file into the template
+func parseFile(t *template.Template, fsys fs.FS, name string) error {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return NewParseTemplateErr(name, err)
+	}
+	if _, err := t.Parse(string(content)); err != nil {
+		return NewParseTemplateErr(name, err)
+	}
+	return nil
+}
+
+// rootFS - return the directory of the files, the disk is used if fsys is nil
+func rootFS(fsys fs.FS, root string) (fs.FS, error) {
+	if fsys == nil {
+		if root == "" {
+			root = "."
+		}
+		return os.DirFS(root), nil
+	}
+	root = strings.Trim(path.Clean("/"+root), "/")
+	if root == "" {
+		return fsys, nil
+	}
+	return fs.Sub(fsys, root)
+}
+
+// skipMissingRoot - a missing root has no files, so the files can be set after the server is created
+func skipMissingRoot(name string, err error) error {
+	if name == "." && errors.Is(err, fs.ErrNotExist) {
+		return fs.SkipAll
+	}
+	return err
+}
+
+// isUnder - check whether the slash separated name is in the directory
+func isUnder(name string, dir string) bool {
+	dir = strings.Trim(dir, "/")
+	return dir != "" && strings.HasPrefix(name, dir+"/")
+}
+
+// logError - log the error of the views
+func logError(section string, message string, err error) {
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(logTypes.NewLogObject(logTypes.ERROR, section, ViewsMaintenanceType, time.Now(), message, err.Error()))
+	}
+}
+
+// errorRender - the render of a missing template, the error is added to the context by gin
+type errorRender struct {
+	err error
+}
+
+// Render - answer 500 and return the error
+func (e errorRender) Render(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusInternalServerError)
+	return e.err
+}
+
+// WriteContentType - write the content type of the html
+func (e errorRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+}
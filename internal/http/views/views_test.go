@@ -0,0 +1,184 @@
+package views
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/http/types"
+	"github.com/gin-gonic/gin"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../../..", "test", "Gonyx")
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("Creating directory --> Expected: %v, but got %v", nil, err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("Writing file --> Expected: %v, but got %v", nil, err)
+		}
+	}
+}
+
+func renderPage(r *Renderer, name string, data any) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.HTMLRender = r
+	engine.GET("/", func(c *gin.Context) { c.HTML(http.StatusOK, name, data) })
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w
+}
+
+var templateFiles = map[string]string{
+	"layouts/base.html":   `<html><title>{{block "title" .}}Gonyx{{end}}</title>{{template "partials/nav.html" .}}{{block "content" .}}{{end}}</html>`,
+	"partials/nav.html":   `<nav>{{.User}}</nav>`,
+	"home.html":           `{{define "title"}}Home{{end}}{{define "content"}}<main>{{upper .User}} <link href="{{asset "css/app.css"}}"></main>{{end}}{{template "layouts/base.html" .}}`,
+	"users/show.html":     `{{define "content"}}<p>{{.User}}</p>{{end}}{{template "layouts/base.html" .}}`,
+	"layouts/ignored.txt": `{{not parsed}}`,
+}
+
+func TestRenderer(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, templateFiles)
+	writeFiles(t, root, map[string]string{"static/css/app.css": "body{}"})
+
+	assets, err := NewAssets(types.StaticConfig{Root: filepath.Join(root, "static"), Fingerprint: true}, nil)
+	if err != nil {
+		t.Fatalf("Creating assets --> Expected: %v, but got %v", nil, err)
+	}
+	funcs := template.FuncMap{"asset": assets.URL, "upper": strings.ToUpper}
+	r, err := NewRenderer(types.TemplatesConfig{Root: root}, nil, funcs)
+	if err != nil {
+		t.Fatalf("Creating renderer --> Expected: %v, but got %v", nil, err)
+	}
+
+	w := renderPage(r, "home.html", gin.H{"User": "ali"})
+	expected := `<html><title>Home</title><nav>ali</nav><main>ALI <link href="` + assets.URL("css/app.css") + `"></main></html>`
+	if w.Code != http.StatusOK || w.Body.String() != expected {
+		t.Errorf("Rendering page --> Expected: %v, but got %v %v", expected, w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(assets.URL("css/app.css"), "/static/css/app.") {
+		t.Errorf("Asset url --> Expected: %v, but got %v", "/static/css/app.<hash>.css", assets.URL("css/app.css"))
+	}
+
+	// the blocks of a page do not leak to the other pages
+	w = renderPage(r, "users/show.html", gin.H{"User": "<b>"})
+	expected = `<html><title>Gonyx</title><nav>&lt;b&gt;</nav><p>&lt;b&gt;</p></html>`
+	if w.Body.String() != expected {
+		t.Errorf("Rendering nested page --> Expected: %v, but got %v", expected, w.Body.String())
+	}
+
+	if w = renderPage(r, "missing.html", nil); w.Code != http.StatusInternalServerError {
+		t.Errorf("Rendering missing page --> Expected: %v, but got %v", http.StatusInternalServerError, w.Code)
+	}
+
+	// an invalid template keeps the previous ones
+	writeFiles(t, root, map[string]string{"broken.html": `{{if}}`})
+	if err := r.Load(); err == nil {
+		t.Errorf("Loading invalid template --> Expected an error, but got %v", err)
+	}
+	if w = renderPage(r, "users/show.html", gin.H{"User": "ali"}); w.Code != http.StatusOK {
+		t.Errorf("Rendering after failed load --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestRenderer_EmbeddedFS(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for name, content := range templateFiles {
+		fsys["web/templates/"+name] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	r, err := NewRenderer(types.TemplatesConfig{Root: "web/templates"}, fsys, template.FuncMap{"asset": (*Assets)(nil).URL, "upper": strings.ToUpper})
+	if err != nil {
+		t.Fatalf("Creating renderer --> Expected: %v, but got %v", nil, err)
+	}
+	if w := renderPage(r, "users/show.html", gin.H{"User": "ali"}); !strings.Contains(w.Body.String(), "<p>ali</p>") {
+		t.Errorf("Rendering embedded page --> Expected: %v, but got %v", "<p>ali</p>", w.Body.String())
+	}
+	if err := r.Watch(); err != nil || r.stopWatch != nil {
+		t.Errorf("Watching embedded files --> Expected: %v, but got %v", "no watcher", err)
+	}
+}
+
+func TestRenderer_Watch(t *testing.T) {
+	makeReadyConfigManager()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"index.html": "v1"})
+	r, err := NewRenderer(types.TemplatesConfig{Root: root}, nil, nil)
+	if err != nil {
+		t.Fatalf("Creating renderer --> Expected: %v, but got %v", nil, err)
+	}
+	if err := r.Watch(); err != nil {
+		t.Fatalf("Watching templates --> Expected: %v, but got %v", nil, err)
+	}
+	defer r.Close()
+
+	writeFiles(t, root, map[string]string{"index.html": "v2"})
+	deadline := time.Now().Add(5 * time.Second)
+	for renderPage(r, "index.html", nil).Body.String() != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Reloading changed template --> Expected: %v, but got %v", "v2", renderPage(r, "index.html", nil).Body.String())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestAssets(t *testing.T) {
+	fsys := fstest.MapFS{
+		"public/css/app.css": &fstest.MapFile{Data: []byte("body{color:red}"), ModTime: time.Now()},
+		"public/js/app.js":   &fstest.MapFile{Data: []byte("console.log(1)"), ModTime: time.Now()},
+	}
+	assets, err := NewAssets(types.StaticConfig{Prefix: "/assets/", Root: "public", Fingerprint: true, MaxAge: 60}, fsys)
+	if err != nil {
+		t.Fatalf("Creating assets --> Expected: %v, but got %v", nil, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/assets/*filepath", assets.Handler())
+	engine.HEAD("/assets/*filepath", assets.Handler())
+	serve := func(method string, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	url := assets.URL("/css/app.css")
+	if !strings.HasPrefix(url, "/assets/css/app.") || !strings.HasSuffix(url, ".css") || url == "/assets/css/app.css" {
+		t.Fatalf("Fingerprinted url --> Expected: %v, but got %v", "/assets/css/app.<hash>.css", url)
+	}
+
+	w := serve(http.MethodGet, url)
+	if w.Code != http.StatusOK || w.Body.String() != "body{color:red}" || !strings.Contains(w.Header().Get("Cache-Control"), "immutable") ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "text/css") {
+		t.Errorf("Fingerprinted file --> Expected: %v immutable text/css, but got %v %v %v", http.StatusOK, w.Code, w.Header().Get("Cache-Control"), w.Header().Get("Content-Type"))
+	}
+
+	if w = serve(http.MethodGet, "/assets/js/app.js"); w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("Plain file --> Expected: %v %v, but got %v %v", http.StatusOK, "public, max-age=60", w.Code, w.Header().Get("Cache-Control"))
+	}
+	if w = serve(http.MethodHead, "/assets/js/app.js"); w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("Head of file --> Expected: %v, but got %v", http.StatusOK, w.Code)
+	}
+
+	for _, target := range []string{"/assets/css/missing.css", "/assets/css", "/assets/../public/js/app.js"} {
+		if w = serve(http.MethodGet, target); w.Code != http.StatusNotFound {
+			t.Errorf("Missing file %v --> Expected: %v, but got %v", target, http.StatusNotFound, w.Code)
+		}
+	}
+}
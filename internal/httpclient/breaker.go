@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker - the circuit of a client, it is opened after the consecutive failures
+type breaker struct {
+	threshold   int
+	openTimeout time.Duration
+
+	lock      sync.Mutex
+	failures  int       // the consecutive failed requests
+	openUntil time.Time // the circuit is open until this time
+	trial     bool      // a trial request is in flight while the circuit is half-open
+}
+
+// acquire - check whether a request can be sent
+// If the circuit is open and its timeout is passed, only one trial request is let through
+func (b *breaker) acquire() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// report - record the result of a request
+func (b *breaker) report(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.trial = false
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.openTimeout)
+	}
+}
+
+// release - finish a request without a result, e.g. when the caller is gone
+func (b *breaker) release() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.trial = false
+}
@@ -0,0 +1,382 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+var (
+	HttpClientMaintenanceType = types.NewLogType("HTTP_CLIENT_MAINTENANCE")
+)
+
+const (
+	BasicAuth  = "basic"
+	BearerAuth = "bearer"
+	HeaderAuth = "header"
+)
+
+// Client - a client of an upstream service, it retries the idempotent requests, opens its circuit after the
+// consecutive failures and propagates the request id and the trace context of the inbound request
+type Client struct {
+	config     Config
+	baseURL    *url.URL
+	statuses   []int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	propagate  []string
+	breaker    *breaker
+	transport  *http.Transport
+	httpClient *http.Client
+}
+
+// MARK: Public functions
+
+// New - validate the config and create the client
+func New(config Config) (*Client, error) {
+	c := &Client{
+		config:     config,
+		statuses:   config.Retry.Statuses,
+		backoff:    time.Duration(config.Retry.Backoff) * time.Millisecond,
+		maxBackoff: time.Duration(config.Retry.MaxBackoff) * time.Millisecond,
+		propagate:  config.Propagate,
+		breaker: &breaker{
+			threshold:   config.CircuitBreaker.FailureThreshold,
+			openTimeout: time.Duration(config.CircuitBreaker.OpenTimeout) * time.Millisecond,
+		},
+	}
+
+	if config.BaseURL != "" {
+		baseURL, err := url.Parse(config.BaseURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, NewConfigErr(config.Name, fmt.Sprintf("the base url '%v' is not an absolute url", config.BaseURL))
+		}
+		c.baseURL = baseURL
+	}
+
+	switch config.Auth.Type {
+	case "", BasicAuth, BearerAuth:
+	case HeaderAuth:
+		if config.Auth.Header == "" {
+			return nil, NewConfigErr(config.Name, "the header of the auth is not set")
+		}
+	default:
+		return nil, NewConfigErr(config.Name, fmt.Sprintf("the auth type '%v' is not supported", config.Auth.Type))
+	}
+	if config.Retry.Jitter < 0 || config.Retry.Jitter > 1 {
+		return nil, NewConfigErr(config.Name, "the jitter of the retry must be between 0 and 1")
+	}
+
+	if len(c.statuses) == 0 {
+		c.statuses = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+	if c.backoff <= 0 {
+		c.backoff = 100 * time.Millisecond
+	}
+	if c.maxBackoff <= 0 {
+		c.maxBackoff = 2 * time.Second
+	}
+	if c.breaker.openTimeout <= 0 {
+		c.breaker.openTimeout = 30 * time.Second
+	}
+	if len(c.propagate) == 0 {
+		c.propagate = defaultPropagate
+	}
+
+	c.transport = http.DefaultTransport.(*http.Transport).Clone()
+	c.transport.DialContext = (&net.Dialer{
+		Timeout:   millisecondsOr(config.DialTimeout, 5*time.Second),
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	c.transport.TLSHandshakeTimeout = millisecondsOr(config.TLSHandshakeTimeout, 10*time.Second)
+	c.transport.ResponseHeaderTimeout = time.Duration(config.ResponseHeaderTimeout) * time.Millisecond
+	c.transport.IdleConnTimeout = millisecondsOr(config.IdleConnTimeout, 90*time.Second)
+	c.transport.MaxIdleConns = intOr(config.MaxIdleConns, 100)
+	c.transport.MaxIdleConnsPerHost = intOr(config.MaxIdleConnsPerHost, 10)
+	c.transport.MaxConnsPerHost = config.MaxConnsPerHost
+
+	c.httpClient = &http.Client{
+		Transport: c,
+		Timeout:   time.Duration(config.Timeout) * time.Millisecond,
+	}
+	return c, nil
+}
+
+// Name - return the name of the client
+func (c *Client) Name() string {
+	return c.config.Name
+}
+
+// HTTPClient - return the standard client which sends the requests through this client, e.g. for a generated SDK
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// NewRequest - create a request of the path which is resolved against the base url
+// A gin context can be passed as ctx, then the call is canceled with the inbound request and its headers are propagated
+func (c *Client) NewRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	target, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(contextOf(ctx), method, target, body)
+}
+
+// Do - send the request, the response body must be closed by the caller
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.httpClient.Do(req)
+}
+
+// Get - send a GET request of the path
+func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Post - send a POST request of the path with the body, it is not retried
+func (c *Client) Post(ctx context.Context, path string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.Do(req)
+}
+
+// Close - close the idle connections of the client
+func (c *Client) Close() {
+	c.transport.CloseIdleConnections()
+}
+
+// RoundTrip - add the headers and send the request, the idempotent requests are retried on failure
+func (c *Client) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if gc, ok := ctx.(*gin.Context); ok {
+		ctx = contextOf(gc)
+	}
+	out := req.Clone(ctx)
+	if !out.URL.IsAbs() {
+		target, err := c.resolve(out.URL.String())
+		if err != nil {
+			return nil, err
+		}
+		if out.URL, err = url.Parse(target); err != nil {
+			return nil, err
+		}
+		out.Host = ""
+	}
+	c.addHeaders(ctx, out)
+
+	attempts := 1
+	if c.config.Retry.Attempts > 0 && isIdempotent(out.Method) &&
+		(out.Body == nil || out.Body == http.NoBody || out.GetBody != nil) {
+		attempts += c.config.Retry.Attempts
+	}
+
+	start := time.Now()
+	var retryAfter time.Duration
+	var resp *http.Response
+	var err error
+	attempt := 0
+	for ; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if waitErr := c.wait(ctx, attempt, retryAfter); waitErr != nil {
+				break
+			}
+			if out.GetBody != nil {
+				body, bodyErr := out.GetBody()
+				if bodyErr != nil {
+					return nil, bodyErr
+				}
+				out.Body = body
+			}
+		}
+
+		if !c.breaker.acquire() {
+			resp, err = nil, NewCircuitOpenErr(c.config.Name)
+			break
+		}
+		resp, err = c.transport.RoundTrip(out)
+		if err != nil {
+			if ctx.Err() != nil {
+				// the caller is gone
+				c.breaker.release()
+				break
+			}
+			c.breaker.report(false)
+			continue
+		}
+
+		c.breaker.report(resp.StatusCode < http.StatusInternalServerError)
+		if !slices.Contains(c.statuses, resp.StatusCode) || attempt == attempts-1 {
+			break
+		}
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		resp = nil
+	}
+
+	c.log(out, resp, err, min(attempt+1, attempts), time.Since(start))
+	if resp == nil && err == nil {
+		err = ctx.Err()
+	}
+	return resp, err
+}
+
+// MARK: Private functions
+
+// resolve - return the url of the path against the base url
+func (c *Client) resolve(path string) (string, error) {
+	target, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	if target.IsAbs() || c.baseURL == nil {
+		return target.String(), nil
+	}
+
+	// the last segment of the base url is kept, e.g. "http://users/api" + "/v1/users" -> "http://users/api/v1/users"
+	base := *c.baseURL
+	base.RawPath = ""
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return base.ResolveReference(&url.URL{Path: strings.TrimPrefix(target.Path, "/"), RawQuery: target.RawQuery}).String(), nil
+}
+
+// addHeaders - add the default headers, the credentials and the propagated headers which the request does not have
+func (c *Client) addHeaders(ctx context.Context, out *http.Request) {
+	for name, value := range c.config.Headers {
+		if out.Header.Get(name) == "" {
+			out.Header.Set(name, value)
+		}
+	}
+
+	auth := c.config.Auth
+	switch auth.Type {
+	case BasicAuth:
+		if out.Header.Get("Authorization") == "" {
+			out.SetBasicAuth(auth.Username, auth.Password)
+		}
+	case BearerAuth:
+		if out.Header.Get("Authorization") == "" {
+			out.Header.Set("Authorization", "Bearer "+auth.Token)
+		}
+	case HeaderAuth:
+		if out.Header.Get(auth.Header) == "" {
+			out.Header.Set(auth.Header, auth.Token)
+		}
+	}
+
+	propagate(ctx, out, c.propagate)
+}
+
+// wait - wait for the backoff of the retry, the Retry-After of the upstream is respected up to the max backoff
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	backoff := c.backoff << (attempt - 1)
+	if backoff > c.maxBackoff || backoff <= 0 {
+		backoff = c.maxBackoff
+	}
+	if jitter := c.config.Retry.Jitter; jitter > 0 {
+		backoff -= time.Duration(rand.Float64() * jitter * float64(backoff))
+	}
+	if retryAfter > backoff {
+		backoff = min(retryAfter, c.maxBackoff)
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// log - log the call, the failed calls are logged as errors
+func (c *Client) log(req *http.Request, resp *http.Response, err error, attempts int, duration time.Duration) {
+	l, _ := logger.GetManager().GetLoggerWithContext(req.Context())
+	if l == nil {
+		return
+	}
+
+	details := map[string]interface{}{
+		"client":     c.config.Name,
+		"method":     req.Method,
+		"url":        req.URL.Redacted(),
+		"request_id": req.Header.Get(RequestIDHeader),
+		"attempts":   attempts,
+		"duration":   duration.Milliseconds(),
+	}
+	level, message := types.DEBUG, "The upstream call is finished ..."
+	if err != nil {
+		details["error"] = err.Error()
+		if req.Context().Err() == nil {
+			level, message = types.ERROR, "The upstream call failed ..."
+		}
+	} else if resp != nil {
+		details["status"] = resp.StatusCode
+		if resp.StatusCode >= http.StatusInternalServerError {
+			level, message = types.ERROR, "The upstream call failed ..."
+		}
+	}
+	l.Log(types.NewLogObject(level, "httpclient.Client.RoundTrip", HttpClientMaintenanceType, time.Now(), message, details))
+}
+
+// parseRetryAfter - return the wait of a Retry-After header in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// isIdempotent - check whether the request can be sent again
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// millisecondsOr - return the milliseconds as a duration, or the default if they are not set
+func millisecondsOr(milliseconds int, defaultValue time.Duration) time.Duration {
+	if milliseconds <= 0 {
+		return defaultValue
+	}
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+// intOr - return the value, or the default if it is not set
+func intOr(value int, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// ConfigErr Error
+type ConfigErr struct {
+	Name   string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *ConfigErr) Error() string {
+	return fmt.Sprintf("The config of the http client `%v` is not valid: %v", err.Name, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewConfigErr - return a new instance of ConfigErr
+func NewConfigErr(name string, reason string) error {
+	return &ConfigErr{Name: name, Reason: reason}
+}
+
+// ClientNotFoundErr Error
+type ClientNotFoundErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *ClientNotFoundErr) Error() string {
+	return fmt.Sprintf("The http client `%v` is not defined", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *ClientNotFoundErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewClientNotFoundErr - return a new instance of ClientNotFoundErr
+func NewClientNotFoundErr(name string) error {
+	return &ClientNotFoundErr{Name: name}
+}
+
+// CircuitOpenErr Error - returned without sending the request while the circuit of the client is open
+type CircuitOpenErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *CircuitOpenErr) Error() string {
+	return fmt.Sprintf("The circuit of the http client `%v` is open", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *CircuitOpenErr) ErrorCode() errs.Code {
+	return errs.Unavailable
+}
+
+// NewCircuitOpenErr - return a new instance of CircuitOpenErr
+func NewCircuitOpenErr(name string) error {
+	return &CircuitOpenErr{Name: name}
+}
@@ -0,0 +1,196 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../..", "test", "Gonyx")
+}
+
+func TestClient_HeadersAndPropagation(t *testing.T) {
+	makeReadyConfigManager()
+
+	var received http.Header
+	var path string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		path = r.URL.RequestURI()
+	}))
+	defer upstream.Close()
+
+	client, err := New(Config{
+		Name:    "users",
+		BaseURL: upstream.URL + "/api",
+		Headers: map[string]string{"User-Agent": "gonyx", "X-Tenant": "default"},
+		Auth:    AuthConfig{Type: BearerAuth, Token: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Creating client --> Expected: %v, but got %v", nil, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", func(c *gin.Context) {
+		req, err := client.NewRequest(c, http.MethodGet, "/v1/users?page=2", nil)
+		if err != nil {
+			t.Fatalf("Creating request --> Expected: %v, but got %v", nil, err)
+		}
+		req.Header.Set("X-Tenant", "acme")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Sending request --> Expected: %v, but got %v", nil, err)
+		}
+		_ = resp.Body.Close()
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-ID", "req-1")
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.Header.Set("Cookie", "session=private")
+	engine.ServeHTTP(httptest.NewRecorder(), r)
+
+	if path != "/api/v1/users?page=2" {
+		t.Errorf("Resolving path --> Expected: %v, but got %v", "/api/v1/users?page=2", path)
+	}
+	expected := map[string]string{
+		"User-Agent":    "gonyx",
+		"X-Tenant":      "acme",
+		"Authorization": "Bearer secret",
+		"X-Request-Id":  "req-1",
+		"Cookie":        "",
+	}
+	for name, value := range expected {
+		if received.Get(name) != value {
+			t.Errorf("Header %v --> Expected: %v, but got %v", name, value, received.Get(name))
+		}
+	}
+
+	traceparent := received.Get("traceparent")
+	if !strings.HasPrefix(traceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") || strings.Contains(traceparent, "00f067aa0ba902b7") ||
+		!strings.HasSuffix(traceparent, "-01") {
+		t.Errorf("Child traceparent --> Expected: %v, but got %v", "00-4bf92f3577b34da6a3ce929d0e0e4736-<new span>-01", traceparent)
+	}
+
+	// a call without an inbound request has a new request id
+	resp, err := client.Get(context.Background(), "health")
+	if err != nil {
+		t.Fatalf("Sending request --> Expected: %v, but got %v", nil, err)
+	}
+	_ = resp.Body.Close()
+	if len(received.Get("X-Request-ID")) != 32 || received.Get("traceparent") != "" || path != "/api/health" {
+		t.Errorf("Request without inbound --> Expected: %v, but got %v %v %v", "a new request id", received.Get("X-Request-ID"), received.Get("traceparent"), path)
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	makeReadyConfigManager()
+
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer upstream.Close()
+
+	client, err := New(Config{Name: "retry", BaseURL: upstream.URL, Retry: RetryConfig{Attempts: 2, Backoff: 1, Jitter: 0.5}})
+	if err != nil {
+		t.Fatalf("Creating client --> Expected: %v, but got %v", nil, err)
+	}
+
+	req, _ := client.NewRequest(context.Background(), http.MethodPut, "/items/1", strings.NewReader("payload"))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Sending request --> Expected: %v, but got %v", nil, err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "payload" || calls.Load() != 3 {
+		t.Errorf("Retried request --> Expected: %v %v %v, but got %v %v %v", http.StatusOK, "payload", 3, resp.StatusCode, string(body), calls.Load())
+	}
+
+	// the non-idempotent requests are not retried
+	calls.Store(0)
+	resp, err = client.Post(context.Background(), "/items", "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Sending request --> Expected: %v, but got %v", nil, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("Post request --> Expected: %v %v, but got %v %v", http.StatusServiceUnavailable, 1, resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	makeReadyConfigManager()
+
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer upstream.Close()
+
+	client, err := New(Config{Name: "breaker", BaseURL: upstream.URL, CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: 60000}})
+	if err != nil {
+		t.Fatalf("Creating client --> Expected: %v, but got %v", nil, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.Background(), "/")
+		if err != nil {
+			t.Fatalf("Sending request --> Expected: %v, but got %v", nil, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	_, err = client.Get(context.Background(), "/")
+	var circuitOpenErr *CircuitOpenErr
+	if !errors.As(err, &circuitOpenErr) || calls.Load() != 2 {
+		t.Errorf("Open circuit --> Expected: %v %v, but got %v %v", "CircuitOpenErr", 2, err, calls.Load())
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	configs := []Config{
+		{Name: "relative", BaseURL: "/api"},
+		{Name: "auth", Auth: AuthConfig{Type: "digest"}},
+		{Name: "header", Auth: AuthConfig{Type: HeaderAuth}},
+		{Name: "jitter", Retry: RetryConfig{Jitter: 2}},
+	}
+	for _, item := range configs {
+		var configErr *ConfigErr
+		if _, err := New(item); !errors.As(err, &configErr) {
+			t.Errorf("Invalid config %v --> Expected: %v, but got %v", item.Name, "ConfigErr", err)
+		}
+	}
+}
+
+func TestManager_AddClient(t *testing.T) {
+	makeReadyConfigManager()
+
+	if _, err := GetManager().GetClient("missing"); err == nil {
+		t.Errorf("Missing client --> Expected an error, but got %v", err)
+	}
+	client, err := GetManager().AddClient(Config{Name: "billing", BaseURL: "http://127.0.0.1:9999"})
+	if err != nil {
+		t.Fatalf("Adding client --> Expected: %v, but got %v", nil, err)
+	}
+	if got, err := GetManager().GetClient("billing"); err != nil || got != client {
+		t.Errorf("Getting client --> Expected: %v, but got %v %v", client, got, err)
+	}
+}
@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+)
+
+// Mark: manager
+
+// manager object
+type manager struct {
+	name       string
+	lock       sync.RWMutex
+	clients    map[string]*Client // the clients of the config
+	registered map[string]*Client // the clients registered by the application, they are kept on reload
+}
+
+// MARK: Module variables
+var managerInstance *manager = nil
+var once sync.Once
+
+// Module init function
+func init() {
+	log.Println("Http Client Manager Package Initialized...")
+}
+
+// init - Manager Constructor - It creates the clients of the config, the previous ones are closed
+func (m *manager) init() {
+	m.name = "httpclient"
+
+	clients := make(map[string]*Client)
+	if clientsCfg, err := config.GetManager().Get(m.name, "clients"); err == nil {
+		items, _ := clientsCfg.([]interface{})
+		for _, item := range items {
+			jsonBody, err := json.Marshal(item)
+			if err != nil {
+				continue
+			}
+
+			var obj Config
+			if err := json.Unmarshal(jsonBody, &obj); err != nil {
+				continue
+			}
+
+			client, err := New(obj)
+			if err != nil {
+				l, _ := logger.GetManager().GetLogger()
+				if l != nil {
+					l.Log(types.NewLogObject(types.ERROR, "httpclient.manager.init", HttpClientMaintenanceType, time.Now(), "Creating the http client failed ...", map[string]interface{}{"client": obj.Name, "error": err.Error()}))
+				}
+				continue
+			}
+			clients[obj.Name] = client
+		}
+	}
+
+	m.lock.Lock()
+	previous := m.clients
+	m.clients = clients
+	if m.registered == nil {
+		m.registered = make(map[string]*Client)
+	}
+	m.lock.Unlock()
+
+	// the calls in flight keep their connections, only the idle ones are closed
+	for _, client := range previous {
+		client.Close()
+	}
+}
+
+// restartOnChangeConfig - subscribe a function for when the config is changed
+func (m *manager) restartOnChangeConfig() {
+	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	if err == nil {
+		wrapper.RegisterChangeCallback(func() interface{} {
+			m.init()
+			return nil
+		})
+	}
+}
+
+// MARK: Public functions
+
+// GetManager - This function returns singleton instance of Http Client Manager
+func GetManager() *manager {
+	// once used for prevent race condition and manage critical section.
+	once.Do(func() {
+		managerInstance = &manager{}
+		managerInstance.init()
+		managerInstance.restartOnChangeConfig()
+	})
+	return managerInstance
+}
+
+// GetClient - return the client by name
+// The clients registered by the application take precedence over the ones of the config
+func (m *manager) GetClient(name string) (*Client, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if client, ok := m.registered[name]; ok {
+		return client, nil
+	}
+	if client, ok := m.clients[name]; ok {
+		return client, nil
+	}
+	return nil, NewClientNotFoundErr(name)
+}
+
+// AddClient - create a client of the config and register it by its name
+func (m *manager) AddClient(config Config) (*Client, error) {
+	client, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	previous := m.registered[config.Name]
+	m.registered[config.Name] = client
+	m.lock.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+	return client, nil
+}
@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MARK: Variables
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceparentHeader = "traceparent"
+)
+
+// defaultPropagate - the headers of the inbound request which are sent on the outbound calls by default
+var defaultPropagate = []string{RequestIDHeader, "X-Correlation-ID", TraceparentHeader, "tracestate", "baggage"}
+
+// inboundContextKey - the key of the inbound headers in a context
+type inboundContextKey struct{}
+
+// MARK: Public functions
+
+// WithInboundHeaders - return a copy of the context which carries the headers of the inbound request
+// The headers of the propagate list are sent on the calls which are made with the context
+func WithInboundHeaders(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, inboundContextKey{}, header)
+}
+
+// FromGin - return the context of the inbound request which carries its headers to the outbound calls
+// The request id which is set on the response by a middleware is used if the request does not have one
+func FromGin(c *gin.Context) context.Context {
+	header := c.Request.Header.Clone()
+	if header.Get(RequestIDHeader) == "" {
+		if requestID := c.Writer.Header().Get(RequestIDHeader); requestID != "" {
+			header.Set(RequestIDHeader, requestID)
+		}
+	}
+	return WithInboundHeaders(c.Request.Context(), header)
+}
+
+// MARK: Private functions
+
+// contextOf - return the context of the outbound call, a gin context is replaced by the context of its request
+func contextOf(ctx context.Context) context.Context {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		return FromGin(c)
+	}
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// propagate - copy the headers of the inbound request, a new request id is set if there is none
+// The trace is continued by a new span id, so the upstream sees this call as the parent of its spans
+func propagate(ctx context.Context, out *http.Request, names []string) {
+	inbound, _ := ctx.Value(inboundContextKey{}).(http.Header)
+	for _, name := range names {
+		if out.Header.Get(name) != "" {
+			continue
+		}
+		value := inbound.Get(name)
+		if strings.EqualFold(name, TraceparentHeader) {
+			value = childTraceparent(value)
+		}
+		if value != "" {
+			out.Header.Set(name, value)
+		}
+	}
+	if out.Header.Get(RequestIDHeader) == "" {
+		out.Header.Set(RequestIDHeader, randomHex(16))
+	}
+}
+
+// childTraceparent - return the W3C traceparent of a child span, or an empty string if the parent is not valid
+// The format is "<version>-<trace id>-<parent id>-<flags>", e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+func childTraceparent(parent string) string {
+	parts := strings.Split(strings.TrimSpace(parent), "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" || !isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) ||
+		parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	return "00-" + parts[1] + "-" + randomHex(8) + "-" + parts[3]
+}
+
+// isHex - check whether the value has the length and only has lowercase hex digits
+func isHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex - return the hex of n random bytes
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
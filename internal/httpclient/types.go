@@ -0,0 +1,66 @@
+package httpclient
+
+// Config - defines a client of an upstream service in the `clients` of the httpclient config.
+// All durations are in milliseconds.
+type Config struct {
+	Name    string `json:"name"`
+	BaseURL string `json:"base_url"` // the relative paths of the requests are resolved against it, e.g. "http://users:8080/api/"
+
+	// Timeout is the limit of a call including the retries and reading the body, zero means no timeout
+	Timeout int `json:"timeout"`
+
+	DialTimeout           int `json:"dial_timeout"`            // default: 5000
+	TLSHandshakeTimeout   int `json:"tls_handshake_timeout"`   // default: 10000
+	ResponseHeaderTimeout int `json:"response_header_timeout"` // zero means no timeout
+	IdleConnTimeout       int `json:"idle_conn_timeout"`       // default: 90000
+
+	MaxIdleConns        int `json:"max_idle_conns"`          // default: 100
+	MaxIdleConnsPerHost int `json:"max_idle_conns_per_host"` // default: 10
+	MaxConnsPerHost     int `json:"max_conns_per_host"`      // zero means no limit
+
+	// Headers are added to the requests which do not have them, e.g. "User-Agent"
+	Headers map[string]string `json:"headers"`
+
+	Auth           AuthConfig           `json:"auth"`
+	Retry          RetryConfig          `json:"retry"`
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker"`
+
+	// Propagate lists the headers of the inbound request which are sent on the outbound calls
+	// (default: X-Request-ID, X-Correlation-ID, traceparent, tracestate and baggage)
+	Propagate []string `json:"propagate"`
+}
+
+// AuthConfig - defines the credentials which are added to the requests.
+type AuthConfig struct {
+	// Type is "basic", "bearer" or "header", empty means no credentials
+	Type     string `json:"type"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`  // the token of the "bearer" and the value of the "header" types
+	Header   string `json:"header"` // the header of the "header" type, e.g. "X-API-Key"
+}
+
+// RetryConfig - defines the retries of the idempotent requests.
+type RetryConfig struct {
+	// Attempts is the number of retries after the first try, zero disables the retries
+	Attempts int `json:"attempts"`
+
+	// Backoff is the wait before the first retry, it is doubled on each retry up to MaxBackoff (default: 100 and 2000)
+	Backoff    int `json:"backoff"`
+	MaxBackoff int `json:"max_backoff"`
+
+	// Jitter is the random fraction of the backoff which is removed from each wait, e.g. 0.2 waits 80% to 100% of it
+	Jitter float64 `json:"jitter"`
+
+	// Statuses are the response statuses which are retried (default: 429, 502, 503 and 504)
+	Statuses []int `json:"statuses"`
+}
+
+// CircuitBreakerConfig - defines when the client stops sending the requests after failures.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the consecutive failures which open the circuit, zero disables the circuit breaker
+	FailureThreshold int `json:"failure_threshold"`
+
+	// OpenTimeout is the duration before a trial request is sent again (default: 30 seconds)
+	OpenTimeout int `json:"open_timeout"`
+}
@@ -0,0 +1,23 @@
+package i18n
+
+// builtinMessages - the default messages of the validation errors, the catalogs override them
+var builtinMessages = map[string]catalog{
+	defaultLocale: {
+		"validation.default":  {text: "The {field} field is not valid"},
+		"validation.required": {text: "The {field} field is required"},
+		"validation.email":    {text: "The {field} field must be a valid email address"},
+		"validation.url":      {text: "The {field} field must be a valid url"},
+		"validation.uuid":     {text: "The {field} field must be a valid uuid"},
+		"validation.numeric":  {text: "The {field} field must be numeric"},
+		"validation.alphanum": {text: "The {field} field must contain only letters and numbers"},
+		"validation.oneof":    {text: "The {field} field must be one of {param}"},
+		"validation.len":      {text: "The {field} field must have the length of {param}"},
+		"validation.min":      {text: "The {field} field must be at least {param}"},
+		"validation.max":      {text: "The {field} field must be at most {param}"},
+		"validation.gte":      {text: "The {field} field must be greater than or equal to {param}"},
+		"validation.lte":      {text: "The {field} field must be less than or equal to {param}"},
+		"validation.gt":       {text: "The {field} field must be greater than {param}"},
+		"validation.lt":       {text: "The {field} field must be less than {param}"},
+		"validation.eqfield":  {text: "The {field} field must be equal to the {param} field"},
+	},
+}
@@ -0,0 +1,179 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// MARK: Variables
+
+// placeholderPattern - the named parameters of a message, e.g. "Hello {name}"
+var placeholderPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// pluralForms - the names of the CLDR plural categories in the catalogs
+var pluralForms = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+// message - a translation, the plural forms are set if it depends on a count
+type message struct {
+	text    string
+	forms   map[plural.Form]string
+	exactly map[int]string // the forms of exact counts, e.g. "=0"
+}
+
+// catalog - the messages of a locale by their dot separated keys
+type catalog map[string]message
+
+// MARK: Private functions
+
+// format - choose the form of the count and replace the parameters
+func (m message) format(tag language.Tag, count *int, params map[string]any) string {
+	text := m.text
+	if count != nil && m.forms != nil {
+		if exact, ok := m.exactly[*count]; ok {
+			text = exact
+		} else if form, ok := m.forms[plural.Cardinal.MatchPlural(tag, abs(*count), 0, 0, 0, 0)]; ok {
+			text = form
+		} else {
+			text = m.forms[plural.Other]
+		}
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if value, ok := params[name]; ok {
+			return fmt.Sprint(value)
+		}
+		if name == "count" && count != nil {
+			return strconv.Itoa(*count)
+		}
+		return placeholder
+	})
+}
+
+// add - flatten the nested values into the catalog, a map of plural categories is a plural message
+func (c catalog) add(prefix string, value any) error {
+	switch v := value.(type) {
+	case string:
+		c[prefix] = message{text: v}
+	case map[string]any:
+		if msg, ok := pluralMessage(v); ok && prefix != "" {
+			c[prefix] = msg
+			return nil
+		}
+		for key, item := range v {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			if err := c.add(name, item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("the value of `%v` is not a message", prefix)
+	}
+	return nil
+}
+
+// pluralMessage - return the plural message if all keys are plural categories or exact counts and "other" is set
+func pluralMessage(values map[string]any) (message, bool) {
+	if _, ok := values["other"]; !ok {
+		return message{}, false
+	}
+
+	msg := message{forms: make(map[plural.Form]string), exactly: make(map[int]string)}
+	for key, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			return message{}, false
+		}
+		if form, ok := pluralForms[key]; ok {
+			msg.forms[form] = text
+			continue
+		}
+		if strings.HasPrefix(key, "=") {
+			if n, err := strconv.Atoi(key[1:]); err == nil {
+				msg.exactly[n] = text
+				continue
+			}
+		}
+		return message{}, false
+	}
+	msg.text = msg.forms[plural.Other]
+	return msg, true
+}
+
+// loadDir - read the catalogs under the directory of fsys into the catalogs by locale
+// A file is named by its locale, e.g. "fa.json", or it is in the directory of its locale, e.g. "fa/users.yaml"
+func loadDir(fsys fs.FS, dir string, catalogs map[string]catalog) error {
+	return fs.WalkDir(fsys, dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		extension := path.Ext(name)
+		if entry.IsDir() || (extension != ".json" && extension != ".yaml" && extension != ".yml") {
+			return nil
+		}
+
+		relative := name
+		if dir != "." {
+			relative = strings.TrimPrefix(name, strings.TrimSuffix(dir, "/")+"/")
+		}
+		locale := strings.TrimSuffix(relative, extension)
+		if i := strings.Index(relative, "/"); i >= 0 {
+			locale = relative[:i]
+		}
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return NewCatalogErr(name, err)
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return NewCatalogErr(name, err)
+		}
+		values := make(map[string]any)
+		if extension == ".json" {
+			err = json.Unmarshal(content, &values)
+		} else {
+			err = yaml.Unmarshal(content, &values)
+		}
+		if err != nil {
+			return NewCatalogErr(name, err)
+		}
+
+		c, ok := catalogs[tag.String()]
+		if !ok {
+			c = make(catalog)
+			catalogs[tag.String()] = c
+		}
+		if err := c.add("", values); err != nil {
+			return NewCatalogErr(name, err)
+		}
+		return nil
+	})
+}
+
+// abs - return the absolute value
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,32 @@
+package i18n
+
+import "context"
+
+// MARK: Variables
+
+// LocaleKey - the key of the locale in the keys of a gin context
+const LocaleKey = "gonyx.locale"
+
+// localeContextKey - the key of the locale in a context
+type localeContextKey struct{}
+
+// MARK: Public functions
+
+// WithLocale - return a copy of the context which carries the locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext - return the locale of the context, or the default locale if it has none
+// A gin context carries the locale in its keys, so it is accepted as well
+func LocaleFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if locale, ok := ctx.Value(localeContextKey{}).(string); ok {
+			return locale
+		}
+		if locale, ok := ctx.Value(LocaleKey).(string); ok {
+			return locale
+		}
+	}
+	return GetManager().DefaultLocale()
+}
@@ -0,0 +1,24 @@
+package i18n
+
+import "fmt"
+
+// CatalogErr Error
+type CatalogErr struct {
+	File string
+	Err  error
+}
+
+// Error method - satisfying error interface
+func (err *CatalogErr) Error() string {
+	return fmt.Sprintf("Loading the message catalog `%v` failed: %v", err.File, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *CatalogErr) Unwrap() error {
+	return err.Err
+}
+
+// NewCatalogErr - return a new instance of CatalogErr
+func NewCatalogErr(file string, err error) error {
+	return &CatalogErr{File: file, Err: err}
+}
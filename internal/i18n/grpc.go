@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MARK: Variables
+
+// localeMetadataKeys - the metadata of the locale in the order of precedence, the gateway prefixes the http headers
+var localeMetadataKeys = []string{"locale", "accept-language", "grpcgateway-accept-language"}
+
+// localeStream - a server stream with the context which carries the locale
+type localeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context - return the context which carries the locale
+func (s *localeStream) Context() context.Context {
+	return s.ctx
+}
+
+// MARK: Public functions
+
+// UnaryServerInterceptor - negotiate the locale of the call by its metadata and put it into the context
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		locale := negotiateMetadata(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", locale))
+		return handler(WithLocale(ctx, locale), req)
+	}
+}
+
+// StreamServerInterceptor - negotiate the locale of the stream by its metadata and put it into the context
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		locale := negotiateMetadata(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs("content-language", locale))
+		return handler(srv, &localeStream{ServerStream: ss, ctx: WithLocale(ss.Context(), locale)})
+	}
+}
+
+// MARK: Private functions
+
+// negotiateMetadata - return the supported locale of the incoming metadata
+func negotiateMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range localeMetadataKeys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return GetManager().Negotiate(values[0])
+		}
+	}
+	return GetManager().DefaultLocale()
+}
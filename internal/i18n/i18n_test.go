@@ -0,0 +1,227 @@
+package i18n
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../..", "test", "Gonyx")
+}
+
+// newManager - return a manager of the default locale without the config
+func newManager(defaultLocale string, dirs ...string) *manager {
+	return &manager{name: "i18n", defaultLocale: defaultLocale, dirs: dirs, messages: make(map[string]catalog)}
+}
+
+var catalogFiles = fstest.MapFS{
+	"locales/en.json": &fstest.MapFile{Data: []byte(`{
+		"greeting": "Hello {name}",
+		"inbox": {"messages": {"=0": "No messages", "one": "{count} message", "other": "{count} messages"}}
+	}`)},
+	"locales/fa/common.yaml": &fstest.MapFile{Data: []byte("greeting: \"سلام {name}\"\n")},
+	"locales/ru.yml": &fstest.MapFile{Data: []byte(`
+inbox:
+  messages:
+    one: "{count} сообщение"
+    few: "{count} сообщения"
+    many: "{count} сообщений"
+    other: "{count} сообщения"
+`)},
+}
+
+func TestManager_Translate(t *testing.T) {
+	m := newManager("en")
+	if err := m.LoadFS(catalogFiles, "locales"); err != nil {
+		t.Fatalf("Loading catalogs --> Expected: %v, but got %v", nil, err)
+	}
+
+	tests := []struct {
+		locale   string
+		key      string
+		expected string
+	}{
+		{"en", "greeting", "Hello ali"},
+		{"fa", "greeting", "سلام ali"},
+		{"fa-IR", "greeting", "سلام ali"},
+		{"ru", "greeting", "Hello ali"},
+		{"de", "greeting", "Hello ali"},
+		{"en", "missing.key", "missing.key"},
+		{"en", "validation.required", "The ali field is required"},
+	}
+	for _, test := range tests {
+		params := map[string]any{"name": "ali", "field": "ali"}
+		if got := m.Translate(test.locale, test.key, params); got != test.expected {
+			t.Errorf("Translate %v %v --> Expected: %v, but got %v", test.locale, test.key, test.expected, got)
+		}
+	}
+
+	plurals := []struct {
+		locale   string
+		count    int
+		expected string
+	}{
+		{"en", 0, "No messages"},
+		{"en", 1, "1 message"},
+		{"en", 5, "5 messages"},
+		{"ru", 1, "1 сообщение"},
+		{"ru", 3, "3 сообщения"},
+		{"ru", 5, "5 сообщений"},
+		{"ru", 21, "21 сообщение"},
+		{"fa", 2, "2 messages"},
+	}
+	for _, test := range plurals {
+		if got := m.TranslatePlural(test.locale, "inbox.messages", test.count, nil); got != test.expected {
+			t.Errorf("TranslatePlural %v %v --> Expected: %v, but got %v", test.locale, test.count, test.expected, got)
+		}
+	}
+
+	if locales := m.Locales(); len(locales) != 3 || locales[0] != "en" {
+		t.Errorf("Locales --> Expected: %v, but got %v", "[en fa ru]", locales)
+	}
+}
+
+func TestManager_Negotiate(t *testing.T) {
+	m := newManager("en")
+	if err := m.LoadFS(catalogFiles, "locales"); err != nil {
+		t.Fatalf("Loading catalogs --> Expected: %v, but got %v", nil, err)
+	}
+
+	tests := map[string]string{
+		"":                             "en",
+		"fa-IR,fa;q=0.9,en;q=0.8":      "fa",
+		"de-DE,ru;q=0.5":               "ru",
+		"de-DE":                        "en",
+		"invalid;;q=x":                 "en",
+		"en-US,en;q=0.9":               "en",
+		"ja,fa;q=0.1,ru;q=0.2,*;q=0.1": "ru",
+	}
+	for acceptLanguage, expected := range tests {
+		if got := m.Negotiate(acceptLanguage); got != expected {
+			t.Errorf("Negotiate %q --> Expected: %v, but got %v", acceptLanguage, expected, got)
+		}
+	}
+}
+
+func TestManager_AddMessagesAndErrors(t *testing.T) {
+	m := newManager("en")
+	if err := m.AddMessages("fa", map[string]any{"greeting": "درود {name}", "validation": map[string]any{"required": "{field} الزامی است"}}); err != nil {
+		t.Fatalf("Adding messages --> Expected: %v, but got %v", nil, err)
+	}
+	if err := m.LoadFS(catalogFiles, "locales"); err != nil {
+		t.Fatalf("Loading catalogs --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the files take precedence over the messages of the application
+	if got := m.Translate("fa", "greeting", map[string]any{"name": "ali"}); got != "سلام ali" {
+		t.Errorf("Files over messages --> Expected: %v, but got %v", "سلام ali", got)
+	}
+	if got := m.Translate("fa", "validation.required", map[string]any{"field": "نام"}); got != "نام الزامی است" {
+		t.Errorf("Messages over built-in --> Expected: %v, but got %v", "نام الزامی است", got)
+	}
+
+	if err := m.AddMessages("not a locale!", map[string]any{"a": "b"}); err == nil {
+		t.Errorf("Adding invalid locale --> Expected an error, but got %v", err)
+	}
+	if err := m.AddMessages("en", map[string]any{"a": 1}); err == nil {
+		t.Errorf("Adding invalid message --> Expected an error, but got %v", err)
+	}
+
+	// an invalid file keeps the previous catalogs
+	broken := fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"greeting": `)}}
+	if err := m.LoadFS(broken, "."); err == nil {
+		t.Errorf("Loading invalid catalog --> Expected an error, but got %v", err)
+	}
+	if got := m.Translate("en", "greeting", map[string]any{"name": "ali"}); got != "Hello ali" {
+		t.Errorf("Translate after failed load --> Expected: %v, but got %v", "Hello ali", got)
+	}
+}
+
+func TestManager_Watch(t *testing.T) {
+	makeReadyConfigManager()
+
+	dir := t.TempDir()
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "en.json"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Writing catalog --> Expected: %v, but got %v", nil, err)
+		}
+	}
+	write(`{"title": "v1"}`)
+
+	m := newManager("en", dir)
+	if err := m.Reload(); err != nil {
+		t.Fatalf("Loading catalogs --> Expected: %v, but got %v", nil, err)
+	}
+	m.watch()
+	defer m.unwatch()
+
+	write(`{"title": "v2"}`)
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Translate("en", "title", nil) != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Reloading changed catalog --> Expected: %v, but got %v", "v2", m.Translate("en", "title", nil))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	makeReadyConfigManager()
+	if err := GetManager().LoadFS(catalogFiles, "locales"); err != nil {
+		t.Fatalf("Loading catalogs --> Expected: %v, but got %v", nil, err)
+	}
+
+	tests := []struct {
+		md       metadata.MD
+		expected string
+	}{
+		{metadata.Pairs("accept-language", "fa-IR,en;q=0.5"), "fa"},
+		{metadata.Pairs("locale", "ru", "accept-language", "fa"), "ru"},
+		{metadata.Pairs("grpcgateway-accept-language", "ru-RU"), "ru"},
+		{metadata.MD{}, GetManager().DefaultLocale()},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		_, _ = UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			if got := LocaleFromContext(ctx); got != test.expected {
+				t.Errorf("Unary locale %v --> Expected: %v, but got %v", test.md, test.expected, got)
+			}
+			return nil, nil
+		})
+
+		stream := &testStream{ctx: ctx}
+		_ = StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+			if got := LocaleFromContext(ss.Context()); got != test.expected {
+				t.Errorf("Stream locale %v --> Expected: %v, but got %v", test.md, test.expected, got)
+			}
+			return nil
+		})
+		if got := stream.header.Get("content-language"); len(got) != 1 || got[0] != test.expected {
+			t.Errorf("Stream content language --> Expected: %v, but got %v", test.expected, got)
+		}
+	}
+}
+
+// testStream - a server stream which records its header
+type testStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
@@ -0,0 +1,355 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/watcher"
+	"golang.org/x/text/language"
+)
+
+// MARK: Variables
+
+var (
+	I18nMaintenanceType = types.NewLogType("I18N_MAINTENANCE")
+)
+
+const (
+	defaultLocale = "en"
+	defaultDir    = "./locales"
+)
+
+// fsSource - a directory of catalogs in the files registered by the application, e.g. an embed.FS
+type fsSource struct {
+	fsys fs.FS
+	dir  string
+}
+
+// Mark: manager
+
+// manager object
+type manager struct {
+	name          string
+	lock          sync.RWMutex
+	defaultLocale string
+	dirs          []string           // the directories of the config on the disk
+	sources       []fsSource         // the files registered by the application, they are kept on reload
+	messages      map[string]catalog // the messages registered by the application, they are kept on reload
+	catalogs      map[string]catalog // the messages of all sources by locale
+	tags          []language.Tag     // the locales of the catalogs, the default locale is the first one
+	matcher       language.Matcher
+	stopWatch     func()
+}
+
+// MARK: Module variables
+var managerInstance *manager = nil
+var once sync.Once
+
+// Module init function
+func init() {
+	log.Println("I18n Manager Package Initialized...")
+}
+
+// init - Manager Constructor - It reads the config and loads the catalogs
+func (m *manager) init() {
+	m.name = "i18n"
+
+	m.lock.Lock()
+	m.defaultLocale = defaultLocale
+	if value, err := config.GetManager().Get(m.name, "default_locale"); err == nil {
+		if locale, ok := value.(string); ok && locale != "" {
+			m.defaultLocale = language.Make(locale).String()
+		}
+	}
+
+	m.dirs = []string{defaultDir}
+	if value, err := config.GetManager().Get(m.name, "dirs"); err == nil {
+		if items, ok := value.([]interface{}); ok {
+			m.dirs = nil
+			for _, item := range items {
+				if dir, ok := item.(string); ok {
+					m.dirs = append(m.dirs, dir)
+				}
+			}
+		}
+	}
+
+	reload := false
+	if value, err := config.GetManager().Get(m.name, "reload"); err == nil {
+		reload, _ = value.(bool)
+	}
+	if env, err := config.GetManager().Get("app", "env"); err == nil && env == "dev" {
+		reload = true
+	}
+	if m.messages == nil {
+		m.messages = make(map[string]catalog)
+	}
+	m.lock.Unlock()
+
+	if err := m.Reload(); err != nil {
+		m.logError("i18n.manager.init", "Loading the message catalogs failed ...", err)
+	}
+	if reload {
+		m.watch()
+	}
+}
+
+// restartOnChangeConfig - subscribe a function for when the config is changed
+func (m *manager) restartOnChangeConfig() {
+	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	if err == nil {
+		wrapper.RegisterChangeCallback(func() interface{} {
+			m.unwatch()
+			m.init()
+			return nil
+		})
+	}
+}
+
+// MARK: Public functions
+
+// GetManager - This function returns singleton instance of I18n Manager
+func GetManager() *manager {
+	// once used for prevent race condition and manage critical section.
+	once.Do(func() {
+		managerInstance = &manager{}
+		managerInstance.init()
+		managerInstance.restartOnChangeConfig()
+	})
+	return managerInstance
+}
+
+// DefaultLocale - return the locale which is used when the requested one is not supported
+func (m *manager) DefaultLocale() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.defaultLocale
+}
+
+// Locales - return the locales of the catalogs, the default locale is the first one
+func (m *manager) Locales() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	locales := make([]string, len(m.tags))
+	for i, tag := range m.tags {
+		locales[i] = tag.String()
+	}
+	return locales
+}
+
+// Negotiate - return the best supported locale of an `Accept-Language` value, e.g. "fa-IR,fa;q=0.9,en;q=0.8"
+func (m *manager) Negotiate(acceptLanguage string) string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 || m.matcher == nil {
+		return m.defaultLocale
+	}
+	_, index, confidence := m.matcher.Match(tags...)
+	if confidence == language.No {
+		return m.defaultLocale
+	}
+	return m.tags[index].String()
+}
+
+// Translate - return the message of the key in the locale, the parent locales and the default locale are tried too
+// The key itself is returned if no catalog has the message
+func (m *manager) Translate(locale string, key string, params map[string]any) string {
+	msg, tag, ok := m.lookup(locale, key)
+	if !ok {
+		return key
+	}
+	return msg.format(tag, nil, params)
+}
+
+// TranslatePlural - return the plural form of the message for the count, `{count}` is replaced by the count
+func (m *manager) TranslatePlural(locale string, key string, count int, params map[string]any) string {
+	msg, tag, ok := m.lookup(locale, key)
+	if !ok {
+		return key
+	}
+	return msg.format(tag, &count, params)
+}
+
+// AddMessages - add the messages of the locale, the nested maps are flattened to dot separated keys
+// The messages of the files take precedence, so they can be used as the defaults of a module
+func (m *manager) AddMessages(locale string, messages map[string]any) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return NewCatalogErr(locale, err)
+	}
+
+	m.lock.Lock()
+	c, ok := m.messages[tag.String()]
+	if !ok {
+		c = make(catalog)
+		m.messages[tag.String()] = c
+	}
+	err = c.add("", map[string]any(messages))
+	m.lock.Unlock()
+
+	if err != nil {
+		return NewCatalogErr(locale, err)
+	}
+	return m.Reload()
+}
+
+// LoadFS - load the catalogs of the directory in the files, e.g. an embed.FS of a production build
+func (m *manager) LoadFS(fsys fs.FS, dir string) error {
+	if dir == "" {
+		dir = "."
+	}
+	m.lock.Lock()
+	m.sources = append(m.sources, fsSource{fsys: fsys, dir: dir})
+	m.lock.Unlock()
+
+	return m.Reload()
+}
+
+// Reload - load the catalogs of all sources again, the previous catalogs are kept on error
+func (m *manager) Reload() error {
+	// the later layers override the messages of the former ones
+	catalogs := make(map[string]catalog)
+	supported := make(map[string]bool) // the built-in messages do not make a locale supported
+	merge := func(layer map[string]catalog, isBuiltin bool) {
+		for locale, messages := range layer {
+			if !isBuiltin {
+				supported[locale] = true
+			}
+			c, ok := catalogs[locale]
+			if !ok {
+				c = make(catalog)
+				catalogs[locale] = c
+			}
+			for key, msg := range messages {
+				c[key] = msg
+			}
+		}
+	}
+
+	m.lock.RLock()
+	merge(builtinMessages, true)
+	merge(m.messages, false)
+	sources := append([]fsSource{}, m.sources...)
+	for _, dir := range m.dirs {
+		if _, err := os.Stat(dir); err == nil {
+			sources = append(sources, fsSource{fsys: os.DirFS(dir), dir: "."})
+		}
+	}
+	defaultTag := language.Make(m.defaultLocale)
+	m.lock.RUnlock()
+
+	for _, source := range sources {
+		loaded := make(map[string]catalog)
+		if err := loadDir(source.fsys, source.dir, loaded); err != nil {
+			return err
+		}
+		merge(loaded, false)
+	}
+
+	tags := []language.Tag{defaultTag}
+	var others []string
+	for locale := range supported {
+		if locale != defaultTag.String() {
+			others = append(others, locale)
+		}
+	}
+	sort.Strings(others)
+	for _, locale := range others {
+		tags = append(tags, language.Make(locale))
+	}
+
+	m.lock.Lock()
+	m.catalogs = catalogs
+	m.tags = tags
+	m.matcher = language.NewMatcher(tags)
+	m.lock.Unlock()
+	return nil
+}
+
+// MARK: Private functions
+
+// lookup - find the message in the locale, its parents, the default locale and the built-in locale
+func (m *manager) lookup(locale string, key string) (message, language.Tag, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, candidate := range []string{locale, m.defaultLocale, defaultLocale} {
+		tag, err := language.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		for t := tag; ; t = t.Parent() {
+			if msg, ok := m.catalogs[t.String()][key]; ok {
+				return msg, t, true
+			}
+			if t == language.Und {
+				break
+			}
+		}
+	}
+	return message{}, language.Und, false
+}
+
+// watch - load the catalogs again when the directories of the config are changed
+func (m *manager) watch() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var dirs []string
+	for _, dir := range m.dirs {
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 || m.stopWatch != nil {
+		return
+	}
+
+	stop, err := watcher.GetManager().Watch(dirs, func(changedPath string) {
+		if err := m.Reload(); err != nil {
+			m.logError("i18n.manager.watch", "Reloading the message catalogs failed ...", err)
+		}
+	})
+	if err != nil {
+		m.logError("i18n.manager.watch", "Watching the message catalogs failed ...", err)
+		return
+	}
+	m.stopWatch = stop
+}
+
+// unwatch - stop the watcher if it is running
+func (m *manager) unwatch() {
+	m.lock.Lock()
+	stop := m.stopWatch
+	m.stopWatch = nil
+	m.lock.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// logError - log the error of the catalogs
+func (m *manager) logError(section string, message string, err error) {
+	var catalogErr *CatalogErr
+	details := map[string]interface{}{"error": err.Error()}
+	if errors.As(err, &catalogErr) {
+		details["file"] = catalogErr.File
+	}
+
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(types.NewLogObject(types.ERROR, section, I18nMaintenanceType, time.Now(), message, details))
+	}
+}
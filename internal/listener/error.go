@@ -0,0 +1,59 @@
+package listener
+
+import "fmt"
+
+// ListenErr Error
+type ListenErr struct {
+	Address string
+	Err     error
+}
+
+// Error method - satisfying error interface
+func (err *ListenErr) Error() string {
+	return fmt.Sprintf("Listening on `%v` encountered an error: %v", err.Address, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *ListenErr) Unwrap() error {
+	return err.Err
+}
+
+// NewListenErr - return a new instance of ListenErr
+func NewListenErr(address string, err error) error {
+	return &ListenErr{Address: address, Err: err}
+}
+
+// SystemdListenerNotFoundErr Error
+type SystemdListenerNotFoundErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *SystemdListenerNotFoundErr) Error() string {
+	return fmt.Sprintf("There is no socket passed by systemd with the name or index: %v", err.Name)
+}
+
+// NewSystemdListenerNotFoundErr - return a new instance of SystemdListenerNotFoundErr
+func NewSystemdListenerNotFoundErr(name string) error {
+	return &SystemdListenerNotFoundErr{Name: name}
+}
+
+// HandoffErr Error
+type HandoffErr struct {
+	Err error
+}
+
+// Error method - satisfying error interface
+func (err *HandoffErr) Error() string {
+	return fmt.Sprintf("Handing the listeners to the child process encountered an error: %v", err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *HandoffErr) Unwrap() error {
+	return err.Err
+}
+
+// NewHandoffErr - return a new instance of HandoffErr
+func NewHandoffErr(err error) error {
+	return &HandoffErr{Err: err}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package listener
+
+import "os"
+
+// HandoffSignal - the listeners handoff is not supported on this platform
+var HandoffSignal os.Signal = nil
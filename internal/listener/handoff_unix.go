@@ -0,0 +1,11 @@
+//go:build unix
+
+package listener
+
+import (
+	"os"
+	"syscall"
+)
+
+// HandoffSignal - the signal asking the process to hand its listeners to a new process
+var HandoffSignal os.Signal = syscall.SIGUSR2
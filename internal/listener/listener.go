@@ -0,0 +1,274 @@
+package listener
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MARK: Variables
+
+const (
+	unixScheme    = "unix://"
+	systemdScheme = "systemd://"
+
+	// inheritedListenersEnv - the keys of the listeners passed by the parent process, in the order of the files from fd 3
+	inheritedListenersEnv = "GONYX_INHERITED_LISTENERS"
+	firstPassedFd         = 3
+)
+
+var (
+	lock        sync.Mutex
+	passedOnce  sync.Once
+	passedFiles map[string]*os.File     // the sockets passed by systemd or the parent process by key
+	active      map[string]net.Listener // the open listeners by key, handed to the child process
+)
+
+// SocketConfig - defines the permissions of a unix domain socket
+type SocketConfig struct {
+	Mode  string `json:"mode"`  // octal permission bits, e.g. "0660"
+	Owner string `json:"owner"` // user name or uid
+	Group string `json:"group"` // group name or gid
+}
+
+// MARK: Public functions
+
+// Listen - listen on the address, which can be "unix:///path.sock", "systemd://<name or index>" or a network address
+// A socket passed by systemd or by the parent process for the same address is used instead of a new one
+func Listen(network string, address string, socket SocketConfig) (net.Listener, error) {
+	key, network, address := parseAddress(network, address)
+	loadPassedFiles()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	var l net.Listener
+	var err error
+	if file, ok := passedFiles[key]; ok {
+		// the file is duplicated, so the socket stays open for the next listen after a restart
+		l, err = net.FileListener(file)
+	} else {
+		switch network {
+		case "systemd":
+			err = NewSystemdListenerNotFoundErr(address)
+		case "unix":
+			l, err = listenUnix(address, socket)
+		default:
+			l, err = net.Listen(network, address)
+		}
+	}
+	if err != nil {
+		return nil, NewListenErr(address, err)
+	}
+
+	if active == nil {
+		active = make(map[string]net.Listener)
+	}
+	active[key] = l
+	return &trackedListener{Listener: l, key: key}, nil
+}
+
+// Handoff - start a copy of the process which inherits all open listeners, the caller should stop gracefully after it
+func Handoff() (*os.Process, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, NewHandoffErr(err)
+	}
+
+	var keys []string
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	for key, l := range active {
+		var file *os.File
+		switch item := l.(type) {
+		case *net.TCPListener:
+			file, err = item.File()
+		case *net.UnixListener:
+			// the socket path belongs to the child process from now on
+			item.SetUnlinkOnClose(false)
+			file, err = item.File()
+		default:
+			err = errors.New("the listener cannot be passed to another process")
+		}
+		if err != nil {
+			return nil, NewHandoffErr(err)
+		}
+		keys = append(keys, key)
+		files = append(files, file)
+	}
+
+	encodedKeys, err := json.Marshal(keys)
+	if err != nil {
+		return nil, NewHandoffErr(err)
+	}
+
+	var env []string
+	for _, item := range os.Environ() {
+		if !strings.HasPrefix(item, "LISTEN_") && !strings.HasPrefix(item, inheritedListenersEnv+"=") {
+			env = append(env, item)
+		}
+	}
+	env = append(env, inheritedListenersEnv+"="+string(encodedKeys))
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	if err := cmd.Start(); err != nil {
+		return nil, NewHandoffErr(err)
+	}
+	return cmd.Process, nil
+}
+
+// MARK: Private functions
+
+// parseAddress - return the key, the network and the address without the scheme
+func parseAddress(network string, address string) (string, string, string) {
+	switch {
+	case strings.HasPrefix(address, unixScheme):
+		network = "unix"
+		address = strings.TrimPrefix(address, unixScheme)
+	case strings.HasPrefix(address, systemdScheme):
+		network = "systemd"
+		address = strings.TrimPrefix(address, systemdScheme)
+	case network == "":
+		network = "tcp"
+	}
+	return network + "|" + address, network, address
+}
+
+// loadPassedFiles - read the sockets of systemd socket activation (`LISTEN_FDS`) and of the parent process
+func loadPassedFiles() {
+	passedOnce.Do(func() {
+		passedFiles = make(map[string]*os.File)
+
+		if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err == nil && pid == os.Getpid() {
+			count, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+			names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+			for i := 0; i < count; i++ {
+				file := os.NewFile(uintptr(firstPassedFd+i), "systemd-"+strconv.Itoa(i))
+				passedFiles["systemd|"+strconv.Itoa(i)] = file
+				if i < len(names) && names[i] != "" {
+					passedFiles["systemd|"+names[i]] = file
+				}
+			}
+
+			// the children must not take them as their own
+			_ = os.Unsetenv("LISTEN_PID")
+			_ = os.Unsetenv("LISTEN_FDS")
+			_ = os.Unsetenv("LISTEN_FDNAMES")
+		}
+
+		if value := os.Getenv(inheritedListenersEnv); value != "" {
+			var keys []string
+			if err := json.Unmarshal([]byte(value), &keys); err == nil {
+				for i, key := range keys {
+					passedFiles[key] = os.NewFile(uintptr(firstPassedFd+i), key)
+				}
+			}
+			_ = os.Unsetenv(inheritedListenersEnv)
+		}
+	})
+}
+
+// listenUnix - listen on the unix domain socket and apply the permissions
+func listenUnix(path string, socket SocketConfig) (net.Listener, error) {
+	// the socket of a previous process is removed unless it is still accepting connections
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, errors.New("the socket is in use by another process")
+		}
+		_ = os.Remove(path)
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySocketConfig(path, socket); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// applySocketConfig - change the mode and the ownership of the socket file
+func applySocketConfig(path string, socket SocketConfig) error {
+	if socket.Mode != "" {
+		mode, err := strconv.ParseUint(socket.Mode, 8, 32)
+		if err != nil {
+			return err
+		}
+		if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+			return err
+		}
+	}
+
+	if socket.Owner == "" && socket.Group == "" {
+		return nil
+	}
+
+	uid, gid := -1, -1
+	if socket.Owner != "" {
+		id, err := strconv.Atoi(socket.Owner)
+		if err != nil {
+			u, err := user.Lookup(socket.Owner)
+			if err != nil {
+				return err
+			}
+			id, _ = strconv.Atoi(u.Uid)
+		}
+		uid = id
+	}
+	if socket.Group != "" {
+		id, err := strconv.Atoi(socket.Group)
+		if err != nil {
+			g, err := user.LookupGroup(socket.Group)
+			if err != nil {
+				return err
+			}
+			id, _ = strconv.Atoi(g.Gid)
+		}
+		gid = id
+	}
+	return os.Chown(path, uid, gid)
+}
+
+// MARK: trackedListener
+
+// trackedListener - a listener which is forgotten for the handoff when it is closed
+type trackedListener struct {
+	net.Listener
+	key  string
+	once sync.Once
+}
+
+// Close - close the listener and forget it
+func (l *trackedListener) Close() error {
+	l.once.Do(func() {
+		lock.Lock()
+		if active[l.key] == l.Listener {
+			delete(active, l.key)
+		}
+		lock.Unlock()
+	})
+	return l.Listener.Close()
+}
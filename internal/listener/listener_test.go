@@ -0,0 +1,66 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	cases := []struct {
+		network, address, key string
+	}{
+		{"tcp", "127.0.0.1:8080", "tcp|127.0.0.1:8080"},
+		{"", ":8080", "tcp|:8080"},
+		{"tcp", "unix:///run/app.sock", "unix|/run/app.sock"},
+		{"tcp", "systemd://http", "systemd|http"},
+	}
+
+	for _, item := range cases {
+		if key, _, _ := parseAddress(item.network, item.address); key != item.key {
+			t.Errorf("Parse %v --> Expected: %v, but got %v", item.address, item.key, key)
+		}
+	}
+}
+
+func TestListen_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	l, err := Listen("tcp", "unix://"+path, SocketConfig{Mode: "0600"})
+	if err != nil {
+		t.Fatalf("Listen on unix socket --> Expected: %v, but got %v", nil, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Socket file --> Expected: %v, but got %v", nil, err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Socket mode --> Expected: %v, but got %v", os.FileMode(0600), info.Mode().Perm())
+	}
+
+	if _, err := Listen("tcp", "unix://"+path, SocketConfig{}); err == nil {
+		t.Errorf("Listen on the socket in use --> Expected an error, but got %v", err)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("Dial unix socket --> Expected: %v, but got %v", nil, err)
+	}
+	_ = conn.Close()
+
+	_ = l.Close()
+	if _, ok := active["unix|"+path]; ok {
+		t.Errorf("Closed listener --> Expected to be forgotten for the handoff")
+	}
+}
+
+func TestListen_SystemdNotPassed(t *testing.T) {
+	_, err := Listen("tcp", "systemd://not-passed", SocketConfig{})
+	if listenErr, ok := err.(*ListenErr); !ok {
+		t.Errorf("Listen on not passed socket --> Expected: %T, but got %v", &ListenErr{}, err)
+	} else if _, ok := listenErr.Err.(*SystemdListenerNotFoundErr); !ok {
+		t.Errorf("Listen on not passed socket --> Expected: %T, but got %v", &SystemdListenerNotFoundErr{}, listenErr.Err)
+	}
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+)
+
+// tenantLogger - a logger which sets the tenant of the context on the log objects
+type tenantLogger struct {
+	types.Logger
+	tenant string
+}
+
+// Log - set the tenant on the log object and write it
+func (l *tenantLogger) Log(obj *types.LogObject) {
+	if obj != nil && obj.Tenant == "" {
+		copied := *obj
+		copied.Tenant = l.tenant
+		obj = &copied
+	}
+	l.Logger.Log(obj)
+}
+
+// GetLoggerWithContext - This function returns logger instance which adds the tenant of the context to the log objects
+func (m *manager) GetLoggerWithContext(ctx context.Context) (types.Logger, *Error) {
+	l, err := m.GetLogger()
+	if err != nil {
+		return nil, err
+	}
+	if tenantID, ok := tenancy.FromContext(ctx); ok {
+		return &tenantLogger{Logger: l, tenant: tenantID}, nil
+	}
+	return l, nil
+}
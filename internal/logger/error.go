@@ -12,6 +12,11 @@ func (err *Error) Error() string {
 	return fmt.Sprintf("Logger Error: %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
 // NewError - return a new instance of Error
 func NewError(err error) *Error {
 	return &Error{Err: err}
@@ -20,6 +20,7 @@ type LogObject struct {
 	Time       int64
 	Additional interface{}
 	Message    interface{}
+	Tenant     string // the tenant of the request which is logged, it is set by the logger of a context
 }
 
 // NewLogObject - enhance method to create and return reference of LogObject
@@ -114,4 +115,5 @@ type ZhycanLog struct {
 	Message     string `json:"message"`
 	Additional  string `json:"additional"`
 	LogTime     int64  `json:"logTime"`
+	Tenant      string `gorm:"size:256" json:"tenant"`
 }
@@ -282,6 +282,9 @@ func (l *ZapWrapper) runner() {
 				zap.Any("time", c.Time),
 				zap.Any("additional", c.Additional),
 			}
+			if c.Tenant != "" {
+				f = append(f, zap.String("tenant", c.Tenant))
+			}
 			switch c.Level {
 			case types.DEBUG:
 				l.logger.Debug(fmt.Sprintf("%v", c.Message), f...)
@@ -225,6 +225,7 @@ func (l *LogMeWrapper) runner(output string) {
 							Message:     fmt.Sprintf("%v", c.Message),
 							Additional:  fmt.Sprintf("%v", c.Additional),
 							LogTime:     c.Time,
+							Tenant:      c.Tenant,
 						}
 						l.supportedOutputOption[output].sqlDbInstance.Create(&item)
 					}
@@ -243,7 +244,7 @@ func (l *LogMeWrapper) debug(object *types.LogObject, output string) {
 			object.Time,
 			object.Level.String(),
 			object.LogType,
-			object.Module,
+			moduleOf(object),
 			object.Message,
 			object.Additional,
 		)
@@ -259,7 +260,7 @@ func (l *LogMeWrapper) info(object *types.LogObject, output string) {
 			object.Time,
 			object.Level.String(),
 			object.LogType,
-			object.Module,
+			moduleOf(object),
 			object.Message,
 			object.Additional,
 		)
@@ -275,7 +276,7 @@ func (l *LogMeWrapper) warning(object *types.LogObject, output string) {
 			object.Time,
 			object.Level.String(),
 			object.LogType,
-			object.Module,
+			moduleOf(object),
 			object.Message,
 			object.Additional,
 		)
@@ -291,9 +292,17 @@ func (l *LogMeWrapper) error(object *types.LogObject, output string) {
 			object.Time,
 			object.Level.String(),
 			object.LogType,
-			object.Module,
+			moduleOf(object),
 			object.Message,
 			object.Additional,
 		)
 	}
 }
+
+// moduleOf - return the module of the log object with its tenant, e.g. "http.proxy@acme"
+func moduleOf(object *types.LogObject) string {
+	if object.Tenant == "" {
+		return object.Module
+	}
+	return object.Module + "@" + object.Tenant
+}
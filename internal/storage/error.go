@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// NotFoundErr Error
+type NotFoundErr struct {
+	Key string
+}
+
+// Error method - satisfying error interface
+func (err *NotFoundErr) Error() string {
+	return fmt.Sprintf("The object `%v` is not found", err.Key)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *NotFoundErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewNotFoundErr - return a new instance of NotFoundErr
+func NewNotFoundErr(key string) error {
+	return &NotFoundErr{Key: key}
+}
+
+// InvalidKeyErr Error
+type InvalidKeyErr struct {
+	Key string
+}
+
+// Error method - satisfying error interface
+func (err *InvalidKeyErr) Error() string {
+	return fmt.Sprintf("The object key `%v` is not valid, it must be a relative path without `.` segments", err.Key)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *InvalidKeyErr) ErrorCode() errs.Code {
+	return errs.InvalidArgument
+}
+
+// NewInvalidKeyErr - return a new instance of InvalidKeyErr
+func NewInvalidKeyErr(key string) error {
+	return &InvalidKeyErr{Key: key}
+}
+
+// BackendNotFoundErr Error
+type BackendNotFoundErr struct {
+	Name string
+}
+
+// Error method - satisfying error interface
+func (err *BackendNotFoundErr) Error() string {
+	return fmt.Sprintf("The storage backend `%v` is neither configured nor registered", err.Name)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *BackendNotFoundErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewBackendNotFoundErr - return a new instance of BackendNotFoundErr
+func NewBackendNotFoundErr(name string) error {
+	return &BackendNotFoundErr{Name: name}
+}
+
+// BackendConfigErr Error
+type BackendConfigErr struct {
+	Name   string
+	Reason string
+}
+
+// Error method - satisfying error interface
+func (err *BackendConfigErr) Error() string {
+	return fmt.Sprintf("The config of the storage backend `%v` is not valid: %v", err.Name, err.Reason)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *BackendConfigErr) ErrorCode() errs.Code {
+	return errs.FailedPrecondition
+}
+
+// NewBackendConfigErr - return a new instance of BackendConfigErr
+func NewBackendConfigErr(name string, reason string) error {
+	return &BackendConfigErr{Name: name, Reason: reason}
+}
+
+// S3Err Error - returned when the S3-compatible server answers with an error
+type S3Err struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Error method - satisfying error interface
+func (err *S3Err) Error() string {
+	return fmt.Sprintf("S3 request failed with status %v: %v %v", err.Status, err.Code, err.Message)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *S3Err) ErrorCode() errs.Code {
+	return errs.CodeFromHTTPStatus(err.Status)
+}
+
+// NewS3Err - return a new instance of S3Err
+func NewS3Err(status int, code string, message string) error {
+	return &S3Err{Status: status, Code: code, Message: message}
+}
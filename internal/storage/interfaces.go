@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Backend - a storage of objects by key, e.g. the local filesystem or an S3-compatible bucket
+// The keys are slash separated relative paths, e.g. "avatars/2024/user-1.png"
+type Backend interface {
+	Put(ctx context.Context, key string, r io.Reader, options PutOptions) (ObjectInfo, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// PutOptions - the attributes of a stored object
+type PutOptions struct {
+	ContentType string
+	Metadata    map[string]string
+}
+
+// ObjectInfo - the attributes of a stored object
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	Checksum    string // hex encoded sha256 of the content
+	ModTime     time.Time
+	Metadata    map[string]string
+}
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// metaDir - the directory under the root which keeps the attributes of the objects
+const metaDir = ".meta"
+
+// LocalBackend - store the objects as files under a root directory
+type LocalBackend struct {
+	root string
+}
+
+// localMeta - the attributes of an object kept beside the file
+type localMeta struct {
+	ContentType string            `json:"content_type"`
+	Checksum    string            `json:"checksum"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// NewLocalBackend - create the backend, the root directory is created if it does not exist
+func NewLocalBackend(root string) (*LocalBackend, error) {
+	if root == "" {
+		return nil, NewBackendConfigErr("local", "the root directory is empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalBackend{root: root}, nil
+}
+
+// Put - write the object to a temporary file and move it to its path, so a failed write leaves nothing behind
+func (b *LocalBackend) Put(ctx context.Context, key string, r io.Reader, options PutOptions) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	filePath := b.filePath(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer os.Remove(tempFile.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), contextReader{ctx: ctx, r: r})
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	meta := localMeta{
+		ContentType: options.ContentType,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		Metadata:    options.Metadata,
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = contentTypeByKey(key)
+	}
+	if err := b.writeMeta(key, meta); err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.Rename(tempFile.Name(), filePath); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: meta.ContentType,
+		Checksum:    meta.Checksum,
+		ModTime:     info.ModTime(),
+		Metadata:    meta.Metadata,
+	}, nil
+}
+
+// Get - open the object, the reader is an *os.File, so it can be seeked to serve ranges
+func (b *LocalBackend) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	info, err := b.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	file, err := os.Open(b.filePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ObjectInfo{}, NewNotFoundErr(key)
+		}
+		return nil, ObjectInfo{}, err
+	}
+	return file, info, nil
+}
+
+// Stat - return the attributes of the object
+func (b *LocalBackend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := os.Stat(b.filePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ObjectInfo{}, NewNotFoundErr(key)
+		}
+		return ObjectInfo{}, err
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, NewNotFoundErr(key)
+	}
+
+	result := ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime(), ContentType: contentTypeByKey(key)}
+	if data, err := os.ReadFile(b.metaPath(key)); err == nil {
+		var meta localMeta
+		if json.Unmarshal(data, &meta) == nil {
+			if meta.ContentType != "" {
+				result.ContentType = meta.ContentType
+			}
+			result.Checksum = meta.Checksum
+			result.Metadata = meta.Metadata
+		}
+	}
+	return result, nil
+}
+
+// Delete - remove the object, removing an object which does not exist is not an error
+func (b *LocalBackend) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(b.filePath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(b.metaPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// filePath - return the path of the object file
+func (b *LocalBackend) filePath(key string) string {
+	return filepath.Join(b.root, filepath.FromSlash(key))
+}
+
+// metaPath - return the path of the attributes of the object
+func (b *LocalBackend) metaPath(key string) string {
+	return filepath.Join(b.root, metaDir, filepath.FromSlash(key)+".json")
+}
+
+// writeMeta - write the attributes of the object
+func (b *LocalBackend) writeMeta(key string, meta localMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	metaPath := b.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, data, 0o644)
+}
+
+// MARK: Private functions
+
+// validateKey - reject the keys which may point outside of the storage
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return NewInvalidKeyErr(key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		// the hidden segments are reserved, e.g. for the attributes of the local backend
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return NewInvalidKeyErr(key)
+		}
+	}
+	return nil
+}
+
+// contentTypeByKey - return the content type by the extension of the key
+func contentTypeByKey(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// contextReader - stop reading when the context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read - read from the reader if the context is not done
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
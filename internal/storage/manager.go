@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
+)
+
+// MARK: Variables
+
+var (
+	StorageMaintenanceType = types.NewLogType("STORAGE_MAINTENANCE")
+)
+
+// backendConfig - defines a backend in the `backends` of the storage config
+type backendConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // "local" or "s3"
+	Root string `json:"root"` // the root directory of the local backend
+	S3Config
+}
+
+// Mark: manager
+
+// manager object
+type manager struct {
+	name           string
+	lock           sync.RWMutex
+	backends       map[string]Backend // the backends of the config
+	registered     map[string]Backend // the backends registered by the application, they are kept on reload
+	defaultBackend string
+}
+
+// MARK: Module variables
+var managerInstance *manager = nil
+var once sync.Once
+
+// Module init function
+func init() {
+	log.Println("Storage Manager Package Initialized...")
+}
+
+// init - Manager Constructor - It initializes the backends of the config
+func (m *manager) init() {
+	m.name = "storage"
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.backends = make(map[string]Backend)
+	if m.registered == nil {
+		m.registered = make(map[string]Backend)
+	}
+
+	backendsCfg, err := config.GetManager().Get(m.name, "backends")
+	if err != nil {
+		return
+	}
+	items, _ := backendsCfg.([]interface{})
+
+	for _, item := range items {
+		jsonBody, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+
+		var obj backendConfig
+		if err := json.Unmarshal(jsonBody, &obj); err != nil {
+			continue
+		}
+
+		backend, err := newBackend(obj)
+		if err != nil {
+			l, _ := logger.GetManager().GetLogger()
+			if l != nil {
+				l.Log(types.NewLogObject(types.ERROR, "storage.manager.init", StorageMaintenanceType, time.Now(), "Creating the storage backend failed ...", map[string]interface{}{"backend": obj.Name, "error": err.Error()}))
+			}
+			continue
+		}
+		m.backends[obj.Name] = backend
+	}
+
+	m.defaultBackend = ""
+	if defaultB, err := config.GetManager().Get(m.name, "default"); err == nil {
+		m.defaultBackend, _ = defaultB.(string)
+	}
+}
+
+// restartOnChangeConfig - subscribe a function for when the config is changed
+func (m *manager) restartOnChangeConfig() {
+	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	if err == nil {
+		wrapper.RegisterChangeCallback(func() interface{} {
+			m.init()
+			return nil
+		})
+	}
+}
+
+// MARK: Public functions
+
+// GetManager - This function returns singleton instance of Storage Manager
+func GetManager() *manager {
+	// once used for prevent race condition and manage critical section.
+	once.Do(func() {
+		managerInstance = &manager{}
+		managerInstance.init()
+		managerInstance.restartOnChangeConfig()
+	})
+	return managerInstance
+}
+
+// GetBackend - return the backend by name, or the default backend if no name is given
+// The backends registered by the application take precedence over the ones of the config
+func (m *manager) GetBackend(name ...string) (Backend, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	backendName := m.defaultBackend
+	if len(name) > 0 && name[0] != "" {
+		backendName = name[0]
+	}
+
+	if backend, ok := m.registered[backendName]; ok {
+		return backend, nil
+	}
+	if backend, ok := m.backends[backendName]; ok {
+		return backend, nil
+	}
+	return nil, NewBackendNotFoundErr(backendName)
+}
+
+// RegisterBackend - register a backend to be referenced by name, e.g. a custom implementation or a configured client
+func (m *manager) RegisterBackend(name string, backend Backend) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.registered[name] = backend
+}
+
+// MARK: Private functions
+
+// newBackend - create the backend of the config
+func newBackend(config backendConfig) (Backend, error) {
+	switch config.Type {
+	case "local":
+		return NewLocalBackend(config.Root)
+	case "s3":
+		return NewS3Backend(config.S3Config)
+	}
+	return nil, NewBackendConfigErr(config.Name, fmt.Sprintf("the type `%v` is not supported", config.Type))
+}
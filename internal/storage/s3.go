@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MARK: Variables
+
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3EmptyBodyHash  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3MetaPrefix     = "X-Amz-Meta-"
+	s3ChecksumHeader = "X-Amz-Meta-Sha256"
+)
+
+// S3Config - defines the connection of an S3-compatible bucket, e.g. AWS S3 or MinIO
+type S3Config struct {
+	Endpoint  string `json:"endpoint"` // e.g. "https://s3.eu-central-1.amazonaws.com" or "http://127.0.0.1:9000"
+	Region    string `json:"region"`   // default: us-east-1
+	Bucket    string `json:"bucket"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+
+	// PathStyle addresses the bucket as "<endpoint>/<bucket>" instead of "<bucket>.<endpoint>", MinIO needs it
+	PathStyle bool `json:"path_style"`
+
+	// Timeout is the duration in milliseconds of each request, zero means no timeout
+	Timeout int `json:"timeout"`
+}
+
+// S3Backend - store the objects in an S3-compatible bucket, the requests are signed by AWS signature version 4
+type S3Backend struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// s3ErrorResponse - the error body of S3
+type s3ErrorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// MARK: Public functions
+
+// NewS3Backend - create the backend of the bucket
+func NewS3Backend(config S3Config) (*S3Backend, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, NewBackendConfigErr("s3", fmt.Sprintf("the endpoint `%v` is not an absolute url", config.Endpoint))
+	}
+	if config.Bucket == "" {
+		return nil, NewBackendConfigErr("s3", "the bucket is empty")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return &S3Backend{
+		config:   config,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: time.Duration(config.Timeout) * time.Millisecond},
+	}, nil
+}
+
+// Put - upload the object, the content is written to a temporary file first to know its size and checksum
+func (b *S3Backend) Put(ctx context.Context, key string, r io.Reader, options PutOptions) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	tempFile, err := os.CreateTemp("", "gonyx-s3-*")
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info := ObjectInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: options.ContentType,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		ModTime:     time.Now(),
+		Metadata:    options.Metadata,
+	}
+	if info.ContentType == "" {
+		info.ContentType = contentTypeByKey(key)
+	}
+
+	var body io.Reader = http.NoBody
+	if size > 0 {
+		body = io.NopCloser(tempFile)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.objectURL(key), body)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", info.ContentType)
+	req.Header.Set(s3ChecksumHeader, info.Checksum)
+	for name, value := range options.Metadata {
+		req.Header.Set(s3MetaPrefix+name, value)
+	}
+
+	resp, err := b.do(req, s3UnsignedBody)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return info, nil
+}
+
+// Get - download the object
+func (b *S3Backend) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.objectURL(key), nil)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	resp, err := b.do(req, s3EmptyBodyHash)
+	if err != nil {
+		return nil, ObjectInfo{}, b.notFound(key, err)
+	}
+	return resp.Body, objectInfoFromHeader(key, resp), nil
+}
+
+// Stat - return the attributes of the object
+func (b *S3Backend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, b.objectURL(key), nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	resp, err := b.do(req, s3EmptyBodyHash)
+	if err != nil {
+		return ObjectInfo{}, b.notFound(key, err)
+	}
+	_ = resp.Body.Close()
+	return objectInfoFromHeader(key, resp), nil
+}
+
+// Delete - remove the object, S3 does not report the objects which do not exist
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, b.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req, s3EmptyBodyHash)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// MARK: Private functions
+
+// objectURL - return the url of the object by the addressing style of the config
+func (b *S3Backend) objectURL(key string) string {
+	u := *b.endpoint
+	escapedKey := uriEncode(key, false)
+	if b.config.PathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + b.config.Bucket + "/" + key
+		u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + uriEncode(b.config.Bucket, true) + "/" + escapedKey
+	} else {
+		u.Host = b.config.Bucket + "." + u.Host
+		u.Path = "/" + key
+		u.RawPath = "/" + escapedKey
+	}
+	return u.String()
+}
+
+// do - sign and send the request, the responses with an error status are returned as S3Err
+func (b *S3Backend) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	b.sign(req, payloadHash, time.Now().UTC())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var body s3ErrorResponse
+		_ = xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body)
+		return nil, NewS3Err(resp.StatusCode, body.Code, body.Message)
+	}
+	return resp, nil
+}
+
+// notFound - convert the 404 of S3 to NotFoundErr
+func (b *S3Backend) notFound(key string, err error) error {
+	if s3Err, ok := err.(*S3Err); ok && s3Err.Status == http.StatusNotFound {
+		return NewNotFoundErr(key)
+	}
+	return err
+}
+
+// sign - add the AWS signature version 4 headers to the request
+func (b *S3Backend) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// host, content type and all x-amz-* headers are signed
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lowerName := strings.ToLower(name)
+		if strings.HasPrefix(lowerName, "x-amz-") || lowerName == "content-type" {
+			headers[lowerName] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + b.config.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+b.config.SecretKey), date)
+	key = hmacSHA256(key, b.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		s3Algorithm, b.config.AccessKey, scope, signedHeaders, signature))
+}
+
+// objectInfoFromHeader - read the attributes of the object from the response headers
+func objectInfoFromHeader(key string, resp *http.Response) ObjectInfo {
+	info := ObjectInfo{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		Checksum:    resp.Header.Get(s3ChecksumHeader),
+	}
+	if size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modTime
+	}
+	for name, values := range resp.Header {
+		if strings.HasPrefix(name, s3MetaPrefix) && name != s3ChecksumHeader && len(values) > 0 {
+			if info.Metadata == nil {
+				info.Metadata = make(map[string]string)
+			}
+			info.Metadata[strings.ToLower(strings.TrimPrefix(name, s3MetaPrefix))] = values[0]
+		}
+	}
+	return info
+}
+
+// canonicalQuery - encode the query sorted by the names as signature version 4 expects
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		values := append([]string{}, query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode - percent encode everything except the unreserved characters of RFC 3986, and the slash if it is kept
+func uriEncode(value string, encodeSlash bool) string {
+	var result strings.Builder
+	for _, c := range []byte(value) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			result.WriteByte(c)
+		} else {
+			result.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return result.String()
+}
+
+// hmacSHA256 - return the HMAC-SHA256 of the data
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package tenancy
+
+import "context"
+
+// MARK: Variables
+
+// TenantKey - the key of the tenant id in the keys of a gin context
+const TenantKey = "gonyx.tenant"
+
+// tenantContextKey - the key of the tenant id in a context
+type tenantContextKey struct{}
+
+// MARK: Public functions
+
+// WithTenant - return a copy of the context which carries the tenant id
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// FromContext - return the tenant id of the context
+// A gin context carries the tenant id in its keys, so it is accepted as well
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	if tenantID, ok := ctx.Value(tenantContextKey{}).(string); ok && tenantID != "" {
+		return tenantID, true
+	}
+	if tenantID, ok := ctx.Value(TenantKey).(string); ok && tenantID != "" {
+		return tenantID, true
+	}
+	return "", false
+}
+
+// KeyPrefix - return the key prefix of the tenant of the context, or an empty string if it has no tenant
+func KeyPrefix(ctx context.Context) string {
+	tenantID, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tenant, err := GetManager().GetTenant(tenantID); err == nil && tenant.KeyPrefix != "" {
+		return tenant.KeyPrefix
+	}
+	return tenantID
+}
@@ -0,0 +1,27 @@
+package tenancy
+
+import (
+	"fmt"
+
+	"github.com/Blocktunium/gonyx/internal/errs"
+)
+
+// TenantNotFoundErr Error
+type TenantNotFoundErr struct {
+	ID string
+}
+
+// Error method - satisfying error interface
+func (err *TenantNotFoundErr) Error() string {
+	return fmt.Sprintf("The tenant `%v` is not defined", err.ID)
+}
+
+// ErrorCode method - satisfying errs.Coder interface
+func (err *TenantNotFoundErr) ErrorCode() errs.Code {
+	return errs.NotFound
+}
+
+// NewTenantNotFoundErr - return a new instance of TenantNotFoundErr
+func NewTenantNotFoundErr(id string) error {
+	return &TenantNotFoundErr{ID: id}
+}
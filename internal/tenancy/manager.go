@@ -0,0 +1,140 @@
+package tenancy
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+)
+
+// Mark: manager
+
+// manager object
+type manager struct {
+	name       string
+	lock       sync.RWMutex
+	tenants    map[string]Tenant // the tenants of the config
+	registered map[string]Tenant // the tenants registered by the application, they are kept on reload
+}
+
+// MARK: Module variables
+var managerInstance *manager = nil
+var once sync.Once
+
+// Module init function
+func init() {
+	log.Println("Tenancy Manager Package Initialized...")
+}
+
+// init - Manager Constructor - It reads the tenants of the config
+func (m *manager) init() {
+	m.name = "tenancy"
+
+	tenants := make(map[string]Tenant)
+	if tenantsCfg, err := config.GetManager().Get(m.name, "tenants"); err == nil {
+		items, _ := tenantsCfg.([]interface{})
+		for _, item := range items {
+			jsonBody, err := json.Marshal(item)
+			if err != nil {
+				continue
+			}
+
+			var obj Tenant
+			if err := json.Unmarshal(jsonBody, &obj); err != nil || obj.ID == "" {
+				continue
+			}
+			tenants[obj.ID] = obj
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.tenants = tenants
+	if m.registered == nil {
+		m.registered = make(map[string]Tenant)
+	}
+}
+
+// restartOnChangeConfig - subscribe a function for when the config is changed
+func (m *manager) restartOnChangeConfig() {
+	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	if err == nil {
+		wrapper.RegisterChangeCallback(func() interface{} {
+			m.init()
+			return nil
+		})
+	}
+}
+
+// MARK: Public functions
+
+// GetManager - This function returns singleton instance of Tenancy Manager
+func GetManager() *manager {
+	// once used for prevent race condition and manage critical section.
+	once.Do(func() {
+		managerInstance = &manager{}
+		managerInstance.init()
+		managerInstance.restartOnChangeConfig()
+	})
+	return managerInstance
+}
+
+// GetTenant - return the tenant by id
+// The tenants registered by the application take precedence over the ones of the config
+func (m *manager) GetTenant(id string) (Tenant, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if tenant, ok := m.registered[id]; ok {
+		return tenant, nil
+	}
+	if tenant, ok := m.tenants[id]; ok {
+		return tenant, nil
+	}
+	return Tenant{}, NewTenantNotFoundErr(id)
+}
+
+// GetTenantByHost - return the tenant which has the host, the port of the host is ignored
+func (m *manager) GetTenantByHost(host string) (Tenant, bool) {
+	host = strings.ToLower(stripPort(host))
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, tenants := range []map[string]Tenant{m.registered, m.tenants} {
+		for _, tenant := range tenants {
+			for _, item := range tenant.Hosts {
+				if strings.ToLower(item) == host {
+					return tenant, true
+				}
+			}
+		}
+	}
+	return Tenant{}, false
+}
+
+// RegisterTenant - register a tenant, e.g. a tenant which is loaded from a database
+func (m *manager) RegisterTenant(tenant Tenant) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.registered[tenant.ID] = tenant
+}
+
+// MARK: Private functions
+
+// stripPort - remove the port of the host, e.g. "acme.example.com:8080" -> "acme.example.com"
+func stripPort(host string) string {
+	if strings.HasPrefix(host, "[") {
+		if i := strings.Index(host, "]"); i > 0 {
+			return host[1:i]
+		}
+	}
+	if i := strings.LastIndex(host, ":"); i >= 0 && strings.Count(host, ":") == 1 {
+		return host[:i]
+	}
+	return host
+}
@@ -0,0 +1,59 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Blocktunium/gonyx/internal/config"
+)
+
+func makeReadyConfigManager() {
+	_ = config.CreateManager("../..", "test", "Gonyx")
+}
+
+func TestContext(t *testing.T) {
+	if id, ok := FromContext(context.Background()); ok || id != "" {
+		t.Errorf("Tenant of empty context --> Expected: %v, but got %v", "", id)
+	}
+
+	ctx := WithTenant(context.Background(), "acme")
+	if id, ok := FromContext(ctx); !ok || id != "acme" {
+		t.Errorf("Tenant of context --> Expected: %v, but got %v", "acme", id)
+	}
+	if id, ok := FromContext(context.WithValue(context.Background(), TenantKey, "globex")); !ok || id != "globex" {
+		t.Errorf("Tenant of string key --> Expected: %v, but got %v", "globex", id)
+	}
+}
+
+func TestManager(t *testing.T) {
+	makeReadyConfigManager()
+	GetManager().RegisterTenant(Tenant{ID: "initech", Hosts: []string{"Initech.io"}, KeyPrefix: "ini"})
+	GetManager().RegisterTenant(Tenant{ID: "hooli"})
+
+	if _, err := GetManager().GetTenant("unknown"); err == nil {
+		t.Errorf("Unknown tenant --> Expected an error, but got %v", err)
+	}
+	if tenant, ok := GetManager().GetTenantByHost("initech.io:8080"); !ok || tenant.ID != "initech" {
+		t.Errorf("Tenant of host --> Expected: %v, but got %v", "initech", tenant.ID)
+	}
+	if prefix := KeyPrefix(WithTenant(context.Background(), "initech")); prefix != "ini" {
+		t.Errorf("Key prefix --> Expected: %v, but got %v", "ini", prefix)
+	}
+	if prefix := KeyPrefix(WithTenant(context.Background(), "hooli")); prefix != "hooli" {
+		t.Errorf("Key prefix of tenant id --> Expected: %v, but got %v", "hooli", prefix)
+	}
+}
+
+func TestStripPort(t *testing.T) {
+	tests := map[string]string{
+		"acme.io":      "acme.io",
+		"acme.io:8080": "acme.io",
+		"[::1]:8080":   "::1",
+		"::1":          "::1",
+	}
+	for host, expected := range tests {
+		if result := stripPort(host); result != expected {
+			t.Errorf("Strip port of %v --> Expected: %v, but got %v", host, expected, result)
+		}
+	}
+}
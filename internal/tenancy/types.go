@@ -0,0 +1,26 @@
+package tenancy
+
+// Tenant - defines a tenant in the `tenants` of the tenancy config.
+type Tenant struct {
+	ID string `json:"id"`
+
+	// Hosts are the hosts of the tenant which are resolved by the host strategy, e.g. "acme.example.com"
+	Hosts []string `json:"hosts"`
+
+	// KeyPrefix is the prefix of the cache keys of the tenant (default: the id)
+	KeyPrefix string `json:"key_prefix"`
+
+	DB DBConfig `json:"db"`
+}
+
+// DBConfig - defines how the database of a tenant is isolated, the options can be combined.
+type DBConfig struct {
+	// Connection is the name of the database connection of the tenant, empty means the requested connection is used
+	Connection string `json:"connection"`
+
+	// Schema is the schema of the tables of the tenant, e.g. a postgresql schema
+	Schema string `json:"schema"`
+
+	// Column is the column of the tenant id, the queries are filtered by it and it is set on create
+	Column string `json:"column"`
+}
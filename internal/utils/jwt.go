@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash"
+	"strings"
+	"time"
+)
+
+// VerifyJWT - verify the HMAC signature (HS256, HS384 or HS512), the expiry and the not-before of the token and return its claims
+// The numbers of the claims are kept as json.Number
+func VerifyJWT(token string, secret []byte) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token is malformed")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if !decodeJWTPart(parts[0], &header) {
+		return nil, errors.New("the header of the token is malformed")
+	}
+	var newHash func() hash.Hash
+	switch header.Alg {
+	case "HS256":
+		newHash = sha256.New
+	case "HS384":
+		newHash = sha512.New384
+	case "HS512":
+		newHash = sha512.New
+	default:
+		return nil, errors.New("the algorithm of the token is not supported")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("the signature of the token is malformed")
+	}
+	mac := hmac.New(newHash, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("the signature of the token is not valid")
+	}
+
+	claims := make(map[string]any)
+	if !decodeJWTPart(parts[1], &claims) {
+		return nil, errors.New("the claims of the token are malformed")
+	}
+	now := time.Now().Unix()
+	if exp, ok := claims["exp"].(json.Number); ok {
+		if value, err := exp.Int64(); err != nil || now >= value {
+			return nil, errors.New("the token is expired")
+		}
+	}
+	if nbf, ok := claims["nbf"].(json.Number); ok {
+		if value, err := nbf.Int64(); err != nil || now < value {
+			return nil, errors.New("the token is not valid yet")
+		}
+	}
+	return claims, nil
+}
+
+// decodeJWTPart - decode the base64url json of a part of the token, the numbers are kept as json.Number
+func decodeJWTPart(part string, v any) bool {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v) == nil
+}
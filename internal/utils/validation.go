@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+)
+
+// FieldViolation - a failed rule of a field of a message, e.g. of the rules in the annotations of a protobuf file
+type FieldViolation struct {
+	Field  string // the path of the field, e.g. "Items[0].Name", it is empty if the rule is of the message
+	Reason string
+}
+
+// MessageValidator - validate a message by the rules which are not generated into its methods, e.g. by a protovalidate runtime
+// It returns no error if the message is valid or it is not known by the validator
+type MessageValidator func(msg any) ([]FieldViolation, error)
+
+// validatorAll - the messages generated with all their violations, e.g. by protoc-gen-validate
+type validatorAll interface {
+	ValidateAll() error
+}
+
+// validator - the messages which validate themselves
+type validator interface {
+	Validate() error
+}
+
+// fieldError - the error of a field which is generated by protoc-gen-validate, Cause is the error of an embedded message
+type fieldError interface {
+	Field() string
+	Reason() string
+}
+
+// multiError - the errors of all the violations of a message which is generated by protoc-gen-validate
+type multiError interface {
+	AllErrors() []error
+}
+
+var (
+	messageValidatorsLock sync.RWMutex
+	messageValidators     []MessageValidator
+)
+
+// MARK: Public functions
+
+// RegisterMessageValidator - add a validator which runs after the generated validation methods of the messages
+func RegisterMessageValidator(v MessageValidator) {
+	messageValidatorsLock.Lock()
+	defer messageValidatorsLock.Unlock()
+	messageValidators = append(messageValidators, v)
+}
+
+// ValidateMessage - validate the message by its `ValidateAll` or `Validate` method and the registered validators
+// The violations are collected from the errors of the fields, the nested fields are joined by "."
+func ValidateMessage(msg any) ([]FieldViolation, error) {
+	var generated error
+	switch v := msg.(type) {
+	case validatorAll:
+		generated = v.ValidateAll()
+	case validator:
+		generated = v.Validate()
+	}
+
+	var violations []FieldViolation
+	var errs []error
+	if generated != nil {
+		collectViolations("", generated, &violations)
+		errs = append(errs, generated)
+	}
+
+	messageValidatorsLock.RLock()
+	validators := messageValidators
+	messageValidatorsLock.RUnlock()
+	for _, v := range validators {
+		items, err := v(msg)
+		if err != nil {
+			violations = append(violations, items...)
+			errs = append(errs, err)
+		}
+	}
+	return violations, errors.Join(errs...)
+}
+
+// MARK: Private functions
+
+// collectViolations - append the violations of the error, an error which is not of a field is a violation of the message
+func collectViolations(prefix string, err error, violations *[]FieldViolation) {
+	if multi, ok := err.(multiError); ok {
+		for _, item := range multi.AllErrors() {
+			collectViolations(prefix, item, violations)
+		}
+		return
+	}
+
+	item, ok := err.(fieldError)
+	if !ok {
+		*violations = append(*violations, FieldViolation{Field: prefix, Reason: err.Error()})
+		return
+	}
+
+	field := item.Field()
+	if prefix != "" {
+		field = prefix + "." + field
+	}
+	// the violations of an embedded message are reported by their own fields
+	if withCause, ok := err.(interface{ Cause() error }); ok && withCause.Cause() != nil {
+		cause := withCause.Cause()
+		_, isMulti := cause.(multiError)
+		_, isField := cause.(fieldError)
+		if isMulti || isField {
+			collectViolations(field, cause, violations)
+			return
+		}
+	}
+	*violations = append(*violations, FieldViolation{Field: field, Reason: item.Reason()})
+}
@@ -12,7 +12,33 @@ func (err *StartWatcherErr) Error() string {
 	return fmt.Sprintf("Cannot start watcher instance | %v", err.Err)
 }
 
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *StartWatcherErr) Unwrap() error {
+	return err.Err
+}
+
 // NewStartWatcherErr - return a new instance of StartWatcherErr
 func NewStartWatcherErr(err error) error {
 	return &StartWatcherErr{Err: err}
 }
+
+// WatchPathErr Error
+type WatchPathErr struct {
+	Path string
+	Err  error
+}
+
+// Error method - satisfying error interface
+func (err *WatchPathErr) Error() string {
+	return fmt.Sprintf("Cannot watch the path `%v` | %v", err.Path, err.Err)
+}
+
+// Unwrap - return the wrapped error, so it is matched by errors.Is and errors.As
+func (err *WatchPathErr) Unwrap() error {
+	return err.Err
+}
+
+// NewWatchPathErr - return a new instance of WatchPathErr
+func NewWatchPathErr(path string, err error) error {
+	return &WatchPathErr{Path: path, Err: err}
+}
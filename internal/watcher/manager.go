@@ -208,3 +208,43 @@ func (m *manager) Stop() {
 	m.watcher.Close()
 	<-m.watcher.Closed
 }
+
+// Watch - watch the paths recursively by a dedicated watcher and call f with the changed path until stop is called
+// It is used by the modules which reload their files, e.g. the html templates in the dev env
+func (m *manager) Watch(paths []string, f func(path string)) (stop func(), err error) {
+	m.lock.Lock()
+	interval := time.Millisecond * time.Duration(m.watchInterval)
+	m.lock.Unlock()
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+
+	w := watcher.New()
+	w.FilterOps(watcher.Create, watcher.Move, watcher.Remove, watcher.Rename, watcher.Write)
+	// a change is enough to reload, the rest of the cycle is not needed
+	w.SetMaxEvents(1)
+
+	for _, item := range paths {
+		if err := w.AddRecursive(item); err != nil {
+			return nil, NewWatchPathErr(item, err)
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event := <-w.Event:
+				f(event.Path)
+			case <-w.Error:
+			case <-w.Closed:
+				return
+			}
+		}
+	}()
+	go func() {
+		_ = w.Start(interval)
+	}()
+	w.Wait()
+
+	return w.Close, nil
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"github.com/Blocktunium/gonyx/internal/db"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,6 +13,11 @@ func GetDb(instanceName string) (*gorm.DB, error) {
 	return db.GetManager().GetDb(instanceName)
 }
 
+// GetDbWithContext - Get *gorm.DB instance which is scoped to the tenant of the context, e.g. the context of a request
+func GetDbWithContext(ctx context.Context, instanceName string) (*gorm.DB, error) {
+	return db.GetManager().GetDbWithContext(ctx, instanceName)
+}
+
 // Migrate - migrate models on specific database
 func Migrate(instanceName string, models ...interface{}) error {
 	return db.GetManager().Migrate(instanceName, models...)
@@ -1,11 +1,24 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"log"
+	gohttp "net/http"
+
 	"github.com/Blocktunium/gonyx/internal/engine"
 	interalgRPC "github.com/Blocktunium/gonyx/internal/grpc"
+	"github.com/Blocktunium/gonyx/internal/grpc/interceptors"
+	"github.com/Blocktunium/gonyx/internal/logger"
+	"github.com/Blocktunium/gonyx/internal/logger/types"
 	"github.com/Blocktunium/gonyx/pkg/http"
 	"google.golang.org/grpc"
 	"strings"
+	"time"
+)
+
+var (
+	engineMaintenanceType = types.NewLogType("ENGINE_MAINTENANCE")
 )
 
 // RegisterRestfulController - register the restful controller to the engine
@@ -36,6 +49,7 @@ func (c Controller) GetName() string {
 	return c.name
 }
 
+// RegisterGrpcController - register the grpc controller on its servers, the auth requirements of a GrpcAuthApp are declared too
 func RegisterGrpcController(app engine.GrpcApp, f func(server *grpc.Server)) {
 	controllerName := app.GetName()
 	serverNames := app.GetServerNames()
@@ -48,13 +62,83 @@ func RegisterGrpcController(app engine.GrpcApp, f func(server *grpc.Server)) {
 		return
 	}
 
+	if authApp, ok := app.(engine.GrpcAuthApp); ok {
+		for method, requirement := range authApp.AuthRequirements() {
+			interceptors.SetAuthRequirement(method, requirement)
+		}
+	}
+
 	for _, item := range serverNames {
 		srv, err := interalgRPC.GetManager().GetServerByName(item)
 		if err != nil {
-			// TODO: log the error
+			logControllerError("Cannot find the server of the controller ...", controllerName, item, err)
+			continue
+		}
+
+		// the controller is registered again when the server is rebuilt on config change
+		registered := srv.GetGrpcServer().GetServiceInfo()
+		if err := srv.Register(f); err != nil {
+			logControllerError("Cannot register the controller ...", controllerName, item, err)
 			continue
 		}
 
-		f(srv.GetGrpcServer())
+		if web := srv.WebConfig(); web.Enabled {
+			for service := range srv.GetGrpcServer().GetServiceInfo() {
+				if _, ok := registered[service]; !ok {
+					if err := mountGrpcWebService(srv, web, service); err != nil {
+						logControllerError("Cannot mount the gRPC-Web service of the controller ...", controllerName, item, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// logControllerError - log the error of registering the grpc controller on the server
+func logControllerError(message string, controllerName string, serverName string, err error) {
+	l, _ := logger.GetManager().GetLogger()
+	if l != nil {
+		l.Log(types.NewLogObject(types.ERROR, "engine.RegisterGrpcController", engineMaintenanceType, time.Now(), message, map[string]interface{}{"controller": controllerName, "server": serverName, "error": err.Error()}))
+	} else {
+		log.Printf("%v `%v` on `%v`: %v\n", message, controllerName, serverName, err)
+	}
+}
+
+// mountGrpcWebService - serve the service to the gRPC-Web and Connect clients on the http servers of the web config
+func mountGrpcWebService(srv *interalgRPC.ServerWrapper, web interalgRPC.WebConfig, service string) error {
+	handler, err := srv.WebHandler(web.Prefix)
+	if err != nil {
+		return err
+	}
+	return http.MountHandler(strings.TrimRight(web.Prefix, "/")+"/"+service, handler, web.HttpServers...)
+}
+
+// RegisterGrpcGatewayController - mount the gateway of the grpc controller on the http servers under the prefix
+// The gateway calls the first server of the controller in-process, so the calls pass through the interceptors of both servers
+// It is refused if the server requires the client certificates and has no auth interceptor
+// e.g. f can be `func(ctx, conn) { mux := runtime.NewServeMux(); return mux, pb.RegisterGreeterHandler(ctx, mux, conn) }`
+func RegisterGrpcGatewayController(app engine.GrpcApp, prefix string, f func(ctx context.Context, conn *grpc.ClientConn) (gohttp.Handler, error), httpServerNames ...string) error {
+	serverNames := app.GetServerNames()
+	if len(serverNames) <= 0 {
+		return errors.New("the grpc controller has no server")
+	}
+
+	srv, err := interalgRPC.GetManager().GetServerByName(serverNames[0])
+	if err != nil {
+		return err
+	}
+	// the gateway calls are made without a client certificate, so the mTLS of the server must not be their only authentication
+	if err := srv.CheckLocalExposure(); err != nil {
+		return err
+	}
+	conn, err := srv.LocalConn()
+	if err != nil {
+		return err
+	}
+
+	handler, err := f(context.Background(), conn)
+	if err != nil {
+		return err
 	}
+	return http.MountHandler(prefix, handler, httpServerNames...)
 }
@@ -0,0 +1,67 @@
+package errs
+
+import (
+	"github.com/Blocktunium/gonyx/internal/errs"
+	"google.golang.org/grpc/status"
+)
+
+// Error types
+type (
+	Code             = errs.Code
+	Error            = errs.Error
+	Coder            = errs.Coder
+	Violator         = errs.Violator
+	Problem          = errs.Problem
+	ProblemViolation = errs.ProblemViolation
+)
+
+// Codes - the canonical codes of the errors, the values are the same as the ones of the gRPC codes
+const (
+	OK                 = errs.OK
+	Canceled           = errs.Canceled
+	Unknown            = errs.Unknown
+	InvalidArgument    = errs.InvalidArgument
+	DeadlineExceeded   = errs.DeadlineExceeded
+	NotFound           = errs.NotFound
+	AlreadyExists      = errs.AlreadyExists
+	PermissionDenied   = errs.PermissionDenied
+	ResourceExhausted  = errs.ResourceExhausted
+	FailedPrecondition = errs.FailedPrecondition
+	Aborted            = errs.Aborted
+	OutOfRange         = errs.OutOfRange
+	Unimplemented      = errs.Unimplemented
+	Internal           = errs.Internal
+	Unavailable        = errs.Unavailable
+	DataLoss           = errs.DataLoss
+	Unauthenticated    = errs.Unauthenticated
+)
+
+// New - return a new error with the code and the message which is sent to the client
+func New(code Code, message string) *Error {
+	return errs.New(code, message)
+}
+
+// Wrap - return a new error with the code which wraps err, err is matched by errors.Is and errors.As but it is not sent to the client
+func Wrap(code Code, err error, message string) *Error {
+	return errs.Wrap(code, err, message)
+}
+
+// CodeOf - return the code of the error, e.g. `NotFound` for `db.SelectQueryErr` of a missing record
+func CodeOf(err error) Code {
+	return errs.CodeOf(err)
+}
+
+// HasCode - report whether the code of err is the code
+func HasCode(err error, code Code) bool {
+	return errs.HasCode(err, code)
+}
+
+// ToStatus - return the gRPC status of the error with its details, the grpc servers convert the errors of the handlers by it
+func ToStatus(err error) *status.Status {
+	return errs.ToStatus(err)
+}
+
+// NewProblem - return the problem+json details of the error, instance is the path of the request
+func NewProblem(err error, instance string) *Problem {
+	return errs.NewProblem(err, instance)
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	internalGrpc "github.com/Blocktunium/gonyx/internal/grpc"
+	"github.com/Blocktunium/gonyx/internal/grpc/interceptors"
+	"github.com/Blocktunium/gonyx/internal/utils"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// InterceptorFactory - create the interceptors from their raw config in `interceptors.<name>` of the server in protobuf config
+type InterceptorFactory = internalGrpc.InterceptorFactory
+
+// Principal - the authenticated caller of the call
+type Principal = interceptors.Principal
+
+// AuthRequirement - the authentication which a method needs, it is returned by `AuthRequirements` of a grpc controller
+type AuthRequirement = interceptors.AuthRequirement
+
+// RegisterInterceptor - register an interceptor to be referenced by name and ordered in `interceptors.order` of protobuf config
+// The factory receives `interceptors.<name>` (nil if absent), it must be called before the servers are created
+func RegisterInterceptor(name string, factory func(rawConfig map[string]any) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error)) error {
+	return internalGrpc.RegisterInterceptor(name, factory)
+}
+
+// RequestIDFromContext - return the request id of the call which is set by the `requestid` interceptor
+func RequestIDFromContext(ctx context.Context) string {
+	return interceptors.RequestIDFromContext(ctx)
+}
+
+// PrincipalFromContext - return the principal of the call which is authenticated by the `auth` interceptor
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	return interceptors.PrincipalFromContext(ctx)
+}
+
+// SetAuthRequirement - declare the requirement of a full method, e.g. "/pkg.Service/Method", or of a service by "/pkg.Service/*"
+func SetAuthRequirement(method string, requirement AuthRequirement) {
+	interceptors.SetAuthRequirement(method, requirement)
+}
+
+// ClientConfig - defines an outbound connection like the `clients` of protobuf config
+type ClientConfig = internalGrpc.ClientConfig
+
+// GetClientConn - return the shared connection of the client by its name in `clients` of protobuf config
+func GetClientConn(name string) (*grpc.ClientConn, error) {
+	return internalGrpc.GetManager().GetClientConn(name)
+}
+
+// AddClient - add a client to be used by GetClientConn, a client with the same name is replaced
+func AddClient(config ClientConfig) error {
+	return internalGrpc.GetManager().AddClient(config)
+}
+
+// SetServingStatus - report the service of the server as serving or not in the grpc.health.v1.Health service
+// e.g. a service is not serving while its database is down, the statuses are reset when the server is started
+func SetServingStatus(serverName string, service string, serving bool) error {
+	srv, err := internalGrpc.GetManager().GetServerByName(serverName)
+	if err != nil {
+		return err
+	}
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	srv.HealthServer().SetServingStatus(service, status)
+	return nil
+}
+
+// FieldViolation - a failed rule of a field of a message, it is sent in the `BadRequest` details of the `InvalidArgument` status
+type FieldViolation = utils.FieldViolation
+
+// RegisterMessageValidator - add a validator of the messages, e.g. a protovalidate runtime for the `buf.validate` annotations
+// It is used by the `validation` interceptor and the binding of the http requests after the generated validation methods
+func RegisterMessageValidator(v func(msg any) ([]FieldViolation, error)) {
+	utils.RegisterMessageValidator(v)
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"github.com/Blocktunium/gonyx/internal/http"
+	"github.com/gin-gonic/gin"
+)
+
+// Binding types
+type (
+	BindingErr           = http.BindingErr
+	BindingFieldError    = http.FieldError
+	BindingErrorResponse = http.BindingErrorResponse
+)
+
+// Bind - bind the request into a new value of T by its content type and validate it
+// The validation errors are translated to the locale of the request, see the i18n middleware
+func Bind[T any](c *gin.Context) (T, error) {
+	var obj T
+	err := http.BindRequest(c, &obj)
+	return obj, err
+}
+
+// BindRequest - bind the request into obj by its content type and validate it
+func BindRequest(c *gin.Context, obj any) error {
+	return http.BindRequest(c, obj)
+}
+
+// AbortWithBindingError - answer 400 with the translated validation errors of the fields
+func AbortWithBindingError(c *gin.Context, err error) {
+	http.AbortWithBindingError(c, err)
+}
@@ -1,8 +1,17 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	gohttp "net/http"
+	"time"
+
 	"github.com/Blocktunium/gonyx/internal/http"
+	"github.com/Blocktunium/gonyx/internal/http/middlewares"
+	"github.com/Blocktunium/gonyx/internal/http/proxy"
+	"github.com/Blocktunium/gonyx/internal/http/types"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,6 +29,15 @@ const (
 	methodUse     = "USE"
 )
 
+// ResponseCacheOptions - options of the per-route response cache
+type ResponseCacheOptions = types.ResponseCacheMiddlewareConfig
+
+// ResponseCacheStore - storage used by the response cache
+type ResponseCacheStore = middlewares.ResponseCacheStore
+
+// ProxyOptions - options of a route which forwards the requests to a pool of upstreams
+type ProxyOptions = types.ProxyRouteConfig
+
 // HttpRoute - Structure of the route
 type HttpRoute struct {
 	Method     string
@@ -29,6 +47,58 @@ type HttpRoute struct {
 	GroupNames []string
 	F          func(c *gin.Context)
 	Servers    []string
+	Cache      *ResponseCacheOptions // caches the GET responses of the route if it is set
+	Timeout    time.Duration         // cancels the request context and answers 503 after the timeout if it is set
+	Proxy      *ProxyOptions         // forwards the requests to the upstreams instead of calling F if it is set
+}
+
+// handler - return the route handler, wrapped by the timeout and the response cache if they are set
+// The proxy of the route is returned too, it must be closed if the route is not added
+func (r HttpRoute) handler() (func(c *gin.Context), *proxy.Proxy, error) {
+	f := r.F
+	var p *proxy.Proxy
+	if r.Proxy != nil {
+		options := *r.Proxy
+		if options.Name == "" {
+			options.Name = r.RouteName
+		}
+		var err error
+		p, err = proxy.New(options)
+		if err != nil {
+			return nil, nil, err
+		}
+		f = p.Handler()
+	}
+	if r.Timeout > 0 {
+		f = middlewares.TimeoutHandler(r.Timeout, f)
+	}
+	if r.Cache != nil {
+		f = middlewares.ResponseCacheHandler(r.Cache, r.RouteName, f)
+	}
+	return f, p, nil
+}
+
+// add - add the route to its servers, its proxy is closed with the servers
+func (r HttpRoute) add() error {
+	f, p, err := r.handler()
+	if err != nil {
+		return err
+	}
+	err = http.GetManager().AddRoute(r.Method,
+		r.Path,
+		f,
+		r.RouteName,
+		r.Versions,
+		r.GroupNames,
+		r.Servers...)
+	if p != nil {
+		if err != nil {
+			p.Close()
+		} else {
+			http.GetManager().AddRouteProxy(p)
+		}
+	}
+	return err
 }
 
 // HttpGroup - Structure of the group
@@ -41,13 +111,7 @@ type HttpGroup struct {
 
 // AddHttpRouteByObj - add route by HttpRoute obj
 func AddHttpRouteByObj(httpRoute HttpRoute) error {
-	return http.GetManager().AddRoute(httpRoute.Method,
-		httpRoute.Path,
-		httpRoute.F,
-		httpRoute.RouteName,
-		httpRoute.Versions,
-		httpRoute.GroupNames,
-		httpRoute.Servers...)
+	return httpRoute.add()
 }
 
 // AddHttpRoute - Add route by parameters
@@ -64,14 +128,7 @@ func AddHttpRoute(method string, path string, f func(c *gin.Context), routeName
 // AddBulkHttpRoutes - add bulk http routes to the server
 func AddBulkHttpRoutes(httpRoutes []HttpRoute) error {
 	for _, httpRoute := range httpRoutes {
-		err := http.GetManager().AddRoute(httpRoute.Method,
-			httpRoute.Path,
-			httpRoute.F,
-			httpRoute.RouteName,
-			httpRoute.Versions,
-			httpRoute.GroupNames,
-			httpRoute.Servers...)
-		if err != nil {
+		if err := httpRoute.add(); err != nil {
 			return err
 		}
 	}
@@ -120,6 +177,75 @@ func AttachHttpErrorHandler(f func(ctx *gin.Context, err any), serverNames ...st
 	return http.GetManager().AttachErrorHandler(f, serverNames...)
 }
 
+// AbortWithProblem - answer the error with problem+json and the http status of its code, see the `errs` package
+// The errors which are added by `c.Error` without a response are answered the same way
+func AbortWithProblem(c *gin.Context, err error) {
+	middlewares.AbortWithProblem(c, err)
+}
+
+// MountHandler - serve the requests of the prefix which are not matched by a route by the handler, e.g. a gRPC-Gateway mux
+// The middlewares of the servers run before the handler
+func MountHandler(prefix string, handler gohttp.Handler, serverName ...string) error {
+	return http.GetManager().MountHandler(prefix, handler, serverName...)
+}
+
+// RegisterMiddleware - register a middleware to be referenced by name and ordered in `middlewares.order` of http config
+// The factory receives `middlewares.<name>` (nil if absent), it must be called before the servers are created
+func RegisterMiddleware(name string, factory func(rawConfig map[string]any) (gin.HandlerFunc, error)) error {
+	return http.RegisterMiddleware(name, factory)
+}
+
+// RegisterCompressionEncoder - register a content encoding (e.g. "deflate") to be usable in the `compress` middleware config
+func RegisterCompressionEncoder(name string, factory func(w io.Writer, level int) (io.WriteCloser, error)) {
+	middlewares.RegisterCompressionEncoder(name, factory)
+}
+
+// RegisterResponseCacheStore - register a store to be referenced by name in the response cache config
+func RegisterResponseCacheStore(name string, store ResponseCacheStore) {
+	middlewares.RegisterResponseCacheStore(name, store)
+}
+
+// InvalidateCacheByRoute - invalidate all cached responses of the routes, only the responses of the tenant of the context are invalidated
+func InvalidateCacheByRoute(ctx context.Context, routeNames ...string) error {
+	return middlewares.InvalidateResponseCacheRoutes(ctx, routeNames...)
+}
+
+// InvalidateCacheByTag - invalidate all cached responses that carry one of the tags, only the responses of the tenant of the context are invalidated
+func InvalidateCacheByTag(ctx context.Context, tags ...string) error {
+	return middlewares.InvalidateResponseCacheTags(ctx, tags...)
+}
+
+// CsrfToken - return the CSRF token of the request to be rendered in forms
+func CsrfToken(c *gin.Context) string {
+	return middlewares.CsrfToken(c)
+}
+
+// HTTP3Server - an HTTP/3 server running alongside the TLS listener, e.g. `http3.Server` of quic-go
+type HTTP3Server = http.HTTP3Server
+
+// RegisterHTTP3ServerFactory - register the HTTP/3 implementation used by servers with `protocols.http3.enabled`
+// It must be called before the servers are started, since gonyx ships no QUIC transport, e.g. with quic-go:
+//
+//	http.RegisterHTTP3ServerFactory(func(addr string, tlsConfig *tls.Config, handler gohttp.Handler) (http.HTTP3Server, error) {
+//		return &http3.Server{Addr: addr, TLSConfig: http3.ConfigureTLSConfig(tlsConfig), Handler: handler}, nil
+//	})
+func RegisterHTTP3ServerFactory(factory func(addr string, tlsConfig *tls.Config, handler gohttp.Handler) (HTTP3Server, error)) {
+	http.RegisterHTTP3ServerFactory(factory)
+}
+
+// VersionedRoute - a route of a server with the api version it belongs to
+type VersionedRoute = http.VersionedRoute
+
+// GetVersionedRoutes - return the routes with their api versions, only of the versions if any is given
+func GetVersionedRoutes(versions ...string) []VersionedRoute {
+	return http.GetManager().GetVersionedRoutes(versions...)
+}
+
+// ApiVersion - return the api version selected for the request by the versioning strategy of the server
+func ApiVersion(c *gin.Context) string {
+	return http.ApiVersion(c)
+}
+
 // PrintAllRoutes - Print all routes on the screen
 func PrintAllRoutes() {
 	routes := http.GetManager().GetAllRoutes()
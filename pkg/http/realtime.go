@@ -0,0 +1,95 @@
+package http
+
+import (
+	"github.com/Blocktunium/gonyx/internal/http"
+	"github.com/Blocktunium/gonyx/internal/http/realtime"
+)
+
+// Realtime types
+type (
+	WebSocketConn    = realtime.Conn
+	WebSocketOptions = realtime.WebSocketOptions
+	SSEStream        = realtime.SSEStream
+	SSEOptions       = realtime.SSEOptions
+	Hub              = realtime.Hub
+	HubMessage       = realtime.Message
+	HubClient        = realtime.Client
+	HubBroker        = realtime.Broker
+)
+
+// WebSocket message types
+const (
+	TextMessage   = realtime.TextMessage
+	BinaryMessage = realtime.BinaryMessage
+)
+
+// WebSocketRoute - Structure of the websocket route
+type WebSocketRoute struct {
+	Path       string
+	RouteName  string
+	Versions   []string
+	GroupNames []string
+	Options    *WebSocketOptions // nil means the server `websocket` config is used
+	F          func(conn *WebSocketConn)
+	Servers    []string
+}
+
+// SSERoute - Structure of the server-sent events route
+type SSERoute struct {
+	Path       string
+	RouteName  string
+	Versions   []string
+	GroupNames []string
+	Options    *SSEOptions // nil means the server `sse` config is used
+	F          func(stream *SSEStream)
+	Servers    []string
+}
+
+// AddWebSocketRouteByObj - add websocket route by WebSocketRoute obj
+func AddWebSocketRouteByObj(route WebSocketRoute) error {
+	return http.GetManager().AddWebSocketRoute(route.Path,
+		route.F,
+		route.Options,
+		route.RouteName,
+		route.Versions,
+		route.GroupNames,
+		route.Servers...)
+}
+
+// AddWebSocketRoute - Add websocket route by parameters
+func AddWebSocketRoute(path string, f func(conn *WebSocketConn), routeName string, versions []string, groupNames []string, serverName ...string) error {
+	return http.GetManager().AddWebSocketRoute(path,
+		f,
+		nil,
+		routeName,
+		versions,
+		groupNames,
+		serverName...)
+}
+
+// AddSSERouteByObj - add server-sent events route by SSERoute obj
+func AddSSERouteByObj(route SSERoute) error {
+	return http.GetManager().AddSSERoute(route.Path,
+		route.F,
+		route.Options,
+		route.RouteName,
+		route.Versions,
+		route.GroupNames,
+		route.Servers...)
+}
+
+// AddSSERoute - Add server-sent events route by parameters
+func AddSSERoute(path string, f func(stream *SSEStream), routeName string, versions []string, groupNames []string, serverName ...string) error {
+	return http.GetManager().AddSSERoute(path,
+		f,
+		nil,
+		routeName,
+		versions,
+		groupNames,
+		serverName...)
+}
+
+// GetHub - return the hub with the name to group live connections in rooms and broadcast to them
+func GetHub(name string) *Hub {
+	return http.GetManager().GetHub(name)
+}
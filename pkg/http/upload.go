@@ -0,0 +1,60 @@
+package http
+
+import (
+	"time"
+
+	"github.com/Blocktunium/gonyx/internal/http"
+	"github.com/Blocktunium/gonyx/internal/http/upload"
+	"github.com/gin-gonic/gin"
+)
+
+// Upload types
+type (
+	UploadedFile           = upload.UploadedFile
+	MultipartOptions       = upload.MultipartOptions
+	MultipartResult        = upload.MultipartResult
+	ResumableUploadOptions = upload.ResumableOptions
+)
+
+// ReceiveMultipartUpload - stream the files of the multipart request to the storage backend of the options
+func ReceiveMultipartUpload(c *gin.Context, options MultipartOptions) (*MultipartResult, error) {
+	return upload.ReceiveMultipart(c, options)
+}
+
+// AbortWithUploadError - answer the request with the status of the upload error, e.g. 413 for a large file
+func AbortWithUploadError(c *gin.Context, err error) {
+	upload.AbortWithError(c, err)
+}
+
+// AddResumableUploadRoutes - add the routes of the resumable uploads (tus 1.0) on the path
+// The uploads are created by POST on the path and resumed by HEAD and PATCH on "<path>/:id"
+func AddResumableUploadRoutes(path string, options ResumableUploadOptions, versions []string, groupNames []string, serverName ...string) error {
+	resumable, err := upload.NewResumable(options)
+	if err != nil {
+		return err
+	}
+
+	uploadPath := path + "/:id"
+	routes := []struct {
+		method string
+		path   string
+		f      func(c *gin.Context)
+	}{
+		{MethodOptions, path, resumable.Options},
+		{MethodPost, path, resumable.Create},
+		{MethodHead, uploadPath, resumable.Head},
+		{MethodPatch, uploadPath, resumable.Patch},
+		{MethodDelete, uploadPath, resumable.Delete},
+	}
+	for _, route := range routes {
+		if err := http.GetManager().AddRoute(route.method, route.path, route.f, "", versions, groupNames, serverName...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignedDownloadURL - return a url of the downloads route which serves the object of the key until the ttl is passed
+func SignedDownloadURL(key string, ttl time.Duration, serverName ...string) (string, error) {
+	return http.GetManager().SignedDownloadURL(key, ttl, serverName...)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"html/template"
+	"io/fs"
+
+	"github.com/Blocktunium/gonyx/internal/http"
+)
+
+// SetTemplatesFS - read the html templates from the files instead of the disk, e.g. an embed.FS of a production build
+// The root of the templates config is the directory in the files
+func SetTemplatesFS(fsys fs.FS, serverName ...string) error {
+	return http.GetManager().SetTemplatesFS(fsys, serverName...)
+}
+
+// SetStaticFS - read the static files from the files instead of the disk, e.g. an embed.FS of a production build
+// The root of the static config is the directory in the files
+func SetStaticFS(fsys fs.FS, serverName ...string) error {
+	return http.GetManager().SetStaticFS(fsys, serverName...)
+}
+
+// AddTemplateFuncs - add the functions to the html templates, the `asset` function is always available
+func AddTemplateFuncs(funcs template.FuncMap, serverName ...string) error {
+	return http.GetManager().AddTemplateFuncs(funcs, serverName...)
+}
+
+// AssetURL - return the url of the static file, it is the fingerprinted url if the fingerprint is enabled
+func AssetURL(name string, serverName ...string) string {
+	return http.GetManager().AssetURL(name, serverName...)
+}
@@ -0,0 +1,44 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Blocktunium/gonyx/internal/httpclient"
+	"github.com/gin-gonic/gin"
+)
+
+// Http client types
+type (
+	Client               = httpclient.Client
+	Config               = httpclient.Config
+	AuthConfig           = httpclient.AuthConfig
+	RetryConfig          = httpclient.RetryConfig
+	CircuitBreakerConfig = httpclient.CircuitBreakerConfig
+	CircuitOpenErr       = httpclient.CircuitOpenErr
+)
+
+// Get - return the client of the upstream by name, e.g. a client of the httpclient config
+func Get(name string) (*Client, error) {
+	return httpclient.GetManager().GetClient(name)
+}
+
+// Add - create a client of the config and register it by its name
+func Add(config Config) (*Client, error) {
+	return httpclient.GetManager().AddClient(config)
+}
+
+// New - create a client of the config without registering it
+func New(config Config) (*Client, error) {
+	return httpclient.New(config)
+}
+
+// FromGin - return the context of the inbound request which carries its request id and trace context to the outbound calls
+func FromGin(c *gin.Context) context.Context {
+	return httpclient.FromGin(c)
+}
+
+// WithInboundHeaders - return a copy of the context which carries the headers to propagate to the outbound calls
+func WithInboundHeaders(ctx context.Context, header http.Header) context.Context {
+	return httpclient.WithInboundHeaders(ctx, header)
+}
@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/Blocktunium/gonyx/internal/i18n"
+	"google.golang.org/grpc"
+)
+
+// T - return the message of the key in the locale of the context, e.g. the context of a request or a grpc call
+func T(ctx context.Context, key string, params map[string]any) string {
+	return i18n.GetManager().Translate(i18n.LocaleFromContext(ctx), key, params)
+}
+
+// N - return the plural form of the message for the count in the locale of the context, `{count}` is replaced by the count
+func N(ctx context.Context, key string, count int, params map[string]any) string {
+	return i18n.GetManager().TranslatePlural(i18n.LocaleFromContext(ctx), key, count, params)
+}
+
+// Translate - return the message of the key in the locale, the key itself is returned if no catalog has the message
+func Translate(locale string, key string, params map[string]any) string {
+	return i18n.GetManager().Translate(locale, key, params)
+}
+
+// TranslatePlural - return the plural form of the message for the count in the locale
+func TranslatePlural(locale string, key string, count int, params map[string]any) string {
+	return i18n.GetManager().TranslatePlural(locale, key, count, params)
+}
+
+// Locale - return the locale of the context, or the default locale if it has none
+func Locale(ctx context.Context) string {
+	return i18n.LocaleFromContext(ctx)
+}
+
+// WithLocale - return a copy of the context which carries the locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return i18n.WithLocale(ctx, locale)
+}
+
+// Negotiate - return the best supported locale of an `Accept-Language` value
+func Negotiate(acceptLanguage string) string {
+	return i18n.GetManager().Negotiate(acceptLanguage)
+}
+
+// Locales - return the supported locales, the default locale is the first one
+func Locales() []string {
+	return i18n.GetManager().Locales()
+}
+
+// AddMessages - add the messages of the locale, the nested maps are flattened to dot separated keys
+func AddMessages(locale string, messages map[string]any) error {
+	return i18n.GetManager().AddMessages(locale, messages)
+}
+
+// LoadFS - load the catalogs of the directory in the files, e.g. an embed.FS of a production build
+func LoadFS(fsys fs.FS, dir string) error {
+	return i18n.GetManager().LoadFS(fsys, dir)
+}
+
+// Reload - load the catalogs of all sources again
+func Reload() error {
+	return i18n.GetManager().Reload()
+}
+
+// UnaryServerInterceptor - negotiate the locale of a grpc call by its metadata and put it into the context
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return i18n.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor - negotiate the locale of a grpc stream by its metadata and put it into the context
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return i18n.StreamServerInterceptor()
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"github.com/Blocktunium/gonyx/internal/logger"
 	"github.com/Blocktunium/gonyx/internal/logger/types"
 	"time"
@@ -51,6 +52,21 @@ func Log(object *LogObject) *LogError {
 	return &p
 }
 
+// LogWithContext - write log object to the channel with the tenant of the context
+func LogWithContext(ctx context.Context, object *LogObject) *LogError {
+	l, err := logger.GetManager().GetLoggerWithContext(ctx)
+	if err == nil {
+		if l.IsInitialized() {
+			p := types.LogObject(*object)
+			l.Log(&p)
+		}
+		return nil
+	}
+
+	p := LogError(*err)
+	return &p
+}
+
 // Sync - sync all logs to medium
 func Sync() *LogError {
 	l, err := logger.GetManager().GetLogger()
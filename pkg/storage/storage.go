@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"github.com/Blocktunium/gonyx/internal/storage"
+)
+
+// Storage types
+type (
+	Backend    = storage.Backend
+	ObjectInfo = storage.ObjectInfo
+	PutOptions = storage.PutOptions
+	S3Config   = storage.S3Config
+)
+
+// GetBackend - return the backend by name, or the default backend of the storage config if no name is given
+func GetBackend(name ...string) (Backend, error) {
+	return storage.GetManager().GetBackend(name...)
+}
+
+// RegisterBackend - register a backend to be referenced by name, e.g. by the downloads route
+func RegisterBackend(name string, backend Backend) {
+	storage.GetManager().RegisterBackend(name, backend)
+}
+
+// NewLocalBackend - create a backend which stores the objects under the root directory
+func NewLocalBackend(root string) (Backend, error) {
+	backend, err := storage.NewLocalBackend(root)
+	if err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+// NewS3Backend - create a backend which stores the objects in a bucket of an S3-compatible server
+func NewS3Backend(config S3Config) (Backend, error) {
+	backend, err := storage.NewS3Backend(config)
+	if err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
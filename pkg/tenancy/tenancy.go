@@ -0,0 +1,46 @@
+package tenancy
+
+import (
+	"context"
+
+	"github.com/Blocktunium/gonyx/internal/db"
+	"github.com/Blocktunium/gonyx/internal/tenancy"
+	"gorm.io/gorm"
+)
+
+// Tenancy types
+type (
+	Tenant            = tenancy.Tenant
+	DBConfig          = tenancy.DBConfig
+	TenantNotFoundErr = tenancy.TenantNotFoundErr
+)
+
+// FromContext - return the tenant id of the context, e.g. the context of a request which is resolved by the tenancy middleware
+func FromContext(ctx context.Context) (string, bool) {
+	return tenancy.FromContext(ctx)
+}
+
+// WithTenant - return a copy of the context which carries the tenant id, e.g. for a background job of a tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return tenancy.WithTenant(ctx, tenantID)
+}
+
+// KeyPrefix - return the cache key prefix of the tenant of the context
+func KeyPrefix(ctx context.Context) string {
+	return tenancy.KeyPrefix(ctx)
+}
+
+// GetTenant - return the tenant by id
+func GetTenant(id string) (Tenant, error) {
+	return tenancy.GetManager().GetTenant(id)
+}
+
+// RegisterTenant - register a tenant, e.g. a tenant which is loaded from a database
+func RegisterTenant(tenant Tenant) {
+	tenancy.GetManager().RegisterTenant(tenant)
+}
+
+// Scope - return the instance with the schema and the row filter of the tenant
+func Scope(instance *gorm.DB, tenant Tenant) *gorm.DB {
+	return db.TenantScope(instance, tenant)
+}